@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
+	"github.com/lyeskara/testmcp/internal/converter"
 	"github.com/lyeskara/testmcp/internal/generator"
 )
 
@@ -13,11 +17,64 @@ func main() {
 
 	// Define command-line flags
 	inputFile := flag.String("input", "", "Path to the OpenAPI specification file (JSON or YAML)")
+	specFormat := flag.String("spec-format", "auto", "Format of the specification file: auto (detected from its content, whatever the extension), json or yaml")
+	overlayPath := flag.String("overlay", "", "Path to an OpenAPI Overlay document applied to the spec before conversion")
+	allowRemoteRefs := flag.Bool("allow-remote-refs", false, "Fetch $refs to http(s) URLs while parsing the spec instead of failing on them (only local file refs are resolved by default)")
 	outputDir := flag.String("output", "", "Path to the output MCP server directory")
 
 	validation := flag.Bool("validation", false, "Enable OpenAPI validation")
-	packageName := flag.String("package", "mcpgen", "Generated package name")
+	packageName := flag.String("package", "", "Generated package name (defaults to one derived from the spec's info.title)")
 	includes := flag.String("includes", "", "Comma-separated list of includes for the generated code")
+	bestEffortClient := flag.Bool("best-effort-client", false, "Warn and continue instead of failing when the HTTP client selected by -includes cannot be generated")
+	goGenerate := flag.Bool("go-generate", false, "Write generate.go with a go:generate directive that reruns mcpgen with the flags of this run (an existing generate.go is kept)")
+	manifest := flag.Bool("manifest", false, "Write a TOOLS.md manifest describing the generated tools")
+	retries := flag.Int("retries", 0, "Retry idempotent outbound requests this many times with exponential backoff (0 disables retries)")
+	retryBaseDelay := flag.Duration("retry-base-delay", 200*time.Millisecond, "Delay before the first retry; doubles on each attempt")
+	maxTools := flag.Int("max-tools", 500, "Fail when the spec yields more than this many tools (0 disables the limit)")
+	concurrency := flag.Int("concurrency", 0, "Number of tool files generated in parallel (0 uses GOMAXPROCS)")
+	parts := flag.String("parts", "", "Comma-separated parts of the output to generate, e.g. server to iterate on the server wiring: server, testclient, register, runtime, tools, helpers, manifest or client (all when empty)")
+	only := flag.String("only", "", "Generate a single operation, selected by operationId or \"METHOD /path\"")
+	deprecated := flag.String("deprecated", "mark", "How to handle deprecated operations: include, mark (prefix the description with [DEPRECATED] and add the x-sunset removal date) or exclude")
+	emptySchemas := flag.String("empty-schemas", "keep", "How to handle object properties with an empty or null-only schema: keep, drop or annotate (describe them as \"any value\")")
+	getRequestBody := flag.String("get-request-body", "honor", "How request bodies on GET and HEAD operations are handled: honor (send them) or drop (leave them out with a warning)")
+	unknownFormats := flag.String("unknown-formats", "passthrough", "How schema formats that are not JSON Schema or OpenAPI formats (e.g. decimal) are handled: passthrough, warn (pass them through with a warning) or map (replace them using -format-map, warning about unmapped ones)")
+	formatMap := flag.String("format-map", "", "Comma-separated format replacements applied with -unknown-formats map, e.g. decimal=double,money= (an empty replacement drops the format)")
+	formatsToPatterns := flag.Bool("formats-to-patterns", false, "Add an equivalent pattern to strings with a well-known format (uuid, email, date, date-time, ...) so validators that ignore format still enforce it")
+	discriminatorIfThen := flag.Bool("discriminator-if-then", false, "Emit oneOf unions with a discriminator as an if/then/else chain keyed on the discriminator property, so validators select exactly one branch")
+	descriptionStrategy := flag.String("description-strategy", "prefer-site", "How a property's description is combined with the description of the schema it references: prefer-site (the property's, falling back to the referenced one) or concatenate (both, property's first)")
+	mergeAllOf := flag.Bool("merge-allof", false, "Flatten allOf compositions of object schemas into one object, so fields required by any branch are required at the top level")
+	simplifyCombinators := flag.Bool("simplify-combinators", false, "Inline oneOf, anyOf and allOf compositions with a single branch into the schema holding them when equivalent")
+	normalizeEnumNames := flag.Bool("normalize-enum-names", false, "Name the HTTP client's enum constants after string enum values with whitespace trimmed and consistent casing (the values themselves are kept verbatim)")
+	additionalProperties := flag.String("additional-properties", "permissive", "How input schemas treat request objects that leave additionalProperties unset: permissive (allow extra properties) or strict (emit additionalProperties: false)")
+	responseCodes := flag.String("response-codes", "", "Comma-separated status codes to document in response templates: codes (200), ranges (400-404), classes (4xx) or default (all when empty)")
+	excludeContentTypes := flag.String("exclude-response-content-types", "", "Comma-separated media types left out of response templates, e.g. text/html or text/* (none by default)")
+	synthesizeExamples := flag.Bool("synthesize-response-examples", false, "Add an example built from the schema to JSON response templates that document no examples")
+	schemaIDPrefix := flag.String("schema-id-prefix", "", "Add an $id to each tool input schema: this prefix followed by the operationId (off when empty)")
+	schemaDraft := flag.String("schema-draft", "7", "JSON Schema draft of generated input schemas: 7, or 2020-12 to also emit newer keywords (minContains and maxContains, and dependentRequired and dependentSchemas instead of dependencies)")
+	schemaIndent := flag.String("schema-indent", "2", "Indentation of generated input schemas: a number of spaces, tab, or compact (no whitespace)")
+	schemaFormat := flag.String("schema-format", "inline", "How tool input schemas and response templates are written: inline (Go constants) or json (files under mcptools/schemas embedded with go:embed)")
+	mcpAlias := flag.String("mcp-alias", "", "Import mcp-go's mcp package under this alias in generated files, to avoid clashing with an existing mcp identifier")
+	sortByTag := flag.Bool("sort-by-tag", false, "Register tools in server.go ordered by their first tag, then by name, instead of by name only")
+	serviceInterface := flag.Bool("service-interface", false, "Generate a Service interface with a method per tool and register tools through it")
+	tracing := flag.Bool("tracing", false, "Wrap tool calls in OpenTelemetry spans recorded with a user-provided Tracer (adds a go.opentelemetry.io/otel dependency)")
+	responseTypes := flag.Bool("response-types", false, "Generate a type per JSON success response and Parse<Tool>Response functions decoding response bodies into them")
+	mockServer := flag.Bool("mock-server", false, "Generate mockserver/main.go, a command serving each operation's example response for testing the tools offline")
+	testClient := flag.Bool("test-client", false, "Generate NewTestClient, which connects a client to the generated server in memory for integration tests")
+	overrides := flag.String("overrides", "", "Path to a JSON or YAML file mapping operationIds to tool name, description and request content type overrides")
+	glossaryPath := flag.String("glossary", "", "Path to a JSON or YAML file mapping parameter and property names (or paths such as body.owner.name) to descriptions used where the spec has none")
+	omitDescriptions := flag.Bool("omit-schema-descriptions", false, "Leave descriptions out of generated input schemas to save tokens")
+	omitExamples := flag.Bool("omit-schema-examples", false, "Leave examples out of generated input schemas to save tokens")
+	inputSchemaExamples := flag.Bool("input-schema-examples", false, "Add the request body examples to the root of generated input schemas as complete tool inputs")
+	describeConstraints := flag.Bool("describe-constraints", false, "Append bounds, patterns, enums and defaults to property descriptions in generated input schemas")
+	describeContentTypes := flag.Bool("describe-content-types", false, "Append the content types each tool consumes and produces to its description")
+	transport := flag.String("transport", "stdio", "Transport the generated server is served over: stdio, sse or http (streamable HTTP)")
+	healthPath := flag.String("health-path", "/healthz", "Liveness probe path mounted by the sse and http transports")
+	readyPath := flag.String("ready-path", "/readyz", "Readiness probe path mounted by the sse and http transports")
+	verifyBuild := flag.Bool("verify-build", false, "Run go build on the generated code and fail with the offending files if it does not compile (slower; the output must be inside a Go module with its dependencies)")
+	strict := flag.Bool("strict", false, "Fail when the spec uses features that cannot be represented instead of silently skipping them")
+	prune := flag.Bool("prune", false, "Delete generated tool files for operations that are no longer in the spec")
+	watch := flag.Bool("watch", false, "Keep running and regenerate whenever the input specification, its overlay or a local file its $refs point to changes")
+	maxResponseBytes := flag.Int("max-response-bytes", 0, "Truncate tool response text to this many bytes (0 disables truncation)")
 
 	// Parse command-line flags
 	flag.Parse()
@@ -44,11 +101,176 @@ func main() {
 		}
 	}
 
-	generator, err := generator.NewGenerator(*inputFile, *validation, *packageName, *outputDir)
+	specFormatMode, err := converter.ParseSpecFormat(*specFormat)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	deprecatedMode, err := converter.ParseDeprecatedMode(*deprecated)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	emptySchemaMode, err := converter.ParseEmptySchemaMode(*emptySchemas)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	additionalPropertiesMode, err := converter.ParseAdditionalPropertiesMode(*additionalProperties)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	schemaDraftMode, err := converter.ParseSchemaDraft(*schemaDraft)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	descriptionStrategyMode, err := converter.ParseDescriptionStrategy(*descriptionStrategy)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	getRequestBodyMode, err := converter.ParseGetRequestBodyMode(*getRequestBody)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	unknownFormatMode, err := converter.ParseUnknownFormatMode(*unknownFormats)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	formatMappings, err := converter.ParseFormatMappings(*formatMap)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	schemaIndentUnit, err := generator.ParseSchemaIndent(*schemaIndent)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	selectedParts, err := generator.ParseParts(*parts)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	responseCodeSet, err := converter.ParseResponseCodes(*responseCodes)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	excludedContentTypes, err := converter.ParseContentTypes(*excludeContentTypes)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var toolOverrides map[string]converter.ToolOverride
+	if *overrides != "" {
+		toolOverrides, err = converter.LoadToolOverrides(*overrides)
+		if err != nil {
+			fmt.Printf("Error loading tool overrides: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var glossary map[string]string
+	if *glossaryPath != "" {
+		glossary, err = converter.LoadGlossary(*glossaryPath)
+		if err != nil {
+			fmt.Printf("Error loading glossary: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	options := converter.ConvertOptions{
+		OmitSchemaDescriptions:      *omitDescriptions,
+		OmitSchemaExamples:          *omitExamples,
+		InputSchemaExamples:         *inputSchemaExamples,
+		DescribeConstraints:         *describeConstraints,
+		DescribeContentTypes:        *describeContentTypes,
+		DeprecatedOperations:        deprecatedMode,
+		EmptySchemas:                emptySchemaMode,
+		AdditionalProperties:        additionalPropertiesMode,
+		DiscriminatorIfThen:         *discriminatorIfThen,
+		DescriptionStrategy:         descriptionStrategyMode,
+		MergeAllOf:                  *mergeAllOf,
+		NormalizeEnumNames:          *normalizeEnumNames,
+		SimplifyCombinators:         *simplifyCombinators,
+		GetRequestBodies:            getRequestBodyMode,
+		UnknownFormats:              unknownFormatMode,
+		FormatMappings:              formatMappings,
+		TranslateFormatsToPatterns:  *formatsToPatterns,
+		SchemaIDPrefix:              *schemaIDPrefix,
+		SchemaDraft:                 schemaDraftMode,
+		ResponseCodes:               responseCodeSet,
+		ExcludeResponseContentTypes: excludedContentTypes,
+		SynthesizeResponseExamples:  *synthesizeExamples,
+		ResponseSchemas:             *responseTypes,
+		ToolOverrides:               toolOverrides,
+		Glossary:                    glossary,
+		SpecFormat:                  specFormatMode,
+		OverlayPath:                 *overlayPath,
+		AllowRemoteRefs:             *allowRemoteRefs,
+	}
+	generator, err := generator.NewGeneratorWithOptions(*inputFile, *validation, *packageName, *outputDir, options)
 	if err != nil {
 		fmt.Printf("Error creating generator: %v\n", err)
 		os.Exit(1)
 	}
+	generator.Manifest = *manifest
+	generator.GoGenerate = *goGenerate
+	generator.GenerateFlags = generateFlags()
+	generator.MaxResponseBytes = *maxResponseBytes
+	generator.RetryCount = *retries
+	generator.RetryBaseDelay = *retryBaseDelay
+	generator.Concurrency = *concurrency
+	generator.MaxTools = *maxTools
+	generator.OnlyOperation = *only
+	generator.Parts = selectedParts
+	generator.ServiceInterface = *serviceInterface
+	generator.SortByTag = *sortByTag
+	generator.TestClient = *testClient
+	generator.ResponseTypes = *responseTypes
+	generator.MockServer = *mockServer
+	generator.Tracing = *tracing
+	generator.MCPImportAlias = *mcpAlias
+	generator.SchemaFormat = *schemaFormat
+	generator.SchemaIndent = schemaIndentUnit
+	generator.Strict = *strict
+	generator.VerifyBuild = *verifyBuild
+	generator.Prune = *prune
+	generator.BestEffortHTTPClient = *bestEffortClient
+	generator.Transport = *transport
+	generator.HealthPath = *healthPath
+	generator.ReadyPath = *readyPath
 
 	// Generate the HTTP CLIENT
 	if *includes != "" {
@@ -67,4 +289,30 @@ func main() {
 	}
 
 	fmt.Printf("Successfully converted OpenAPI specification to MCP configuration: %s\n", *outputDir)
+
+	if *watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Watching %s for changes (Ctrl+C to stop)\n", *inputFile)
+		if err := generator.Watch(ctx, *inputFile); err != nil {
+			fmt.Printf("Error watching specification: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generateFlags returns the flags set on the command line as -name=value arguments for the
+// go:generate directive, leaving out those the generator records itself (-input, -output,
+// -package) and those that only make sense interactively
+func generateFlags() []string {
+	var flags []string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "input", "output", "package", "go-generate", "watch":
+			return
+		}
+		flags = append(flags, "-"+f.Name+"="+f.Value.String())
+	})
+	return flags
 }
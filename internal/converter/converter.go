@@ -1,14 +1,23 @@
 package converter
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // Converter represents an OpenAPI to MCP converter
 type Converter struct {
-	parser  *Parser
-	options ConvertOptions
+	parser   *Parser
+	options  ConvertOptions
+	warnings []string
+	// unsupported collects spec features dropped from the output; location is where conversion currently is
+	unsupported []UnsupportedFeature
+	location    string
 }
 
 
@@ -18,11 +27,17 @@ type ConverterInterface interface {
 
 // NewConverter creates a new OpenAPI to MCP converter
 func NewConverter(parser *Parser) *Converter {
+	return NewConverterWithOptions(parser, ConvertOptions{})
+}
+
+// NewConverterWithOptions creates a new OpenAPI to MCP converter with the given options
+func NewConverterWithOptions(parser *Parser, options ConvertOptions) *Converter {
+	if options.ServerConfig == nil {
+		options.ServerConfig = make(map[string]interface{})
+	}
 	return &Converter{
-		parser: parser,
-		options: ConvertOptions{
-			ServerConfig: make(map[string]interface{}),
-		},
+		parser:  parser,
+		options: options,
 	}
 }
 
@@ -33,10 +48,17 @@ func (c *Converter) Convert() (*MCPConfig, error) {
 		return nil, fmt.Errorf("no OpenAPI document loaded")
 	}
 
+	c.warnings = nil
+	c.unsupported = nil
+
 	// Create the MCP configuration
 	config := &MCPConfig{
 		Server: ServerConfig{
-			Config: c.options.ServerConfig,
+			Config:  c.options.ServerConfig,
+			URL:         c.serverURL(),
+			Version:     c.apiVersion(),
+			URLTemplate: c.serverURLTemplate(),
+			Variables:   c.serverVariables(),
 		},
 		Tools: []Tool{},
 	}
@@ -45,10 +67,18 @@ func (c *Converter) Convert() (*MCPConfig, error) {
 	for path, pathItem := range c.parser.GetPaths() {
 		operations := getOperations(pathItem)
 		for method, operation := range operations {
+			restore := c.at(fmt.Sprintf("%s %s", strings.ToUpper(method), path))
 			tool, err := c.convertOperation(path, method, operation)
 			if err != nil {
+				restore()
 				return nil, fmt.Errorf("failed to convert operation %s %s: %w", method, path, err)
 			}
+			if tool == nil {
+				restore()
+				continue // Skipped, the reason was recorded as a warning
+			}
+			c.checkUnsupportedOperation(pathItem, operation)
+			restore()
 			config.Tools = append(config.Tools, *tool)
 		}
 	}
@@ -58,5 +88,99 @@ func (c *Converter) Convert() (*MCPConfig, error) {
 		return config.Tools[i].Name < config.Tools[j].Name
 	})
 
+	sort.Strings(c.warnings)
+	config.Warnings = c.warnings
+
+	sort.Slice(c.unsupported, func(i, j int) bool {
+		return c.unsupported[i].String() < c.unsupported[j].String()
+	})
+	config.Unsupported = c.unsupported
+
+	if err := applyToolOverrides(config, c.options.ToolOverrides); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
+
+// ErrOperationSkipped is returned by ConvertOperation for operations that do not become a tool,
+// such as deprecated operations excluded by DeprecatedExclude. Warnings explains why.
+var ErrOperationSkipped = errors.New("operation skipped")
+
+// ConvertOperation converts a single operation of the loaded document into a Tool, for pipelines
+// that build their own tool list instead of calling Convert. method is an HTTP method in any case
+// and path the operation's path template, e.g. "/pets/{id}".
+//
+// The returned Tool has:
+//   - Name: the operationId, or one derived from method and path when the operation has none
+//   - Description, and DisplayName when ConvertOptions.ToolOverrides renames the tool
+//   - Args: path, query, header and cookie parameters plus a "body" argument for the request
+//     body, sorted by name
+//   - RawInputSchema: the JSON Schema of Args that MCP clients validate tool calls against
+//   - RequestTemplate: how Args map onto the outbound HTTP request
+//   - Responses: one Markdown response template per documented status code and content type
+//
+// The document is still needed to resolve security schemes and server URLs. Warnings returns the
+// problems found while converting this operation.
+func (c *Converter) ConvertOperation(method, path string, operation *openapi3.Operation) (Tool, error) {
+	if c.parser.GetDocument() == nil {
+		return Tool{}, fmt.Errorf("no OpenAPI document loaded")
+	}
+	if operation == nil {
+		return Tool{}, fmt.Errorf("no operation given for %s %s", strings.ToUpper(method), path)
+	}
+
+	c.warnings = nil
+	c.unsupported = nil
+	restore := c.at(fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+	defer restore()
+
+	tool, err := c.convertOperation(path, strings.ToLower(method), operation)
+	if err != nil {
+		return Tool{}, fmt.Errorf("failed to convert operation %s %s: %w", method, path, err)
+	}
+	if tool == nil {
+		return Tool{}, fmt.Errorf("%w: %s %s", ErrOperationSkipped, strings.ToUpper(method), path)
+	}
+	pathItem := c.parser.GetPaths()[path]
+	if pathItem == nil {
+		pathItem = &openapi3.PathItem{}
+	}
+	c.checkUnsupportedOperation(pathItem, operation)
+	sort.Strings(c.warnings)
+
+	// Overrides for other operations are expected here, so their unknown-operation warnings are dropped
+	config := &MCPConfig{Tools: []Tool{*tool}}
+	if err := applyToolOverrides(config, c.options.ToolOverrides); err != nil {
+		return Tool{}, err
+	}
+	return config.Tools[0], nil
+}
+
+// Warnings returns the warnings collected during the last conversion
+func (c *Converter) Warnings() []string {
+	return c.warnings
+}
+
+// warnMissingSchema records that the schema referenced at the current location has no value,
+// which almost always means an unresolved $ref, and how the converter falls back (e.g. "using an
+// empty schema"). Without the warning the spec error would silently become a permissive schema.
+func (c *Converter) warnMissingSchema(ref, fallback string) {
+	location := c.location
+	if location == "" {
+		location = "schema"
+	}
+	missing := "schema reference has no value"
+	if ref != "" {
+		missing = fmt.Sprintf("unresolved $ref %q", ref)
+	}
+	warning := fmt.Sprintf("%s: %s, %s", location, missing, fallback)
+	if !slices.Contains(c.warnings, warning) {
+		c.warnings = append(c.warnings, warning)
+	}
+}
+
+// warnf records a non-fatal conversion problem for the warning report
+func (c *Converter) warnf(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
@@ -1,9 +1,14 @@
 package converter
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func TestNewConverter(t *testing.T) {
@@ -65,3 +70,137 @@ func TestConverter_Convert_NoDocument(t *testing.T) {
 		t.Fatal("expected error when no OpenAPI document is loaded")
 	}
 }
+
+func TestNewConverterWithOptions(t *testing.T) {
+	parser := NewParser(false)
+	c := NewConverterWithOptions(parser, ConvertOptions{TranslateFormatsToPatterns: true})
+	if c.parser != parser {
+		t.Error("expected parser to be set")
+	}
+	if c.options.ServerConfig == nil {
+		t.Error("expected ServerConfig to be initialized")
+	}
+	if !c.options.TranslateFormatsToPatterns {
+		t.Error("expected TranslateFormatsToPatterns to be preserved")
+	}
+}
+
+const convertOperationSpec = `openapi: 3.0.3
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      summary: Get a pet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+        - {name: fields, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: string}
+    delete:
+      deprecated: true
+      responses:
+        '204': {description: Deleted}
+`
+
+func TestConverter_ConvertOperation(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(convertOperationSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverterWithOptions(parser, ConvertOptions{
+		DeprecatedOperations: DeprecatedExclude,
+		ToolOverrides:        map[string]ToolOverride{"getPet": {Name: "fetch_pet"}, "listPets": {Name: "list"}},
+	})
+	pathItem := parser.GetPaths()["/pets/{id}"]
+
+	tool, err := c.ConvertOperation("GET", "/pets/{id}", pathItem.Get)
+	if err != nil {
+		t.Fatalf("ConvertOperation() error = %v", err)
+	}
+	if tool.Name != "getPet" || tool.RegisteredName() != "fetch_pet" {
+		t.Errorf("Name, RegisteredName() = %q, %q, want getPet, fetch_pet", tool.Name, tool.RegisteredName())
+	}
+	if tool.Method != "GET" || tool.Path != "/pets/{id}" || tool.RequestTemplate.Method != "GET" {
+		t.Errorf("Method, Path, RequestTemplate.Method = %q, %q, %q", tool.Method, tool.Path, tool.RequestTemplate.Method)
+	}
+	if len(tool.Args) != 2 || tool.Args[0].Name != "fields" || tool.Args[1].Name != "id" {
+		t.Errorf("Args = %+v, want fields and id", tool.Args)
+	}
+	var schema struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil || len(schema.Required) != 1 || schema.Required[0] != "id" {
+		t.Errorf("RawInputSchema = %s (%v), want id required", tool.RawInputSchema, err)
+	}
+	if len(tool.Responses) != 1 || tool.Responses[0].StatusCode != 200 {
+		t.Errorf("Responses = %+v, want the 200 template", tool.Responses)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Errorf("Warnings() = %v, want none for the other operations' overrides", c.Warnings())
+	}
+
+	if _, err := c.ConvertOperation("delete", "/pets/{id}", pathItem.Delete); !errors.Is(err, ErrOperationSkipped) {
+		t.Errorf("ConvertOperation() error = %v, want ErrOperationSkipped", err)
+	}
+	if _, err := c.ConvertOperation("GET", "/pets/{id}", nil); err == nil {
+		t.Error("expected an error for a nil operation")
+	}
+	if _, err := NewConverter(NewParser(false)).ConvertOperation("GET", "/pets/{id}", pathItem.Get); err == nil {
+		t.Error("expected an error without a loaded document")
+	}
+}
+
+func TestConvert_WarnsOnUnresolvedRefs(t *testing.T) {
+	missing := func(name string) *openapi3.SchemaRef {
+		return &openapi3.SchemaRef{Ref: "#/components/schemas/" + name}
+	}
+	body := openapi3.NewObjectSchema().
+		WithPropertyRef("owner", missing("Owner")).
+		WithPropertyRef("tags", &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: missing("Tag")}})
+	body.AdditionalProperties = openapi3.AdditionalProperties{Schema: missing("Extra")}
+	pet := openapi3.NewObjectSchema().WithPropertyRef("species", missing("Species"))
+
+	operation := &openapi3.Operation{
+		OperationID: "createPet",
+		Parameters: openapi3.Parameters{
+			{Value: &openapi3.Parameter{Name: "limit", In: "query", Schema: missing("Limit")}},
+			{Ref: "#/components/parameters/Trace"},
+		},
+		RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{Content: openapi3.Content{
+			"application/json": {Schema: &openapi3.SchemaRef{Value: body}},
+			"application/xml":  {Schema: missing("PetXML")},
+		}}},
+		Responses: openapi3.NewResponses(),
+	}
+	operation.Responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.NewContentWithJSONSchema(pet)}})
+	operation.Responses.Set("404", &openapi3.ResponseRef{Ref: "#/components/responses/NotFound"})
+
+	doc := &openapi3.T{OpenAPI: "3.0.3", Info: &openapi3.Info{Title: "Pets", Version: "1.0"}, Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/pets", &openapi3.PathItem{Post: operation})
+	config, err := NewConverter(&Parser{doc: doc}).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	want := []string{
+		`POST /pets parameter limit: unresolved $ref "#/components/schemas/Limit", skipping the parameter`,
+		`POST /pets request body application/json additionalProperties: unresolved $ref "#/components/schemas/Extra", using an empty schema`,
+		`POST /pets request body application/json property owner: unresolved $ref "#/components/schemas/Owner", using an empty schema`,
+		`POST /pets request body application/json property tags items: unresolved $ref "#/components/schemas/Tag", allowing items of any type`,
+		`POST /pets request body application/xml: unresolved $ref "#/components/schemas/PetXML", skipping the content type`,
+		`POST /pets response 200 application/json property species: unresolved $ref "#/components/schemas/Species", leaving it out of the response template`,
+		`POST /pets response 404: unresolved response $ref "#/components/responses/NotFound", no response template is generated`,
+		`POST /pets: unresolved parameter $ref "#/components/parameters/Trace", skipping the parameter`,
+	}
+	if strings.Join(config.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Warnings =\n%s\nwant\n%s", strings.Join(config.Warnings, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// deprecatedPrefix marks the description of tools generated from deprecated operations
+const deprecatedPrefix = "[DEPRECATED]"
+
+// ParseDeprecatedMode parses the include, mark and exclude mode names
+func ParseDeprecatedMode(name string) (DeprecatedMode, error) {
+	switch name {
+	case "mark", "":
+		return DeprecatedMark, nil
+	case "include":
+		return DeprecatedInclude, nil
+	case "exclude":
+		return DeprecatedExclude, nil
+	}
+	return DeprecatedMark, fmt.Errorf("unknown deprecated operations mode %q: use include, mark or exclude", name)
+}
+
+// toolDescription returns the operation's description, marked when it is deprecated and the mode
+// asks for it. Marked descriptions end with the operation's sunset date when it documents one.
+func (c *Converter) toolDescription(operation *openapi3.Operation) string {
+	description := getDescription(operation)
+	if !operation.Deprecated || c.options.DeprecatedOperations != DeprecatedMark {
+		return description
+	}
+	marked := deprecatedPrefix
+	if description != "" {
+		marked += " " + description
+	}
+	if sunset := c.sunset(operation.Extensions); sunset != "" {
+		if description == "" {
+			return marked + " Will be removed after " + sunset + "."
+		}
+		marked += "\n\nWill be removed after " + sunset + "."
+	}
+	return marked
+}
+
+// sunset reads the date a deprecated operation will be removed after from its x-sunset extension,
+// a date or date-time in the formats of RFC 3339 or the Sunset HTTP header (RFC 8594):
+//
+//	x-sunset: 2025-12-31
+//	x-sunset: 2025-12-31T12:00:00Z
+//	x-sunset: Wed, 31 Dec 2025 12:00:00 GMT
+//
+// It returns the date, with the time of day in UTC unless it is midnight, or "" when the operation
+// documents none. Values that are not dates are reported as warnings and ignored.
+func (c *Converter) sunset(extensions map[string]interface{}) string {
+	raw, ok := extensions["x-sunset"]
+	if !ok || raw == nil {
+		return ""
+	}
+	value, _ := raw.(string)
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.DateOnly, time.RFC3339, http.TimeFormat} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			if t.Equal(t.Truncate(24 * time.Hour)) {
+				return t.Format(time.DateOnly)
+			}
+			return t.Format(time.RFC3339)
+		}
+	}
+	c.warnf("%s: ignoring x-sunset: expected a date such as 2025-12-31, got %v", c.location, raw)
+	return ""
+}
@@ -0,0 +1,198 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const deprecatedSpec = `openapi: 3.0.3
+info: {title: Deprecated, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        '200': {description: OK}
+  /animals:
+    get:
+      operationId: listAnimals
+      summary: List animals
+      deprecated: true
+      responses:
+        '200': {description: OK}
+`
+
+func TestConvert_DeprecatedOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     DeprecatedMode
+		wantDesc map[string]string
+	}{
+		{
+			name: "mark",
+			mode: DeprecatedMark,
+			wantDesc: map[string]string{
+				"listAnimals": "[DEPRECATED] List animals",
+				"listPets":    "List pets",
+			},
+		},
+		{
+			name: "include",
+			mode: DeprecatedInclude,
+			wantDesc: map[string]string{
+				"listAnimals": "List animals",
+				"listPets":    "List pets",
+			},
+		},
+		{
+			name: "exclude",
+			mode: DeprecatedExclude,
+			wantDesc: map[string]string{
+				"listPets": "List pets",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(deprecatedSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			config, err := NewConverterWithOptions(parser, ConvertOptions{DeprecatedOperations: tt.mode}).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			if len(config.Tools) != len(tt.wantDesc) {
+				t.Fatalf("expected %d tools, got %+v", len(tt.wantDesc), config.Tools)
+			}
+			for _, tool := range config.Tools {
+				if want, ok := tt.wantDesc[tool.Name]; !ok || tool.Description != want {
+					t.Errorf("tool %s description = %q, want %q", tool.Name, tool.Description, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDeprecatedMode(t *testing.T) {
+	tests := map[string]DeprecatedMode{
+		"":        DeprecatedMark,
+		"mark":    DeprecatedMark,
+		"include": DeprecatedInclude,
+		"exclude": DeprecatedExclude,
+	}
+	for name, want := range tests {
+		got, err := ParseDeprecatedMode(name)
+		if err != nil || got != want {
+			t.Errorf("ParseDeprecatedMode(%q) = (%v, %v), want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseDeprecatedMode("hide"); err == nil {
+		t.Error("expected an error for an unknown mode, got nil")
+	}
+}
+
+const sunsetSpec = `openapi: 3.0.3
+info: {title: Sunset, version: "1.0"}
+paths:
+  /v1/pets:
+    get:
+      operationId: listPetsV1
+      summary: List pets
+      deprecated: true
+      x-sunset: 2025-12-31
+      responses:
+        '200': {description: OK}
+  /v1/pets/{id}:
+    get:
+      operationId: getPetV1
+      deprecated: true
+      x-sunset: "Wed, 31 Dec 2025 12:30:00 GMT"
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+  /v1/owners:
+    get:
+      operationId: listOwnersV1
+      summary: List owners
+      deprecated: true
+      x-sunset: next year
+      responses:
+        '200': {description: OK}
+  /v2/pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      x-sunset: 2030-01-01
+      responses:
+        '200': {description: OK}
+`
+
+func TestConvert_DeprecatedSunset(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     DeprecatedMode
+		wantDesc map[string]string
+	}{
+		{
+			name: "mark",
+			mode: DeprecatedMark,
+			wantDesc: map[string]string{
+				"listPetsV1":   "[DEPRECATED] List pets\n\nWill be removed after 2025-12-31.",
+				"getPetV1":     "[DEPRECATED] Will be removed after 2025-12-31T12:30:00Z.",
+				"listOwnersV1": "[DEPRECATED] List owners",
+				"listPets":     "List pets",
+			},
+		},
+		{
+			name: "include",
+			mode: DeprecatedInclude,
+			wantDesc: map[string]string{
+				"listPetsV1":   "List pets",
+				"getPetV1":     "",
+				"listOwnersV1": "List owners",
+				"listPets":     "List pets",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(sunsetSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			config, err := NewConverterWithOptions(parser, ConvertOptions{DeprecatedOperations: tt.mode}).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			for _, tool := range config.Tools {
+				if want := tt.wantDesc[tool.Name]; tool.Description != want {
+					t.Errorf("tool %s description = %q, want %q", tool.Name, tool.Description, want)
+				}
+			}
+
+			wantWarnings := 0
+			if tt.mode == DeprecatedMark {
+				wantWarnings = 1
+			}
+			var warnings []string
+			for _, warning := range config.Warnings {
+				if strings.Contains(warning, "x-sunset") {
+					warnings = append(warnings, warning)
+				}
+			}
+			if len(warnings) != wantWarnings {
+				t.Fatalf("expected %d x-sunset warnings, got %v", wantWarnings, warnings)
+			}
+			if wantWarnings > 0 && !strings.Contains(warnings[0], "GET /v1/owners: ignoring x-sunset") {
+				t.Errorf("unexpected warning %q", warnings[0])
+			}
+		})
+	}
+}
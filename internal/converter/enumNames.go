@@ -0,0 +1,168 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// enumVarNamesExtension is the extension oapi-codegen names the constants of enum values after
+const enumVarNamesExtension = "x-enum-varnames"
+
+// NormalizeEnumNames sets x-enum-varnames on every string enum of the document that names none of
+// its values, so the constants the API client declares for them get clean, consistently cased
+// names (see enumVarName), numbered when values differ only in casing or punctuation. Only the
+// identifiers change: the enum values stay verbatim, so the schemas still accept exactly what the
+// API accepts. Enums naming their values with x-enum-varnames or x-enumNames are left as they are.
+func NormalizeEnumNames(doc *openapi3.T) {
+	if doc == nil {
+		return
+	}
+	visited := make(map[*openapi3.Schema]bool)
+	visit := func(ref *openapi3.SchemaRef) { normalizeEnumNamesIn(ref, visited) }
+
+	if doc.Components != nil {
+		for _, ref := range doc.Components.Schemas {
+			visit(ref)
+		}
+		for _, parameter := range doc.Components.Parameters {
+			if parameter != nil && parameter.Value != nil {
+				visit(parameter.Value.Schema)
+			}
+		}
+		for _, body := range doc.Components.RequestBodies {
+			if body != nil && body.Value != nil {
+				visitContent(body.Value.Content, visit)
+			}
+		}
+		for _, response := range doc.Components.Responses {
+			if response != nil && response.Value != nil {
+				visitContent(response.Value.Content, visit)
+			}
+		}
+	}
+
+	if doc.Paths == nil {
+		return
+	}
+	for _, pathItem := range doc.Paths.Map() {
+		for _, parameter := range pathItem.Parameters {
+			if parameter != nil && parameter.Value != nil {
+				visit(parameter.Value.Schema)
+			}
+		}
+		for _, operation := range pathItem.Operations() {
+			for _, parameter := range operation.Parameters {
+				if parameter != nil && parameter.Value != nil {
+					visit(parameter.Value.Schema)
+				}
+			}
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				visitContent(operation.RequestBody.Value.Content, visit)
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			for _, response := range operation.Responses.Map() {
+				if response != nil && response.Value != nil {
+					visitContent(response.Value.Content, visit)
+				}
+			}
+		}
+	}
+}
+
+// visitContent calls visit with the schema of each media type of content
+func visitContent(content openapi3.Content, visit func(*openapi3.SchemaRef)) {
+	for _, mediaType := range content {
+		if mediaType != nil {
+			visit(mediaType.Schema)
+		}
+	}
+}
+
+// normalizeEnumNamesIn names the enum values of a schema and of the schemas nested in it
+func normalizeEnumNamesIn(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	visited[schema] = true
+
+	if names := enumVarNames(schema); names != nil {
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+		schema.Extensions[enumVarNamesExtension] = names
+	}
+
+	for _, property := range schema.Properties {
+		normalizeEnumNamesIn(property, visited)
+	}
+	normalizeEnumNamesIn(schema.Items, visited)
+	normalizeEnumNamesIn(schema.AdditionalProperties.Schema, visited)
+	normalizeEnumNamesIn(schema.Not, visited)
+	for _, branches := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, branch := range branches {
+			normalizeEnumNamesIn(branch, visited)
+		}
+	}
+}
+
+// enumVarNames returns the names of a string enum's values, or nil when the schema is not a
+// string enum or already names its values
+func enumVarNames(schema *openapi3.Schema) []interface{} {
+	if len(schema.Enum) == 0 || !schema.Type.Is(openapi3.TypeString) {
+		return nil
+	}
+	if schema.Extensions[enumVarNamesExtension] != nil || schema.Extensions["x-enumNames"] != nil {
+		return nil
+	}
+	names := make([]interface{}, 0, len(schema.Enum))
+	taken := make(map[string]bool)
+	for _, value := range schema.Enum {
+		var name string
+		switch v := value.(type) {
+		case string:
+			name = enumVarName(v)
+		case nil:
+			// the null of a nullable enum
+			name = "Null"
+		default:
+			return nil
+		}
+		// oapi-codegen drops values whose name is taken, e.g. ACTIVE next to active
+		unique := name
+		for i := 2; taken[unique]; i++ {
+			unique = fmt.Sprintf("%s%d", name, i)
+		}
+		taken[unique] = true
+		names = append(names, unique)
+	}
+	return names
+}
+
+// enumVarName derives the identifier of a string enum value: surrounding whitespace is trimmed,
+// the value is split into words at characters that are not letters or digits, words written in
+// capitals are lowercased and each word is capitalized, e.g. " IN-STOCK " and "in stock" both
+// become InStock and "inProgress" InProgress. Values without letters or digits are named Empty.
+func enumVarName(value string) string {
+	words := strings.FieldsFunc(strings.TrimSpace(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	if b.Len() == 0 {
+		return "Empty"
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+const enumNamesSpec = `openapi: 3.0.3
+info: {title: Enums, version: "1.0"}
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      parameters:
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: ["created_at ", " UPDATED-AT"]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: '#/components/schemas/Order'}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        status:
+          type: string
+          enum: [" active", "ACTIVE", "in progress ", "inProgress", "  "]
+        priority:
+          type: string
+          nullable: true
+          enum: [high, null]
+        size:
+          type: string
+          enum: [S, M]
+          x-enum-varnames: [Small, Medium]
+        level:
+          type: integer
+          enum: [1, 2]
+`
+
+func TestNormalizeEnumNames(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(enumNamesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	doc := parser.GetDocument()
+	NormalizeEnumNames(doc)
+
+	order := doc.Components.Schemas["Order"].Value
+	tests := map[string]interface{}{
+		"status":   []interface{}{"Active", "Active2", "InProgress", "InProgress2", "Empty"},
+		"priority": []interface{}{"High", "Null"},
+		"size":     []interface{}{"Small", "Medium"},
+		"level":    nil,
+	}
+	for property, want := range tests {
+		if got := order.Properties[property].Value.Extensions[enumVarNamesExtension]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s x-enum-varnames = %#v, want %#v", property, got, want)
+		}
+	}
+	if want := []interface{}{" active", "ACTIVE", "in progress ", "inProgress", "  "}; !reflect.DeepEqual(order.Properties["status"].Value.Enum, want) {
+		t.Errorf("expected the enum values to stay verbatim, got %#v", order.Properties["status"].Value.Enum)
+	}
+
+	sort := doc.Paths.Find("/orders").Get.Parameters[0].Value.Schema.Value
+	if got, want := sort.Extensions[enumVarNamesExtension], []interface{}{"CreatedAt", "UpdatedAt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sort x-enum-varnames = %#v, want %#v", got, want)
+	}
+}
+
+func Test_enumVarName(t *testing.T) {
+	tests := map[string]string{
+		"available":     "Available",
+		"  in stock\t":  "InStock",
+		"IN-STOCK":      "InStock",
+		"inProgress":    "InProgress",
+		"HTTP_2 ready":  "Http2Ready",
+		"v1.2":          "V12",
+		" ":             "Empty",
+		"-":             "Empty",
+		"élan vital":    "ÉlanVital",
+		"already Title": "AlreadyTitle",
+	}
+	for value, want := range tests {
+		if got := enumVarName(value); got != want {
+			t.Errorf("enumVarName(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ParseGetRequestBodyMode parses the honor and drop mode names
+func ParseGetRequestBodyMode(name string) (GetRequestBodyMode, error) {
+	switch name {
+	case "honor", "":
+		return GetRequestBodyHonor, nil
+	case "drop":
+		return GetRequestBodyDrop, nil
+	}
+	return GetRequestBodyHonor, fmt.Errorf("unknown GET request body mode %q: use honor or drop", name)
+}
+
+// applyGetRequestBodyMode removes the request body of GET and HEAD operations when
+// ConvertOptions.GetRequestBodies is GetRequestBodyDrop, warning about each body it drops.
+// It returns the operation unchanged otherwise and a copy when it drops the body; the spec
+// itself is not modified.
+func (c *Converter) applyGetRequestBodyMode(toolName, method string, operation *openapi3.Operation) *openapi3.Operation {
+	method = strings.ToUpper(method)
+	if c.options.GetRequestBodies != GetRequestBodyDrop || operation.RequestBody == nil ||
+		method != "GET" && method != "HEAD" {
+		return operation
+	}
+	c.warnf("%s (%s): dropping the request body, which servers are not expected to read on %s requests",
+		c.location, toolName, method)
+	result := *operation
+	result.RequestBody = nil
+	return &result
+}
@@ -0,0 +1,125 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const getRequestBodySpec = `openapi: 3.0.3
+info: {title: Search, version: "1.0"}
+paths:
+  /search:
+    get:
+      operationId: search
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                query: {type: string}
+      responses:
+        '200': {description: OK}
+  /items:
+    delete:
+      operationId: deleteItems
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                ids: {type: array, items: {type: string}}
+      responses:
+        '204': {description: Deleted}
+`
+
+func TestConvert_GetRequestBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        GetRequestBodyMode
+		wantGetBody bool
+		wantWarning bool
+	}{
+		{name: "honor", mode: GetRequestBodyHonor, wantGetBody: true},
+		{name: "drop", mode: GetRequestBodyDrop, wantGetBody: false, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(getRequestBodySpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			config, err := NewConverterWithOptions(parser, ConvertOptions{GetRequestBodies: tt.mode}).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			tools := map[string]Tool{}
+			for _, tool := range config.Tools {
+				tools[tool.Name] = tool
+			}
+			search, ok := tools["search"]
+			if !ok {
+				t.Fatalf("expected a search tool, got %v", config.Tools)
+			}
+			if got := hasArg(search, "body"); got != tt.wantGetBody {
+				t.Errorf("search has a body argument = %v, want %v", got, tt.wantGetBody)
+			}
+			if got := strings.Contains(search.RawInputSchema, `"body"`); got != tt.wantGetBody {
+				t.Errorf("search input schema has a body = %v, want %v\n%s", got, tt.wantGetBody, search.RawInputSchema)
+			}
+			hasContentType := false
+			for _, header := range search.RequestTemplate.Headers {
+				if header.Key == "Content-Type" {
+					hasContentType = true
+				}
+			}
+			if hasContentType != tt.wantGetBody {
+				t.Errorf("search request template has a Content-Type = %v, want %v", hasContentType, tt.wantGetBody)
+			}
+			if !hasArg(search, "limit") {
+				t.Errorf("expected the query parameter to be kept")
+			}
+
+			// DELETE keeps its body in both modes
+			if !hasArg(tools["deleteItems"], "body") {
+				t.Errorf("expected deleteItems to keep its body")
+			}
+
+			warned := false
+			for _, warning := range config.Warnings {
+				if strings.Contains(warning, "GET /search (search): dropping the request body") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarning {
+				t.Errorf("warned about the dropped body = %v, want %v: %v", warned, tt.wantWarning, config.Warnings)
+			}
+		})
+	}
+}
+
+func hasArg(tool Tool, name string) bool {
+	for _, arg := range tool.Args {
+		if arg.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseGetRequestBodyMode(t *testing.T) {
+	for name, want := range map[string]GetRequestBodyMode{"": GetRequestBodyHonor, "honor": GetRequestBodyHonor, "drop": GetRequestBodyDrop} {
+		if got, err := ParseGetRequestBodyMode(name); err != nil || got != want {
+			t.Errorf("ParseGetRequestBodyMode(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseGetRequestBodyMode("ignore"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,81 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/oasdiff/yaml"
+)
+
+// LoadGlossary reads a JSON or YAML file mapping field names or paths to descriptions
+func LoadGlossary(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glossary file: %w", err)
+	}
+
+	glossary := make(map[string]string)
+	if err := yaml.Unmarshal(data, &glossary); err != nil {
+		return nil, fmt.Errorf("failed to parse glossary file %s: %w", path, err)
+	}
+	return glossary, nil
+}
+
+// applyGlossary describes the tool arguments and body properties the spec leaves undescribed with
+// ConvertOptions.Glossary. Descriptions from the spec always win. A glossary key matches a field
+// by its dotted path from the tool input, such as "body.owner.name", or else by its exact,
+// case-sensitive name, such as "name". Parameters are addressed by their name alone; properties of
+// array items and of oneOf, anyOf and allOf branches share the path of the array or schema holding
+// them (e.g. "body.pets.name" for the name of each pet).
+func (c *Converter) applyGlossary(args []Arg) {
+	if len(c.options.Glossary) == 0 {
+		return
+	}
+	for i := range args {
+		arg := &args[i]
+		if arg.Description == "" && (arg.Schema == nil || arg.Schema.Description == "") {
+			arg.Description = c.glossaryDescription(arg.Name, arg.Name)
+		}
+		c.applyGlossaryToSchema(arg.Schema, arg.Name, map[*Schema]bool{})
+		for _, schema := range arg.ContentTypes {
+			c.applyGlossaryToSchema(schema, arg.Name, map[*Schema]bool{})
+		}
+	}
+}
+
+// applyGlossaryToSchema fills in the descriptions of the properties below s, which is at path
+func (c *Converter) applyGlossaryToSchema(s *Schema, path string, visited map[*Schema]bool) {
+	if s == nil || visited[s] {
+		return
+	}
+	visited[s] = true
+
+	if s.Object != nil {
+		for name, prop := range s.Object.Properties {
+			if prop == nil {
+				continue
+			}
+			propPath := path + "." + name
+			if prop.Description == "" {
+				prop.Description = c.glossaryDescription(propPath, name)
+			}
+			c.applyGlossaryToSchema(prop, propPath, visited)
+		}
+	}
+	if s.Array != nil {
+		c.applyGlossaryToSchema(s.Array.Items, path, visited)
+	}
+	for _, branches := range [][]*Schema{s.OneOf, s.AnyOf, s.AllOf} {
+		for _, branch := range branches {
+			c.applyGlossaryToSchema(branch, path, visited)
+		}
+	}
+}
+
+// glossaryDescription looks a field up in the glossary by path, then by name
+func (c *Converter) glossaryDescription(path, name string) string {
+	if description, ok := c.options.Glossary[path]; ok {
+		return description
+	}
+	return c.options.Glossary[name]
+}
@@ -0,0 +1,109 @@
+package converter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const glossarySpec = `openapi: 3.0.3
+info: {title: Todos, version: "1.0"}
+paths:
+  /todos/{todoId}:
+    put:
+      operationId: updateTodo
+      parameters:
+        - {name: todoId, in: path, required: true, schema: {type: string}}
+        - {name: limit, in: query, description: From the spec, schema: {type: integer}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                owner:
+                  type: object
+                  properties:
+                    name: {type: string}
+                tags:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name: {type: string, description: Tag label}
+      responses:
+        '200': {description: OK}
+`
+
+func TestConvert_Glossary(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(glossarySpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	glossary := map[string]string{
+		"todoId":          "Identifier of the todo",
+		"limit":           "Ignored, the spec describes it",
+		"name":            "Display name",
+		"body.owner.name": "Full name of the owner",
+	}
+	config, err := NewConverterWithOptions(parser, ConvertOptions{Glossary: glossary}).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+	description := func(path ...string) interface{} {
+		s := schema
+		for _, name := range path {
+			if name == "[]" {
+				s, _ = s["items"].(map[string]interface{})
+				continue
+			}
+			props, _ := s["properties"].(map[string]interface{})
+			s, _ = props[name].(map[string]interface{})
+		}
+		return s["description"]
+	}
+
+	tests := []struct {
+		path []string
+		want interface{}
+	}{
+		{[]string{"todoId"}, "Identifier of the todo"},
+		{[]string{"limit"}, "From the spec"},
+		{[]string{"body", "name"}, "Display name"},
+		{[]string{"body", "owner", "name"}, "Full name of the owner"},
+		{[]string{"body", "tags", "[]", "name"}, "Tag label"},
+		{[]string{"body", "owner"}, nil},
+	}
+	for _, tt := range tests {
+		if got := description(tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("description of %v = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadGlossary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glossary.yaml")
+	if err := os.WriteFile(path, []byte("todoId: Identifier of the todo\nbody.owner.name: Full name of the owner\n"), 0644); err != nil {
+		t.Fatalf("failed to write glossary: %v", err)
+	}
+	glossary, err := LoadGlossary(path)
+	if err != nil {
+		t.Fatalf("LoadGlossary failed: %v", err)
+	}
+	want := map[string]string{"todoId": "Identifier of the todo", "body.owner.name": "Full name of the owner"}
+	if !reflect.DeepEqual(glossary, want) {
+		t.Errorf("LoadGlossary() = %v, want %v", glossary, want)
+	}
+
+	if _, err := LoadGlossary(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
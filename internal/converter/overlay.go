@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/speakeasy-api/openapi-overlay/pkg/overlay"
+	"gopkg.in/yaml.v3"
+)
+
+// applyOverlay applies the OpenAPI Overlay document at overlayPath to the spec in data and returns
+// the patched spec. Every action target must match at least one node of the spec, so overlays
+// written against an older version of the spec fail loudly instead of silently doing nothing.
+func applyOverlay(data []byte, overlayPath string) ([]byte, error) {
+	o, err := overlay.Parse(overlayPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid overlay %s: %w", overlayPath, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document for overlay: %w", err)
+	}
+	err, warnings := o.ApplyToStrict(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply overlay %s: %w", overlayPath, err)
+	}
+	for _, warning := range warnings {
+		fmt.Printf("Warning: overlay %s: %s\n", overlayPath, warning)
+	}
+
+	patched, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode overlaid OpenAPI document: %w", err)
+	}
+	return patched, nil
+}
@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const overlayTestSpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        "200":
+          description: OK
+  /pets/{id}:
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Deleted
+`
+
+func writeOverlayTestFiles(t *testing.T, overlay string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	if err := os.WriteFile(specPath, []byte(overlayTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	if err := os.WriteFile(overlayPath, []byte(overlay), 0644); err != nil {
+		t.Fatalf("Failed to write overlay: %v", err)
+	}
+	return specPath, overlayPath
+}
+
+func TestParseFile_Overlay(t *testing.T) {
+	specPath, overlayPath := writeOverlayTestFiles(t, `overlay: 1.0.0
+info:
+  title: Pets overlay
+  version: 1.0.0
+actions:
+  - target: $.paths['/pets'].get
+    update:
+      summary: Browse every pet
+  - target: $.paths['/pets/{id}']
+    remove: true
+`)
+
+	parser := NewParser(false)
+	parser.OverlayPath = overlayPath
+	if err := parser.ParseFile(specPath); err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	paths := parser.GetPaths()
+	if _, ok := paths["/pets/{id}"]; ok {
+		t.Errorf("expected /pets/{id} to be removed by the overlay")
+	}
+	listPets := paths["/pets"]
+	if listPets == nil || listPets.Get == nil {
+		t.Fatalf("expected GET /pets to be kept, got %v", paths)
+	}
+	if listPets.Get.Summary != "Browse every pet" {
+		t.Errorf("summary = %q, want the overlay's", listPets.Get.Summary)
+	}
+	if listPets.Get.OperationID != "listPets" {
+		t.Errorf("operationId = %q, want it kept by the update", listPets.Get.OperationID)
+	}
+}
+
+func TestParseFile_Overlay_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{
+			name: "unresolved target",
+			overlay: `overlay: 1.0.0
+info:
+  title: Pets overlay
+  version: 1.0.0
+actions:
+  - target: $.paths['/owners']
+    remove: true
+`,
+			wantErr: "did not match any targets",
+		},
+		{
+			name: "invalid overlay",
+			overlay: `overlay: 1.0.0
+info:
+  title: Pets overlay
+  version: 1.0.0
+actions: []
+`,
+			wantErr: "at least one action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specPath, overlayPath := writeOverlayTestFiles(t, tt.overlay)
+			parser := NewParser(false)
+			parser.OverlayPath = overlayPath
+			err := parser.ParseFile(specPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if parser.GetDocument() != nil {
+				t.Errorf("expected no document to be loaded")
+			}
+		})
+	}
+
+	specPath, _ := writeOverlayTestFiles(t, "")
+	parser := NewParser(false)
+	parser.OverlayPath = filepath.Join(t.TempDir(), "missing.yaml")
+	if err := parser.ParseFile(specPath); err == nil || !strings.Contains(err.Error(), "failed to read overlay") {
+		t.Errorf("ParseFile() error = %v, want a missing overlay error", err)
+	}
+}
@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// emptyValueNote tells the model how to send a parameter that allows empty values, which the
+// generated runtime otherwise leaves out of the query string when they are empty
+const emptyValueNote = "Pass an empty string to send the parameter without a value."
+
+// applyQueryEncoding carries a query parameter's allowReserved and allowEmptyValue into its
+// argument, so the generated runtime leaves reserved characters unencoded and sends empty values
+// ("name=") only where the API accepts them. The description of a parameter allowing empty values
+// says how to send one. Both keywords only apply to query parameters; elsewhere they are ignored
+// with a warning.
+func (c *Converter) applyQueryEncoding(arg *Arg, param *openapi3.Parameter) {
+	if param.In != openapi3.ParameterInQuery {
+		restore := c.at("parameter " + param.Name)
+		defer restore()
+		if param.AllowReserved {
+			c.warnf("%s: ignoring allowReserved, which only applies to query parameters", c.location)
+		}
+		if param.AllowEmptyValue {
+			c.warnf("%s: ignoring allowEmptyValue, which only applies to query parameters", c.location)
+		}
+		return
+	}
+
+	arg.AllowReserved = param.AllowReserved
+	arg.AllowEmptyValue = param.AllowEmptyValue
+	if param.AllowEmptyValue {
+		if arg.Description == "" {
+			arg.Description = emptyValueNote
+		} else {
+			arg.Description += "\n\n" + emptyValueNote
+		}
+	}
+}
@@ -3,8 +3,12 @@ package converter
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -13,6 +17,19 @@ import (
 type Parser struct {
 	doc              *openapi3.T
 	ValidateDocument bool
+	// OverlayPath, when set, is an OpenAPI Overlay document that ParseFile applies to the spec
+	// before loading it (see applyOverlay)
+	OverlayPath string
+	// AllowRemoteRefs fetches $refs to http(s) URLs instead of failing on them. It is off by default:
+	// fetching means parsing a spec makes network requests to wherever its refs point and depends on
+	// those servers staying available, which is unwanted in CI and unsafe for untrusted specs.
+	// Refs to local files are resolved either way.
+	AllowRemoteRefs bool
+	// Format is the format of the documents ParseFile and Parse read; by default it is detected from
+	// their content, whatever the file extension (see detectSpecFormat)
+	Format SpecFormat
+	// refFiles are the local files the last parse resolved external $refs from
+	refFiles []string
 }
 
 // NewParser creates a new OpenAPI parser
@@ -28,20 +45,45 @@ func (p *Parser) ParseFile(filePath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read OpenAPI file: %w", err)
 	}
+	// The syntax is checked before applying the overlay, which writes the spec back as YAML
+	if err := checkSpecSyntax(data, p.Format); err != nil {
+		return fmt.Errorf("failed to parse OpenAPI document %s: %w", filePath, err)
+	}
+
+	if p.OverlayPath != "" {
+		data, err = applyOverlay(data, p.OverlayPath)
+		if err != nil {
+			return err
+		}
+	}
 
-	return p.Parse(data)
+	return p.parse(data, &url.URL{Path: filePath})
 }
 
-// Parse parses an OpenAPI document from bytes
+// Parse parses an OpenAPI document from bytes. Relative file $refs are resolved from the
+// working directory; use ParseFile to resolve them next to the spec.
 func (p *Parser) Parse(data []byte) error {
+	if err := checkSpecSyntax(data, p.Format); err != nil {
+		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	return p.parse(data, nil)
+}
+
+// parse loads an OpenAPI document located at location, or at no particular place when it is nil
+func (p *Parser) parse(data []byte, location *url.URL) error {
+	p.refFiles = nil
 	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = openapi3.URIMapCache(p.readFromURI)
 
-	// Try to parse as JSON first
+	// Parse the document (loader can handle both JSON and YAML)
 	var doc *openapi3.T
 	var err error
-
-	// Parse the document (loader can handle both JSON and YAML)
-	doc, err = loader.LoadFromData(data)
+	if location != nil {
+		doc, err = loader.LoadFromDataWithPath(data, location)
+	} else {
+		doc, err = loader.LoadFromData(data)
+	}
 
 	if err != nil {
 		return fmt.Errorf("failed to parse OpenAPI document: %w", err)
@@ -59,6 +101,32 @@ func (p *Parser) Parse(data []byte) error {
 	return nil
 }
 
+// remoteRefClient fetches remote $refs when AllowRemoteRefs is set; the timeout keeps an
+// unresponsive server from hanging generation
+var remoteRefClient = &http.Client{Timeout: 30 * time.Second}
+
+// readFromURI reads the document an external $ref points to, refusing remote URLs unless
+// AllowRemoteRefs is set
+func (p *Parser) readFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme == "http" || location.Scheme == "https" {
+		if !p.AllowRemoteRefs {
+			return nil, fmt.Errorf("remote $ref %s is not allowed: fetching remote references is disabled by default", location)
+		}
+		return openapi3.ReadFromHTTP(remoteRefClient)(loader, location)
+	}
+	data, err := openapi3.ReadFromFile(loader, location)
+	if err == nil {
+		p.refFiles = append(p.refFiles, filepath.FromSlash(location.Path))
+	}
+	return data, err
+}
+
+// ReferencedFiles returns the local files the last ParseFile or Parse resolved external $refs from,
+// e.g. to watch them along with the spec. Paths are as the spec's refs resolved them.
+func (p *Parser) ReferencedFiles() []string {
+	return p.refFiles
+}
+
 // GetDocument returns the parsed OpenAPI document
 func (p *Parser) GetDocument() *openapi3.T {
 	return p.doc
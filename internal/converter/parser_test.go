@@ -1,6 +1,9 @@
 package converter
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -122,3 +125,71 @@ func TestParser_GetOperationID_Generated(t *testing.T) {
 		t.Errorf("expected generated id to start with post_foo_bar, got %q", id)
 	}
 }
+
+const remoteRefSpec = `openapi: 3.0.0
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "%s/schemas.yaml#/Pet"
+`
+
+func TestParser_AllowRemoteRefs(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte("Pet:\n  type: object\n"))
+	}))
+	defer server.Close()
+	spec := []byte(fmt.Sprintf(remoteRefSpec, server.URL))
+
+	// Remote refs are refused by default
+	p := NewParser(false)
+	err := p.Parse(spec)
+	if err == nil || !strings.Contains(err.Error(), "remote $ref "+server.URL+"/schemas.yaml is not allowed") {
+		t.Fatalf("Parse() error = %v, want a disallowed remote $ref error", err)
+	}
+	if hits != 0 {
+		t.Errorf("expected no request to the remote server, got %d", hits)
+	}
+
+	p = NewParser(false)
+	p.AllowRemoteRefs = true
+	if err := p.Parse(spec); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if hits == 0 {
+		t.Error("expected the remote $ref to be fetched")
+	}
+}
+
+func TestParseFile_RelativeRefs(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(fmt.Sprintf(remoteRefSpec, ".")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte("Pet:\n  type: object\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Local files are resolved next to the spec without allowing remote refs
+	p := NewParser(false)
+	if err := p.ParseFile(specPath); err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	schema := p.GetDocument().Paths.Find("/pets").Get.Responses.Status(200).Value.Content["application/json"].Schema
+	if schema.Value == nil || !schema.Value.Type.Is("object") {
+		t.Errorf("expected the local $ref to resolve to an object schema, got %+v", schema.Value)
+	}
+	if refs := p.ReferencedFiles(); len(refs) != 1 || filepath.Base(refs[0]) != "schemas.yaml" {
+		t.Errorf("ReferencedFiles() = %v, want the schemas.yaml file", refs)
+	}
+}
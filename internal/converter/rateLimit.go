@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rateLimitPeriods are the periods a rate limit can be expressed over
+var rateLimitPeriods = []string{"second", "minute", "hour", "day"}
+
+// rateLimit reads the rate limit an operation documents through vendor extensions, either as
+// an object or as flat keys:
+//
+//	x-ratelimit:
+//	  limit: 100
+//	  period: minute
+//
+//	x-ratelimit-limit: 100
+//	x-ratelimit-period: minute
+//
+// limit is the number of requests allowed per period, which is one of second, minute, hour or
+// day. The period can be left out when the API does not document one. Invalid values are
+// reported as warnings and nil is returned, as it is when the operation documents no limit.
+func (c *Converter) rateLimit(extensions map[string]interface{}) *RateLimit {
+	values := map[string]interface{}{
+		"limit":  extensions["x-ratelimit-limit"],
+		"period": extensions["x-ratelimit-period"],
+	}
+	if object, ok := extensions["x-ratelimit"].(map[string]interface{}); ok {
+		values = object
+	}
+	if values["limit"] == nil && values["period"] == nil {
+		return nil
+	}
+
+	limit := extensionInt(values, "limit")
+	period, _ := values["period"].(string)
+	period = strings.ToLower(strings.TrimSpace(period))
+	if limit <= 0 {
+		c.warnf("%s: ignoring x-ratelimit: limit must be a positive number of requests, got %v", c.location, values["limit"])
+		return nil
+	}
+	if period != "" && !contains(rateLimitPeriods, period) {
+		c.warnf("%s: ignoring x-ratelimit: period must be one of %s, got %v", c.location, strings.Join(rateLimitPeriods, ", "), values["period"])
+		return nil
+	}
+	return &RateLimit{Limit: limit, Period: period}
+}
+
+// String describes the rate limit, e.g. "100 requests/minute"
+func (r RateLimit) String() string {
+	requests := "requests"
+	if r.Limit == 1 {
+		requests = "request"
+	}
+	if r.Period == "" {
+		return fmt.Sprintf("%d %s", r.Limit, requests)
+	}
+	return fmt.Sprintf("%d %s/%s", r.Limit, requests, r.Period)
+}
+
+// appendRateLimit adds a "Rate limit: ..." line to a tool description
+func appendRateLimit(description string, limit *RateLimit) string {
+	if limit == nil {
+		return description
+	}
+	if description == "" {
+		return "Rate limit: " + limit.String()
+	}
+	return description + "\n\nRate limit: " + limit.String()
+}
@@ -0,0 +1,90 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const rateLimitSpec = `openapi: 3.0.3
+info: {title: Limits, version: "1.0"}
+paths:
+  /search:
+    get:
+      operationId: search
+      description: Search the catalog
+      x-ratelimit:
+        limit: 100
+        period: Minute
+      responses:
+        '200': {description: OK}
+  /export:
+    post:
+      operationId: export
+      x-ratelimit-limit: 1
+      x-ratelimit-period: hour
+      responses:
+        '200': {description: OK}
+  /bulk:
+    post:
+      operationId: bulk
+      x-ratelimit-limit: 5
+      x-ratelimit-period: fortnight
+      responses:
+        '200': {description: OK}
+  /plain:
+    get:
+      operationId: plain
+      description: No limit
+      responses:
+        '200': {description: OK}
+`
+
+func TestConvert_RateLimit(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(rateLimitSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tools := map[string]Tool{}
+	for _, tool := range config.Tools {
+		tools[tool.Name] = tool
+	}
+	tests := map[string]struct {
+		description string
+		rateLimit   *RateLimit
+	}{
+		"search": {"Search the catalog\n\nRate limit: 100 requests/minute", &RateLimit{Limit: 100, Period: "minute"}},
+		"export": {"Rate limit: 1 request/hour", &RateLimit{Limit: 1, Period: "hour"}},
+		"bulk":   {"", nil},
+		"plain":  {"No limit", nil},
+	}
+	for name, tt := range tests {
+		tool := tools[name]
+		if tool.Description != tt.description {
+			t.Errorf("%s: description = %q, want %q", name, tool.Description, tt.description)
+		}
+		if (tool.RateLimit == nil) != (tt.rateLimit == nil) || tool.RateLimit != nil && *tool.RateLimit != *tt.rateLimit {
+			t.Errorf("%s: RateLimit = %+v, want %+v", name, tool.RateLimit, tt.rateLimit)
+		}
+	}
+
+	found := false
+	for _, warning := range config.Warnings {
+		if strings.Contains(warning, "POST /bulk: ignoring x-ratelimit: period must be one of") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about the invalid period, got %v", config.Warnings)
+	}
+}
+
+func TestRateLimit_String(t *testing.T) {
+	if got := (RateLimit{Limit: 10}).String(); got != "10 requests" {
+		t.Errorf("String() = %q, want %q", got, "10 requests")
+	}
+}
@@ -8,7 +8,11 @@ import (
 
 // ConvertRequestBody converts an OpenAPI request body to our Arg structures
 func (c *Converter) convertRequestBody(requestBodyRef *openapi3.RequestBodyRef) (*Arg, error) {
-	if requestBodyRef == nil || requestBodyRef.Value == nil {
+	if requestBodyRef == nil {
+		return nil, nil
+	}
+	if requestBodyRef.Value == nil {
+		c.warnf("%s: unresolved request body $ref %q, the tool takes no body", c.location, requestBodyRef.Ref)
 		return nil, nil
 	}
 
@@ -24,22 +28,34 @@ func (c *Converter) convertRequestBody(requestBodyRef *openapi3.RequestBodyRef)
 	// Process each content type
 	validContent := false
 	for contentType, mediaType := range requestBody.Content {
-		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		if mediaType != nil && mediaType.Schema != nil && mediaType.Schema.Value == nil {
+			restore := c.at("request body " + contentType)
+			c.warnMissingSchema(mediaType.Schema.Ref, "skipping the content type")
+			restore()
+			continue
+		}
+		if mediaType == nil || mediaType.Schema == nil {
+			c.unsupportedf("request body content type %s without a schema", contentType)
 			continue
 		}
 
+		restore := c.at("request body " + contentType)
 		schema, err := c.applySchema(mediaType.Schema.Value)
 		if err != nil {
+			restore()
 			return nil, fmt.Errorf("failed to convert schema for content type %s: %w", contentType, err)
 		}
 
 		if schema != nil {
+			c.stripReadOnly(schema)
 			Arg.ContentTypes[contentType] = schema
 			validContent = true
 		}
+		restore()
 	}
 
 	if validContent {
+		Arg.Examples = collectRequestExamples(requestBody.Content, Arg.ContentTypes)
 		return &Arg, nil
 	}
 
@@ -51,19 +67,37 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]Arg, er
 	args := []Arg{}
 
 	for i, paramRef := range parameters {
-		if paramRef == nil || paramRef.Value == nil {
+		if paramRef == nil {
+			continue
+		}
+		if paramRef.Value == nil {
+			c.warnf("%s: unresolved parameter $ref %q, skipping the parameter", c.location, paramRef.Ref)
 			continue
 		}
 
 		param := paramRef.Value
+		restore := c.at("parameter " + param.Name)
+		c.checkUnsupportedParameterStyle(param)
 
 		// Skip invalid parameters
-		if param.Schema == nil || param.Schema.Value == nil {
+		if param.Schema != nil && param.Schema.Value == nil {
+			c.warnMissingSchema(param.Schema.Ref, "skipping the parameter")
+			restore()
+			continue
+		}
+		if param.Schema == nil {
+			if len(param.Content) > 0 {
+				c.unsupportedf("content instead of schema")
+			} else {
+				c.unsupportedf("no schema")
+			}
+			restore()
 			continue
 		}
 
 		// Convert the schema using our new function
 		schema, err := c.applySchema(param.Schema.Value)
+		restore()
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert schema for parameter %s (index %d): %w",
 				param.Name, i, err)
@@ -78,6 +112,7 @@ func (c *Converter) convertParameters(parameters openapi3.Parameters) ([]Arg, er
 			Schema:      schema,
 			Deprecated:  param.Deprecated,
 		}
+		c.applyQueryEncoding(&arg, param)
 
 		args = append(args, arg)
 	}
@@ -2,6 +2,7 @@ package converter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -11,15 +12,19 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 	if schema == nil {
 		return nil, fmt.Errorf("cannot apply metadata to nil schema")
 	}
+	c.checkUnsupportedKeywords(schema)
+	c.checkFormat(schema.Format)
 
 	// Create a new Schema
 	result := &Schema{
 		Title:       schema.Title,
-		Description: schema.Description,
-		Format:      schema.Format,
-		Enum:        schema.Enum,
+		Description: c.enumDescription(schema),
+		Format:      c.mapFormat(schema.Format),
+		Enum:        normalizeEnumValues(schema.Enum),
 		Default:     schema.Default,
 		Example:     schema.Example,
+		Examples:    collectExamples(schema),
+		Const:       schema.Extensions["const"],
 		ReadOnly:    schema.ReadOnly,
 		WriteOnly:   schema.WriteOnly,
 	}
@@ -47,26 +52,30 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		if !isNullableAlreadyPresent {
 			result.Types = append(result.Types, "null")
 		}
+		// A nullable enum must list null too, or validators reject null despite the type allowing it
+		if len(result.Enum) > 0 && !enumHasNull(result.Enum) {
+			result.Enum = append(append([]interface{}{}, result.Enum...), nil)
+		}
 	}
 
 	var err error
 
-	if hasStringType(schema) {
+	if hasStringType(schema) || hasStringKeywords(schema) {
 		result.String = c.createStringValidation(schema)
 	}
 
-	if hasNumericType(schema) {
+	if hasNumericType(schema) || hasNumericKeywords(schema) {
 		result.Number = c.createNumberValidation(schema)
 	}
 
-	if hasArrayType(schema) {
+	if hasArrayType(schema) || hasArrayKeywords(schema) {
 		result.Array, err = c.createArrayValidation(schema)
 		if err != nil {
 			return nil, fmt.Errorf("error creating array validation: %w", err)
 		}
 	}
 
-	if hasObjectType(schema) {
+	if hasObjectType(schema) || hasObjectKeywords(schema) {
 		result.Object, err = c.createObjectValidation(schema)
 		if err != nil {
 			return nil, fmt.Errorf("error creating object validation: %w", err)
@@ -94,6 +103,13 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		}
 	}
 
+	if c.options.DiscriminatorIfThen && schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+		result.Discriminator = &Discriminator{
+			PropertyName: schema.Discriminator.PropertyName,
+			Mapping:      schema.Discriminator.Mapping,
+		}
+	}
+
 	// Handle AnyOf
 	if len(schema.AnyOf) > 0 {
 		result.AnyOf = make([]*Schema, len(schema.AnyOf))
@@ -132,7 +148,16 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		}
 	}
 
+	if c.options.MergeAllOf {
+		c.mergeAllOfObjects(result)
+	}
+
 	// Handle Not
+	if schema.Not != nil && schema.Not.Value == nil {
+		restore := c.at("not")
+		c.warnMissingSchema(schema.Not.Ref, "ignoring the not keyword")
+		restore()
+	}
 	if schema.Not != nil && schema.Not.Value != nil {
 		notSchema, err := c.applySchema(schema.Not.Value)
 		if err != nil {
@@ -145,6 +170,35 @@ func (c *Converter) applySchema(schema *openapi3.Schema) (*Schema, error) {
 		}
 	}
 
+	// Handle If/Then/Else; kin-openapi keeps these OpenAPI 3.1 keywords in Extensions
+	if result.If, err = c.applyExtensionSchema(schema, "if"); err != nil {
+		return nil, err
+	}
+	if result.Then, err = c.applyExtensionSchema(schema, "then"); err != nil {
+		return nil, err
+	}
+	if result.Else, err = c.applyExtensionSchema(schema, "else"); err != nil {
+		return nil, err
+	}
+
+	if c.options.SimplifyCombinators {
+		simplifyCombinators(result)
+	}
+
+	return result, nil
+}
+
+// applyExtensionSchema converts a schema-valued keyword that kin-openapi leaves in Extensions,
+// returning nil when the keyword is absent
+func (c *Converter) applyExtensionSchema(schema *openapi3.Schema, key string) (*Schema, error) {
+	subSchema, err := extensionSchema(schema.Extensions, key)
+	if err != nil || subSchema == nil {
+		return nil, err
+	}
+	result, err := c.applySchema(subSchema)
+	if err != nil {
+		return nil, fmt.Errorf("error processing %s sub-schema: %w", key, err)
+	}
 	return result, nil
 }
 
@@ -153,10 +207,14 @@ func (c *Converter) createStringValidation(schema *openapi3.Schema) *StringValid
 	if schema == nil {
 		return nil
 	}
+	pattern := schema.Pattern
+	if pattern == "" && c.options.TranslateFormatsToPatterns {
+		pattern = formatPatterns[c.mapFormat(schema.Format)]
+	}
 	return &StringValidation{
 		MinLength: schema.MinLength,
 		MaxLength: schema.MaxLength,
-		Pattern:   schema.Pattern,
+		Pattern:   pattern,
 	}
 }
 
@@ -185,12 +243,31 @@ func (c *Converter) createArrayValidation(schema *openapi3.Schema) (*ArrayValida
 		UniqueItems: schema.UniqueItems,
 	}
 
-	if schema.Items != nil && schema.Items.Value != nil {
-		itemsSchema, err := c.applySchema(schema.Items.Value)
-		if err != nil {
-			return nil, fmt.Errorf("error processing array items schema: %w", err)
+	if schema.Items != nil {
+		restore := c.at("items")
+		if schema.Items.Value == nil {
+			c.warnMissingSchema(schema.Items.Ref, "allowing items of any type")
+		} else {
+			itemsSchema, err := c.applySchema(schema.Items.Value)
+			if err != nil {
+				restore()
+				return nil, fmt.Errorf("error processing array items schema: %w", err)
+			}
+			result.Items = itemsSchema
+		}
+		restore()
+	}
+
+	contains, err := c.applyExtensionSchema(schema, "contains")
+	if err != nil {
+		return nil, fmt.Errorf("error processing array contains schema: %w", err)
+	}
+	if contains != nil {
+		result.Contains = contains
+		if c.options.SchemaDraft >= SchemaDraft202012 {
+			result.MinContains = extensionUint64(schema.Extensions, "minContains")
+			result.MaxContains = extensionUint64(schema.Extensions, "maxContains")
 		}
-		result.Items = itemsSchema
 	}
 
 	return result, nil
@@ -211,27 +288,33 @@ func (c *Converter) createObjectValidation(schema *openapi3.Schema) (*ObjectVali
 	if len(schema.Properties) > 0 {
 		result.Properties = make(map[string]*Schema)
 		for propName, propSchemaRef := range schema.Properties {
+			restore := c.at("property " + propName)
 			if propSchemaRef != nil {
 				if propSchemaRef.Value != nil {
 					propSchema, err := c.applySchema(propSchemaRef.Value)
 					if err != nil {
+						restore()
 						return nil, fmt.Errorf("error processing property '%s': %w", propName, err)
 					}
 					if propSchema != nil {
+						c.inlineRefDescription(propSchemaRef, propSchema)
+						inlineRefTitle(propSchemaRef, propSchema)
 						result.Properties[propName] = propSchema
 					}
 				} else {
 					result.Properties[propName] = &Schema{}
-					fmt.Printf("Warning: Property '%s' has a non-nil SchemaRef but nil Value. Mapping to empty schema.\n", propName)
+					c.warnMissingSchema(propSchemaRef.Ref, "using an empty schema")
 				}
 			} else {
-				fmt.Printf("Warning: Property '%s' has a nil schema reference in the OpenAPI spec.\n", propName)
+				c.warnMissingSchema("", "dropping the property")
 			}
+			restore()
 		}
 		if len(result.Properties) == 0 {
 			result.Properties = nil
 		}
 	}
+	c.handleEmptyProperties(result)
 
 	// --- Handle additionalProperties ---
 	if schema.AdditionalProperties.Has != nil {
@@ -256,9 +339,115 @@ func (c *Converter) createObjectValidation(schema *openapi3.Schema) (*ObjectVali
 			}
 		} else {
 			result.AdditionalProperties = &Schema{}
-			fmt.Printf("Warning: AdditionalProperties SchemaRef has a nil Value. Mapping to empty schema {}.\n")
+			restore := c.at("additionalProperties")
+			c.warnMissingSchema(schema.AdditionalProperties.Schema.Ref, "using an empty schema")
+			restore()
 		}
 	}
 
+	// --- Handle propertyNames (kept in Extensions by kin-openapi) ---
+	propertyNames, err := c.applyExtensionSchema(schema, "propertyNames")
+	if err != nil {
+		return nil, fmt.Errorf("error processing propertyNames schema: %w", err)
+	}
+	result.PropertyNames = propertyNames
+
+	// --- Handle dependentRequired / dependentSchemas (kept in Extensions by kin-openapi) ---
+	result.DependentRequired = extensionStringListMap(schema.Extensions, "dependentRequired")
+	if raw, ok := schema.Extensions["dependentSchemas"].(map[string]interface{}); ok {
+		result.DependentSchemas = make(map[string]*Schema, len(raw))
+		for propName := range raw {
+			dependent, err := extensionSchema(raw, propName)
+			if err != nil {
+				return nil, fmt.Errorf("error processing dependentSchemas for '%s': %w", propName, err)
+			}
+			if dependent == nil {
+				continue
+			}
+			dependentSchema, err := c.applySchema(dependent)
+			if err != nil {
+				return nil, fmt.Errorf("error processing dependentSchemas for '%s': %w", propName, err)
+			}
+			result.DependentSchemas[propName] = dependentSchema
+		}
+	}
+	result.DependentKeywords = c.options.SchemaDraft >= SchemaDraft202012
+
 	return result, nil
 }
+
+// ParseSchemaDraft parses the 7 and 2020-12 draft names
+func ParseSchemaDraft(name string) (SchemaDraft, error) {
+	switch name {
+	case "7", "":
+		return SchemaDraft7, nil
+	case "2020-12":
+		return SchemaDraft202012, nil
+	}
+	return SchemaDraft7, fmt.Errorf("unknown schema draft %q: use 7 or 2020-12", name)
+}
+
+// ParseDescriptionStrategy parses the prefer-site and concatenate strategy names
+func ParseDescriptionStrategy(name string) (DescriptionStrategy, error) {
+	switch name {
+	case "prefer-site", "":
+		return DescriptionPreferSite, nil
+	case "concatenate":
+		return DescriptionConcatenate, nil
+	}
+	return DescriptionPreferSite, fmt.Errorf("unknown description strategy %q: use prefer-site or concatenate", name)
+}
+
+// inlineRefDescription sets the description of a property that references a component schema.
+// OpenAPI 3.0 ignores siblings of $ref, so a property-site description is expressed by wrapping
+// the reference in a single-element allOf. The property-site description wins and falls back to
+// the referenced schema's description, unless DescriptionConcatenate asks for both.
+func (c *Converter) inlineRefDescription(propRef *openapi3.SchemaRef, result *Schema) {
+	var siteDescription, refDescription string
+	switch {
+	case propRef.Ref != "":
+		// A bare $ref has no property-site description; applySchema already copied the referenced one.
+		return
+	case len(propRef.Value.AllOf) == 1 && propRef.Value.AllOf[0] != nil &&
+		propRef.Value.AllOf[0].Ref != "" && propRef.Value.AllOf[0].Value != nil:
+		siteDescription = propRef.Value.Description
+		refDescription = propRef.Value.AllOf[0].Value.Description
+	default:
+		return
+	}
+
+	switch {
+	case siteDescription == "":
+		result.Description = refDescription
+	case refDescription == "" || refDescription == siteDescription:
+		result.Description = siteDescription
+	case c.options.DescriptionStrategy == DescriptionConcatenate:
+		result.Description = fmt.Sprintf("%s - %s", siteDescription, refDescription)
+	default:
+		result.Description = siteDescription
+	}
+}
+
+// inlineRefTitle titles a property that references a named component schema after the component,
+// so the nested object stays self-identifying once the reference is inlined. The reference may be
+// bare or wrapped in a single-element allOf; a title the schema already has is kept.
+func inlineRefTitle(propRef *openapi3.SchemaRef, result *Schema) {
+	if result.Title != "" {
+		return
+	}
+	ref := propRef.Ref
+	if ref == "" && len(propRef.Value.AllOf) == 1 && propRef.Value.AllOf[0] != nil {
+		ref = propRef.Value.AllOf[0].Ref
+	}
+	result.Title = componentSchemaName(ref)
+}
+
+// componentSchemaName returns the name of the component schema a $ref points at, e.g. "Todo" for
+// "#/components/schemas/Todo" or "models.yaml#/components/schemas/Todo", or "" for other references
+func componentSchemaName(ref string) string {
+	_, pointer, found := strings.Cut(ref, "#/components/schemas/")
+	if !found || pointer == "" || strings.Contains(pointer, "/") {
+		return ""
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(pointer)
+}
@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"bytes"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -553,3 +555,606 @@ func TestApplySchema_OneOf_AnyOf_AllOf_Not(t *testing.T) {
 		t.Errorf("Not not set correctly: %+v", result4.Not)
 	}
 }
+
+func TestApplySchema_Examples_SpecVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []interface{}
+	}{
+		{
+			name: "openapi 3.0 singular example",
+			spec: `openapi: 3.0.3
+info: {title: Examples, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+      example: alice
+`,
+			want: []interface{}{"alice"},
+		},
+		{
+			name: "openapi 3.1 examples array merged after singular",
+			spec: `openapi: 3.1.0
+info: {title: Examples, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+      example: alice
+      examples: [bob, alice, carol]
+`,
+			want: []interface{}{"alice", "bob", "carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(tt.spec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			c := NewConverter(parser)
+			schemaRef := parser.GetDocument().Components.Schemas["Name"]
+			result, err := c.applySchema(schemaRef.Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result.Examples, tt.want) {
+				t.Errorf("Examples = %#v, want %#v", result.Examples, tt.want)
+			}
+
+			draft7, err := schemaToDraft7Map(result)
+			if err != nil {
+				t.Fatalf("schemaToDraft7Map() error = %v", err)
+			}
+			if !reflect.DeepEqual(draft7["examples"], tt.want) {
+				t.Errorf("draft7 examples = %#v, want %#v", draft7["examples"], tt.want)
+			}
+		})
+	}
+}
+
+const refDescriptionSpec = `openapi: 3.0.3
+info: {title: Refs, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Address:
+      type: object
+      description: A postal address
+      properties:
+        city: {type: string}
+    Note:
+      type: string
+    Customer:
+      type: object
+      properties:
+        billing:
+          description: Where invoices are sent
+          allOf:
+            - $ref: '#/components/schemas/Address'
+        shipping:
+          allOf:
+            - $ref: '#/components/schemas/Address'
+        home:
+          $ref: '#/components/schemas/Address'
+        memo:
+          description: Free-form memo
+          allOf:
+            - $ref: '#/components/schemas/Note'
+`
+
+func TestApplySchema_RefDescriptionPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy DescriptionStrategy
+		want     map[string]string
+	}{
+		{
+			name:     "prefer site",
+			strategy: DescriptionPreferSite,
+			want: map[string]string{
+				"billing":  "Where invoices are sent",
+				"shipping": "A postal address",
+				"home":     "A postal address",
+				"memo":     "Free-form memo",
+			},
+		},
+		{
+			name:     "concatenate",
+			strategy: DescriptionConcatenate,
+			want: map[string]string{
+				"billing":  "Where invoices are sent - A postal address",
+				"shipping": "A postal address",
+				"home":     "A postal address",
+				"memo":     "Free-form memo",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(refDescriptionSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			c := NewConverterWithOptions(parser, ConvertOptions{DescriptionStrategy: tt.strategy})
+			result, err := c.applySchema(parser.GetDocument().Components.Schemas["Customer"].Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for prop, want := range tt.want {
+				if got := result.Object.Properties[prop].Description; got != want {
+					t.Errorf("property %q description = %q, want %q", prop, got, want)
+				}
+			}
+		})
+	}
+}
+
+const refTitleSpec = `openapi: 3.0.3
+info: {title: Refs, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Todo:
+      type: object
+      properties:
+        text: {type: string}
+    Owner:
+      type: object
+      title: Todo owner
+      properties:
+        name: {type: string}
+    List:
+      type: object
+      properties:
+        first:
+          $ref: '#/components/schemas/Todo'
+        pinned:
+          description: The pinned todo
+          allOf:
+            - $ref: '#/components/schemas/Todo'
+        owner:
+          $ref: '#/components/schemas/Owner'
+        inline:
+          type: object
+          properties:
+            text: {type: string}
+`
+
+func TestApplySchema_RefTitle(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(refTitleSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	result, err := NewConverter(parser).applySchema(parser.GetDocument().Components.Schemas["List"].Value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"first":  "Todo",
+		"pinned": "Todo",
+		"owner":  "Todo owner",
+		"inline": "",
+	}
+	for prop, want := range want {
+		if got := result.Object.Properties[prop].Title; got != want {
+			t.Errorf("property %q title = %q, want %q", prop, got, want)
+		}
+	}
+}
+
+func Test_componentSchemaName(t *testing.T) {
+	tests := map[string]string{
+		"#/components/schemas/Todo":              "Todo",
+		"models.yaml#/components/schemas/Todo":   "Todo",
+		"#/components/schemas/a~1b":              "a/b",
+		"#/components/schemas/Todo/properties/x": "",
+		"#/components/parameters/limit":          "",
+		"":                                       "",
+	}
+	for ref, want := range tests {
+		if got := componentSchemaName(ref); got != want {
+			t.Errorf("componentSchemaName(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+const containsSpec = `openapi: 3.1.0
+info: {title: Contains, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Tags:
+      type: array
+      items:
+        type: string
+      uniqueItems: true
+      contains:
+        type: string
+        pattern: "^urgent"
+      minContains: 1
+      maxContains: 2
+`
+
+func TestCreateArrayValidation_Contains(t *testing.T) {
+	tests := []struct {
+		name        string
+		draft       SchemaDraft
+		wantBounded bool
+	}{
+		{name: "draft 7 drops minContains and maxContains", draft: SchemaDraft7},
+		{name: "draft 2020-12 keeps minContains and maxContains", draft: SchemaDraft202012, wantBounded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(containsSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			c := NewConverterWithOptions(parser, ConvertOptions{SchemaDraft: tt.draft})
+			result, err := c.applySchema(parser.GetDocument().Components.Schemas["Tags"].Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			array := result.Array
+			if !array.UniqueItems {
+				t.Error("expected uniqueItems to be kept")
+			}
+			if array.Contains == nil || array.Contains.String == nil || array.Contains.String.Pattern != "^urgent" {
+				t.Fatalf("expected contains schema with pattern, got %+v", array.Contains)
+			}
+			if tt.wantBounded {
+				if array.MinContains == nil || *array.MinContains != 1 || array.MaxContains == nil || *array.MaxContains != 2 {
+					t.Errorf("expected minContains=1 and maxContains=2, got %v and %v", array.MinContains, array.MaxContains)
+				}
+			} else if array.MinContains != nil || array.MaxContains != nil {
+				t.Errorf("expected no minContains/maxContains for draft 7, got %v and %v", array.MinContains, array.MaxContains)
+			}
+		})
+	}
+}
+
+const conditionalSpec = `openapi: 3.1.0
+info: {title: Conditional, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        country:
+          type: string
+        postalCode:
+          type: string
+      if:
+        properties:
+          country:
+            const: US
+      then:
+        properties:
+          postalCode:
+            pattern: "^[0-9]{5}$"
+      else:
+        properties:
+          postalCode:
+            pattern: "^[A-Z0-9 ]+$"
+`
+
+func TestApplySchema_Conditional(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(conditionalSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverter(parser)
+	result, err := c.applySchema(parser.GetDocument().Components.Schemas["Address"].Value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.If == nil || result.If.Object == nil || result.If.Object.Properties["country"].Const != "US" {
+		t.Fatalf("expected if schema pinning country to US, got %+v", result.If)
+	}
+	if result.Then == nil || result.Then.Object.Properties["postalCode"].String.Pattern != "^[0-9]{5}$" {
+		t.Errorf("expected then schema with US postal code pattern, got %+v", result.Then)
+	}
+	if result.Else == nil || result.Else.Object.Properties["postalCode"].String.Pattern != "^[A-Z0-9 ]+$" {
+		t.Errorf("expected else schema with fallback pattern, got %+v", result.Else)
+	}
+
+	draft7, err := schemaToDraft7Map(result)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	ifMap, ok := draft7["if"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected if in draft 7 output, got %v", draft7)
+	}
+	if _, hasType := ifMap["type"]; hasType {
+		t.Errorf("expected untyped if schema to stay untyped, got %v", ifMap)
+	}
+	if _, ok := ifMap["properties"]; !ok {
+		t.Errorf("expected if schema to keep its properties, got %v", ifMap)
+	}
+	for _, keyword := range []string{"then", "else"} {
+		if _, ok := draft7[keyword]; !ok {
+			t.Errorf("expected %s in draft 7 output, got %v", keyword, draft7)
+		}
+	}
+}
+
+func TestApplySchema_NoConditional(t *testing.T) {
+	c := NewConverter(NewParser(false))
+	result, err := c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.If != nil || result.Then != nil || result.Else != nil {
+		t.Errorf("expected no conditional schemas, got if=%v then=%v else=%v", result.If, result.Then, result.Else)
+	}
+}
+
+const dependentSpec = `openapi: 3.1.0
+info: {title: Dependent, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Payment:
+      type: object
+      properties:
+        creditCard:
+          type: string
+        billingAddress:
+          type: string
+        cvv:
+          type: string
+      dependentRequired:
+        creditCard: [billingAddress, cvv]
+      dependentSchemas:
+        billingAddress:
+          properties:
+            cvv:
+              minLength: 3
+`
+
+func TestCreateObjectValidation_Dependent(t *testing.T) {
+	for _, draft := range []SchemaDraft{SchemaDraft7, SchemaDraft202012} {
+		parser := NewParser(false)
+		if err := parser.Parse([]byte(dependentSpec)); err != nil {
+			t.Fatalf("failed to parse spec: %v", err)
+		}
+		c := NewConverterWithOptions(parser, ConvertOptions{SchemaDraft: draft})
+		result, err := c.applySchema(parser.GetDocument().Components.Schemas["Payment"].Value)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		object := result.Object
+		if want := []string{"billingAddress", "cvv"}; !reflect.DeepEqual(object.DependentRequired["creditCard"], want) {
+			t.Errorf("DependentRequired[creditCard] = %v, want %v", object.DependentRequired["creditCard"], want)
+		}
+		dependent := object.DependentSchemas["billingAddress"]
+		if dependent == nil || dependent.Object == nil || dependent.Object.Properties["cvv"].String.MinLength != 3 {
+			t.Errorf("expected dependent schema for billingAddress requiring cvv minLength 3, got %+v", dependent)
+		}
+		if object.DependentKeywords != (draft == SchemaDraft202012) {
+			t.Errorf("DependentKeywords = %v for draft %v", object.DependentKeywords, draft)
+		}
+	}
+}
+
+func TestCreateObjectValidation_PropertyNames(t *testing.T) {
+	c := NewConverter(NewParser(false))
+	source := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		AdditionalProperties: openapi3.AdditionalProperties{
+			Schema: &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		},
+		Extensions: map[string]interface{}{
+			"propertyNames": map[string]interface{}{"pattern": "^[a-z_]+$", "maxLength": 16},
+		},
+	}
+	result, err := c.applySchema(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := result.Object.PropertyNames
+	if keys == nil || keys.String == nil {
+		t.Fatalf("expected a propertyNames schema with string validation, got %+v", keys)
+	}
+	if keys.String.Pattern != "^[a-z_]+$" || keys.String.MaxLength == nil || *keys.String.MaxLength != 16 {
+		t.Errorf("PropertyNames string validation = %+v, want pattern ^[a-z_]+$ and maxLength 16", keys.String)
+	}
+
+	// An untyped schema with only propertyNames is still an object constraint
+	untyped, err := c.applySchema(&openapi3.Schema{Extensions: source.Extensions})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if untyped.Object == nil || untyped.Object.PropertyNames == nil {
+		t.Errorf("expected propertyNames on an untyped schema, got %+v", untyped.Object)
+	}
+}
+
+func TestApplySchema_NullableEnum(t *testing.T) {
+	c := NewConverter(NewParser(false))
+	source := &openapi3.Schema{
+		Type:     &openapi3.Types{"string"},
+		Nullable: true,
+		Enum:     []interface{}{"a", "b"},
+	}
+	result, err := c.applySchema(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"string", "null"}; !reflect.DeepEqual(result.Types, want) {
+		t.Errorf("Types = %v, want %v", result.Types, want)
+	}
+	if want := []interface{}{"a", "b", nil}; !reflect.DeepEqual(result.Enum, want) {
+		t.Errorf("Enum = %v, want %v", result.Enum, want)
+	}
+	if len(source.Enum) != 2 {
+		t.Errorf("expected the source enum to be left untouched, got %v", source.Enum)
+	}
+
+	draft7, err := schemaToDraft7Map(result)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	if enum, ok := draft7["enum"].([]interface{}); !ok || !enumHasNull(enum) {
+		t.Errorf("expected null to be an accepted enum value, got %v", draft7["enum"])
+	}
+
+	// An enum that already lists null is not extended again
+	source.Enum = []interface{}{"a", nil}
+	result, err = c.applySchema(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Enum) != 2 {
+		t.Errorf("Enum = %v, want null listed once", result.Enum)
+	}
+}
+
+func TestApplySchema_EnumValuesKeepJSONTypes(t *testing.T) {
+	c := &Converter{}
+	tests := []struct {
+		name string
+		enum []interface{}
+		want string
+	}{
+		{"integer", []interface{}{float64(1), float64(2), float64(3)}, `[1,2,3]`},
+		{"boolean", []interface{}{true, false}, `[true,false]`},
+		{"mixed", []interface{}{"1", float64(1), false, map[string]interface{}{"a": "b"}}, `["1",1,false,{"a":"b"}]`},
+		{"integer keys", []interface{}{map[interface{}]interface{}{1: "one"}}, `[{"1":"one"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.applySchema(&openapi3.Schema{Enum: tt.enum})
+			if err != nil {
+				t.Fatalf("applySchema failed: %v", err)
+			}
+			raw, err := GenerateJSONSchemaDraft7([]Arg{{Name: "value", Schema: result}})
+			if err != nil {
+				t.Fatalf("GenerateJSONSchemaDraft7 failed: %v", err)
+			}
+			var schema struct {
+				Properties map[string]struct {
+					Enum json.RawMessage `json:"enum"`
+				} `json:"properties"`
+			}
+			if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+				t.Fatalf("invalid schema: %v", err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, schema.Properties["value"].Enum); err != nil {
+				t.Fatalf("invalid enum: %v", err)
+			}
+			if compact.String() != tt.want {
+				t.Errorf("enum = %s, want %s", compact.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert_NullOnlySchemas(t *testing.T) {
+	spec := `openapi: 3.1.0
+info: {title: Nulls, version: "1.0"}
+paths:
+  /records:
+    post:
+      operationId: createRecord
+      parameters:
+        - {name: cursor, in: query, schema: {type: "null"}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [reserved]
+              properties:
+                reserved: {type: "null"}
+                documented: {type: "null", description: Always null for now}
+                placeholders: {type: array, items: {type: "null"}}
+      responses:
+        '200': {description: OK}
+`
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+	properties := schema["properties"].(map[string]interface{})
+	body := properties["body"].(map[string]interface{})
+	bodyProperties := body["properties"].(map[string]interface{})
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want map[string]interface{}
+	}{
+		{"query parameter", properties["cursor"], map[string]interface{}{"type": "null"}},
+		{"object property", bodyProperties["reserved"], map[string]interface{}{"type": "null"}},
+		{"described property", bodyProperties["documented"], map[string]interface{}{"type": "null", "description": "Always null for now"}},
+		{"array items", bodyProperties["placeholders"], map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "null"}}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if want := []interface{}{"reserved"}; !reflect.DeepEqual(body["required"], want) {
+		t.Errorf("required = %v, want %v", body["required"], want)
+	}
+}
+
+func TestParseDescriptionStrategy(t *testing.T) {
+	tests := map[string]DescriptionStrategy{
+		"":            DescriptionPreferSite,
+		"prefer-site": DescriptionPreferSite,
+		"concatenate": DescriptionConcatenate,
+	}
+	for name, want := range tests {
+		got, err := ParseDescriptionStrategy(name)
+		if err != nil || got != want {
+			t.Errorf("ParseDescriptionStrategy(%q) = (%v, %v), want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseDescriptionStrategy("merge"); err == nil {
+		t.Error("expected an error for an unknown strategy, got nil")
+	}
+}
+
+func TestParseSchemaDraft(t *testing.T) {
+	tests := map[string]SchemaDraft{
+		"":        SchemaDraft7,
+		"7":       SchemaDraft7,
+		"2020-12": SchemaDraft202012,
+	}
+	for name, want := range tests {
+		got, err := ParseSchemaDraft(name)
+		if err != nil || got != want {
+			t.Errorf("ParseSchemaDraft(%q) = (%v, %v), want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseSchemaDraft("2019-09"); err == nil {
+		t.Error("expected an error for an unknown draft, got nil")
+	}
+}
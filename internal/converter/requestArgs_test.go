@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -162,3 +164,117 @@ func TestConvertParameters_SkipInvalid(t *testing.T) {
 		t.Errorf("expected 0 args, got %d", len(args))
 	}
 }
+
+func TestConvertParameters_QueryEncoding(t *testing.T) {
+	c := &Converter{location: "GET /search"}
+	stringSchema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	params := openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "filter", In: "query", AllowReserved: true, Schema: stringSchema}},
+		{Value: &openapi3.Parameter{Name: "flag", In: "query", Description: "Include drafts", AllowEmptyValue: true, Schema: stringSchema}},
+		{Value: &openapi3.Parameter{Name: "id", In: "path", Required: true, AllowReserved: true, Schema: stringSchema}},
+	}
+	args, err := c.convertParameters(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(args) != 3 {
+		t.Fatalf("expected 3 args, got %d", len(args))
+	}
+	if !args[0].AllowReserved || args[0].AllowEmptyValue {
+		t.Errorf("filter: expected only AllowReserved, got %+v", args[0])
+	}
+	if !args[1].AllowEmptyValue || args[1].AllowReserved {
+		t.Errorf("flag: expected only AllowEmptyValue, got %+v", args[1])
+	}
+	if want := "Include drafts\n\n" + emptyValueNote; args[1].Description != want {
+		t.Errorf("flag description = %q, want %q", args[1].Description, want)
+	}
+	if args[2].AllowReserved {
+		t.Errorf("id: allowReserved must only apply to query parameters, got %+v", args[2])
+	}
+	want := "GET /search parameter id: ignoring allowReserved, which only applies to query parameters"
+	if len(c.warnings) != 1 || c.warnings[0] != want {
+		t.Errorf("warnings = %q, want [%q]", c.warnings, want)
+	}
+}
+
+const nonObjectBodySpec = `openapi: 3.0.3
+info: {title: Bulk, version: "1.0"}
+paths:
+  /todos/bulk:
+    post:
+      operationId: createTodos
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: array
+              minItems: 1
+              items:
+                type: object
+                required: [text]
+                properties:
+                  text: {type: string}
+      responses:
+        '201': {description: Created}
+  /notes:
+    post:
+      operationId: createNote
+      requestBody:
+        content:
+          text/plain:
+            schema: {type: string, maxLength: 100}
+      responses:
+        '201': {description: Created}
+`
+
+func TestConvert_NonObjectBodies(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(nonObjectBodySpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverterWithOptions(parser, ConvertOptions{AdditionalProperties: AdditionalPropertiesStrict}).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	want := map[string]map[string]interface{}{
+		"createTodos": {
+			"type":     "object",
+			"title":    "createTodos",
+			"required": []interface{}{"body"},
+			"properties": map[string]interface{}{
+				"body": map[string]interface{}{
+					"type":     "array",
+					"minItems": float64(1),
+					"items": map[string]interface{}{
+						"type":                 "object",
+						"required":             []interface{}{"text"},
+						"properties":           map[string]interface{}{"text": map[string]interface{}{"type": "string"}},
+						"additionalProperties": false,
+					},
+				},
+			},
+		},
+		"createNote": {
+			"type":  "object",
+			"title": "createNote",
+			"properties": map[string]interface{}{
+				"body": map[string]interface{}{"type": "string", "maxLength": float64(100)},
+			},
+		},
+	}
+	for _, tool := range config.Tools {
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+			t.Fatalf("%s: invalid input schema: %v", tool.Name, err)
+		}
+		if !reflect.DeepEqual(schema, want[tool.Name]) {
+			t.Errorf("%s input schema = %s", tool.Name, tool.RawInputSchema)
+		}
+		if len(tool.Args) != 1 || tool.Args[0].Source != "body" {
+			t.Errorf("%s: expected a single body argument, got %+v", tool.Name, tool.Args)
+		}
+	}
+}
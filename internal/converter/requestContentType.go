@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// applyRequestContentType narrows the operation's request body to the content type chosen by the
+// tool override for toolName, so the tool takes a single body schema instead of a oneOf over every
+// documented content type. The override takes precedence over the default of offering them all.
+// It returns the operation unchanged when no override applies and a copy otherwise; the spec
+// itself is not modified. A content type the request body does not document is an error.
+func (c *Converter) applyRequestContentType(toolName string, operation *openapi3.Operation) (*openapi3.Operation, error) {
+	contentType := c.options.ToolOverrides[toolName].RequestContentType
+	if contentType == "" {
+		return operation, nil
+	}
+
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil || len(operation.RequestBody.Value.Content) == 0 {
+		return nil, fmt.Errorf("request content type override %q for %s: the operation has no request body", contentType, toolName)
+	}
+	body := operation.RequestBody.Value
+	mediaType, ok := body.Content[contentType]
+	if !ok {
+		return nil, fmt.Errorf("request content type override %q for %s: the request body only documents %s",
+			contentType, toolName, strings.Join(sortedContentTypes(body.Content), ", "))
+	}
+
+	narrowed := *body
+	narrowed.Content = openapi3.Content{contentType: mediaType}
+	result := *operation
+	result.RequestBody = &openapi3.RequestBodyRef{Value: &narrowed}
+	return &result, nil
+}
@@ -0,0 +1,108 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const requestContentTypeSpec = `openapi: 3.0.3
+info: {title: ContentTypes, version: "1.0"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                nickname: {type: string}
+      responses:
+        '201': {description: Created}
+    get:
+      operationId: listPets
+      responses:
+        '200': {description: OK}
+`
+
+func convertWithOverrides(t *testing.T, overrides map[string]ToolOverride) (*MCPConfig, error) {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(requestContentTypeSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	return NewConverterWithOptions(parser, ConvertOptions{ToolOverrides: overrides}).Convert()
+}
+
+func TestConvert_RequestContentTypeOverride(t *testing.T) {
+	config, err := convertWithOverrides(t, map[string]ToolOverride{
+		"createPet": {RequestContentType: "application/x-www-form-urlencoded"},
+	})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var tool *Tool
+	for i := range config.Tools {
+		if config.Tools[i].Name == "createPet" {
+			tool = &config.Tools[i]
+		}
+	}
+	if tool == nil {
+		t.Fatal("createPet was not converted")
+	}
+	if strings.Contains(tool.RawInputSchema, "oneOf") || strings.Contains(tool.RawInputSchema, `"name"`) {
+		t.Errorf("expected only the form schema, got:\n%s", tool.RawInputSchema)
+	}
+	if !strings.Contains(tool.RawInputSchema, `"nickname"`) {
+		t.Errorf("expected the form schema, got:\n%s", tool.RawInputSchema)
+	}
+	if len(tool.RequestTemplate.Headers) != 1 || tool.RequestTemplate.Headers[0].Value != "application/x-www-form-urlencoded" {
+		t.Errorf("expected the form Content-Type header, got %+v", tool.RequestTemplate.Headers)
+	}
+}
+
+func TestConvert_RequestContentTypeDefault(t *testing.T) {
+	config, err := convertWithOverrides(t, nil)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	for _, tool := range config.Tools {
+		if tool.Name == "createPet" && !strings.Contains(tool.RawInputSchema, "oneOf") {
+			t.Errorf("expected a oneOf over both content types without an override, got:\n%s", tool.RawInputSchema)
+		}
+	}
+}
+
+func TestConvert_RequestContentTypeOverrideInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]ToolOverride
+		wantErr   string
+	}{
+		{
+			name:      "undocumented content type",
+			overrides: map[string]ToolOverride{"createPet": {RequestContentType: "text/plain"}},
+			wantErr:   `request content type override "text/plain" for createPet: the request body only documents application/json, application/x-www-form-urlencoded`,
+		},
+		{
+			name:      "no request body",
+			overrides: map[string]ToolOverride{"listPets": {RequestContentType: "application/json"}},
+			wantErr:   `request content type override "application/json" for listPets: the operation has no request body`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := convertWithOverrides(t, tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
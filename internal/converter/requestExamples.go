@@ -0,0 +1,167 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// collectRequestExamples gathers media-type level examples for the content types that were
+// converted, in content type order. The single `example` comes first, then named `examples`
+// sorted by name. Examples that only point at an externalValue are skipped.
+func collectRequestExamples(content openapi3.Content, converted map[string]*Schema) []RequestExample {
+	var examples []RequestExample
+	for _, contentType := range sortedContentTypes(content) {
+		if _, ok := converted[contentType]; !ok {
+			continue
+		}
+		mediaType := content[contentType]
+		if mediaType.Example != nil {
+			examples = append(examples, RequestExample{ContentType: contentType, Value: mediaType.Example})
+		}
+
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			exampleRef := mediaType.Examples[name]
+			if exampleRef == nil || exampleRef.Value == nil || exampleRef.Value.Value == nil {
+				continue
+			}
+			examples = append(examples, RequestExample{
+				Name:        name,
+				Summary:     exampleRef.Value.Summary,
+				ContentType: contentType,
+				Value:       exampleRef.Value.Value,
+			})
+		}
+	}
+	return examples
+}
+
+// inputSchemaExamples maps the request body examples onto the tool input, where the body is the
+// "body" property: each example becomes {"body": <example>} alongside the documented example of
+// every other argument. Media-type examples are used first, then the body schema's own example
+// and examples. Raw payloads such as an XML document given for an object schema cannot be
+// expressed as tool input and are left out. Returns nil when the body documents no example.
+func inputSchemaExamples(args []Arg) []interface{} {
+	var bodies []interface{}
+	params := map[string]interface{}{}
+	for _, arg := range args {
+		if arg.Source != "body" {
+			if value, ok := schemaExampleValue(arg.Schema); ok {
+				params[arg.Name] = value
+			}
+			continue
+		}
+		for _, example := range arg.Examples {
+			if isRawPayload(example.Value, arg.ContentTypes[example.ContentType]) {
+				continue
+			}
+			bodies = append(bodies, example.Value)
+		}
+		if len(bodies) > 0 {
+			continue
+		}
+		contentTypes := make([]string, 0, len(arg.ContentTypes))
+		for contentType := range arg.ContentTypes {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+		for _, contentType := range contentTypes {
+			schema := arg.ContentTypes[contentType]
+			if schema == nil {
+				continue
+			}
+			// Examples already includes a 3.0 example
+			if len(schema.Examples) > 0 {
+				bodies = append(bodies, schema.Examples...)
+			} else if schema.Example != nil {
+				bodies = append(bodies, schema.Example)
+			}
+		}
+	}
+
+	examples := make([]interface{}, 0, len(bodies))
+	for _, body := range bodies {
+		example := map[string]interface{}{"body": body}
+		for name, value := range params {
+			example[name] = value
+		}
+		examples = append(examples, example)
+	}
+	if len(examples) == 0 {
+		return nil
+	}
+	return examples
+}
+
+// isRawPayload reports whether an example is a serialized document (e.g. XML or form data) for a
+// schema that does not accept strings
+func isRawPayload(value interface{}, schema *Schema) bool {
+	if _, ok := value.(string); !ok || schema == nil || len(schema.Types) == 0 {
+		return false
+	}
+	return !contains(schema.Types, "string")
+}
+
+// schemaExampleValue returns the schema's example, or the first of its examples
+func schemaExampleValue(schema *Schema) (interface{}, bool) {
+	if schema == nil {
+		return nil, false
+	}
+	if schema.Example != nil {
+		return schema.Example, true
+	}
+	if len(schema.Examples) > 0 {
+		return schema.Examples[0], true
+	}
+	return nil, false
+}
+
+// appendExampleRequests adds an "## Example Request" section listing the examples to a tool description
+func appendExampleRequests(description string, examples []RequestExample) string {
+	if len(examples) == 0 {
+		return description
+	}
+
+	var b strings.Builder
+	b.WriteString(description)
+	if description != "" {
+		b.WriteString("\n\n")
+	}
+	b.WriteString("## Example Request\n")
+	for _, example := range examples {
+		b.WriteString("\n")
+		label := example.Summary
+		if label == "" {
+			label = example.Name
+		}
+		if label != "" {
+			b.WriteString(fmt.Sprintf("%s (%s):\n", label, example.ContentType))
+		} else {
+			b.WriteString(fmt.Sprintf("%s:\n", example.ContentType))
+		}
+		b.WriteString("```\n")
+		b.WriteString(formatExampleValue(example.Value))
+		b.WriteString("\n```\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatExampleValue renders strings as-is (e.g. XML or form payloads) and everything else as indented JSON
+func formatExampleValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
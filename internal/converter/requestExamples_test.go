@@ -0,0 +1,176 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const requestExamplesSpec = `openapi: 3.0.3
+info: {title: Examples, version: "1.0"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      summary: Create a pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+            example:
+              name: Rex
+            examples:
+              puppy:
+                summary: A young dog
+                value:
+                  name: Bolt
+              cat:
+                value:
+                  name: Tom
+              remote:
+                externalValue: https://example.com/pet.json
+          application/xml:
+            schema:
+              type: object
+            example: "<pet><name>Rex</name></pet>"
+      responses:
+        '201':
+          description: Created
+`
+
+func TestConvertRequestBody_Examples(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(requestExamplesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	tool := config.Tools[0]
+
+	var body *Arg
+	for i := range tool.Args {
+		if tool.Args[i].Source == "body" {
+			body = &tool.Args[i]
+		}
+	}
+	if body == nil {
+		t.Fatalf("expected a body argument, got %+v", tool.Args)
+	}
+
+	wantNames := []string{"", "cat", "puppy", ""}
+	wantTypes := []string{"application/json", "application/json", "application/json", "application/xml"}
+	if len(body.Examples) != len(wantNames) {
+		t.Fatalf("expected %d examples, got %+v", len(wantNames), body.Examples)
+	}
+	for i, example := range body.Examples {
+		if example.Name != wantNames[i] || example.ContentType != wantTypes[i] {
+			t.Errorf("example %d = (%q, %q), want (%q, %q)", i, example.Name, example.ContentType, wantNames[i], wantTypes[i])
+		}
+	}
+	if body.Examples[2].Summary != "A young dog" {
+		t.Errorf("expected puppy summary to be kept, got %q", body.Examples[2].Summary)
+	}
+
+	for _, want := range []string{
+		"Create a pet\n\n## Example Request\n",
+		"application/json:\n```\n{\n  \"name\": \"Rex\"\n}\n```",
+		"A young dog (application/json):",
+		"cat (application/json):",
+		"application/xml:\n```\n<pet><name>Rex</name></pet>\n```",
+	} {
+		if !strings.Contains(tool.Description, want) {
+			t.Errorf("description missing %q\n%s", want, tool.Description)
+		}
+	}
+	if strings.Contains(tool.Description, "remote") {
+		t.Errorf("expected externalValue-only example to be skipped\n%s", tool.Description)
+	}
+}
+
+func TestAppendExampleRequests_NoExamples(t *testing.T) {
+	if got := appendExampleRequests("Create a pet", nil); got != "Create a pet" {
+		t.Errorf("appendExampleRequests() = %q, want description unchanged", got)
+	}
+}
+
+const inputSchemaExamplesSpec = `openapi: 3.0.3
+info: {title: Examples, version: "1.0"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - {name: dryRun, in: query, schema: {type: boolean, example: true}}
+        - {name: tag, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+            example: {name: Rex}
+          application/xml:
+            schema: {type: object}
+            example: "<pet><name>Rex</name></pet>"
+      responses:
+        '201': {description: Created}
+  /owners:
+    post:
+      operationId: createOwner
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+              example: {name: Ada}
+      responses:
+        '201': {description: Created}
+`
+
+func TestConvert_InputSchemaExamples(t *testing.T) {
+	convert := func(options ConvertOptions) map[string]map[string]interface{} {
+		t.Helper()
+		parser := NewParser(false)
+		if err := parser.Parse([]byte(inputSchemaExamplesSpec)); err != nil {
+			t.Fatalf("failed to parse spec: %v", err)
+		}
+		config, err := NewConverterWithOptions(parser, options).Convert()
+		if err != nil {
+			t.Fatalf("Convert failed: %v", err)
+		}
+		schemas := map[string]map[string]interface{}{}
+		for _, tool := range config.Tools {
+			var schema map[string]interface{}
+			if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+				t.Fatalf("invalid input schema for %s: %v", tool.Name, err)
+			}
+			schemas[tool.Name] = schema
+		}
+		return schemas
+	}
+
+	schemas := convert(ConvertOptions{InputSchemaExamples: true})
+	want := []interface{}{map[string]interface{}{"body": map[string]interface{}{"name": "Rex"}, "dryRun": true}}
+	if got := schemas["createPet"]["examples"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("createPet examples = %v, want %v", got, want)
+	}
+	want = []interface{}{map[string]interface{}{"body": map[string]interface{}{"name": "Ada"}}}
+	if got := schemas["createOwner"]["examples"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("createOwner examples = %v, want %v (from the body schema example)", got, want)
+	}
+
+	for _, options := range []ConvertOptions{{}, {InputSchemaExamples: true, OmitSchemaExamples: true}} {
+		if _, ok := convert(options)["createPet"]["examples"]; ok {
+			t.Errorf("expected no root examples with %+v", options)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package converter
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -39,17 +41,33 @@ func getOperations(pathItem *openapi3.PathItem) map[string]*openapi3.Operation {
 	return operations
 }
 
-// convertOperation converts an OpenAPI operation to an MCP tool
+// convertOperation converts an OpenAPI operation to an MCP tool.
+// It returns a nil tool without error when the operation is skipped; the reason is recorded as a warning.
 func (c *Converter) convertOperation(path, method string, operation *openapi3.Operation) (*Tool, error) {
 	// Generate a tool name
 	toolName := c.parser.GetOperationID(path, method, operation)
 
+	if operation.Deprecated && c.options.DeprecatedOperations == DeprecatedExclude {
+		return nil, nil
+	}
+
+	operation, err := c.applyRequestContentType(toolName, operation)
+	if err != nil {
+		return nil, err
+	}
+	operation = c.applyGetRequestBodyMode(toolName, method, operation)
+
 	// Create the tool
 	tool := &Tool{
 		Name:        toolName,
-		Description: getDescription(operation),
+		Description: c.toolDescription(operation),
+		Method:      strings.ToUpper(method),
+		Path:        path,
 		Args:        []Arg{},
+		Tags:        operation.Tags,
 	}
+	tool.RateLimit = c.rateLimit(operation.Extensions)
+	tool.Description = appendRateLimit(tool.Description, tool.RateLimit)
 
 	// Convert parameters to arguments
 	args, err := c.convertParameters(operation.Parameters)
@@ -67,9 +85,16 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	}
 	if bodyArgs != nil {
 		tool.Args = append(tool.Args, *bodyArgs)
+	} else if isRequestBodyRequired(operation) {
+		c.warnf("skipping %s %s (%s): request body is required but has no content with a convertible schema",
+			strings.ToUpper(method), path, toolName)
+		c.unsupportedf("required request body without a convertible schema (operation skipped)")
+		return nil, nil
 	}
 
-	rawInputSchema, err := GenerateJSONSchemaDraft7(tool.Args)
+	c.applyGlossary(tool.Args)
+
+	rawInputSchema, err := GenerateJSONSchemaDraft7WithMetadata(c.inputSchemaArgs(tool.Args), c.inputSchemaMetadata(toolName, operation, tool.Args))
 	if err != nil {
 		return nil, fmt.Errorf("failed creating raw input schema for the %s tool input", toolName)
 	}
@@ -95,5 +120,38 @@ func (c *Converter) convertOperation(path, method string, operation *openapi3.Op
 	}
 	tool.Responses = responseTemplate
 
+	tool.Consumes = consumedContentTypes(operation)
+	tool.Produces = producedContentTypes(tool.Responses)
+	if c.options.DescribeContentTypes {
+		tool.Description = appendContentTypes(tool.Description, tool.Consumes, tool.Produces)
+	}
+	if bodyArgs != nil {
+		tool.Description = appendExampleRequests(tool.Description, bodyArgs.Examples)
+	}
+
+	tool.MaxResponseBytes = extensionInt(operation.Extensions, "x-mcp-max-response-bytes")
+
 	return tool, nil
 }
+
+// inputSchemaMetadata titles a tool's input schema with the operation summary, falling back to the
+// tool name, derives its $id from the operationId when SchemaIDPrefix is set and adds the request
+// examples when InputSchemaExamples is set
+func (c *Converter) inputSchemaMetadata(toolName string, operation *openapi3.Operation, args []Arg) SchemaMetadata {
+	metadata := SchemaMetadata{Title: strings.TrimSpace(operation.Summary)}
+	if metadata.Title == "" {
+		metadata.Title = toolName
+	}
+	if c.options.SchemaIDPrefix != "" {
+		metadata.ID = c.options.SchemaIDPrefix + url.PathEscape(toolName)
+	}
+	if c.options.InputSchemaExamples && !c.options.OmitSchemaExamples {
+		metadata.Examples = inputSchemaExamples(args)
+	}
+	return metadata
+}
+
+// isRequestBodyRequired reports whether the operation declares a required request body
+func isRequestBodyRequired(operation *openapi3.Operation) bool {
+	return operation.RequestBody != nil && operation.RequestBody.Value != nil && operation.RequestBody.Value.Required
+}
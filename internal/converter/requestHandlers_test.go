@@ -1,8 +1,10 @@
 package converter
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -102,3 +104,147 @@ func TestConvertOperation_RealData(t *testing.T) {
 	}
 	// Args and RawInputSchema are optional, but you can check them if you want
 }
+
+func TestConvert_RequiredBodyWithoutContent_WarnsAndSkips(t *testing.T) {
+	spec := `openapi: 3.0.3
+info: {title: Warnings, version: "1.0"}
+paths:
+  /items:
+    post:
+      operationId: createItem
+      requestBody:
+        required: true
+        content:
+          application/octet-stream: {}
+      responses:
+        '201': {description: Created}
+    put:
+      operationId: replaceItem
+      requestBody:
+        content:
+          application/octet-stream: {}
+      responses:
+        '200': {description: OK}
+`
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverter(parser)
+	config, err := c.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(config.Tools) != 1 || config.Tools[0].Name != "replaceItem" {
+		t.Fatalf("expected only replaceItem to be converted, got %+v", config.Tools)
+	}
+	if config.Tools[0].Method != "PUT" || config.Tools[0].Path != "/items" {
+		t.Errorf("expected tool to record PUT /items, got %s %s", config.Tools[0].Method, config.Tools[0].Path)
+	}
+	if len(config.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", config.Warnings)
+	}
+	if !strings.Contains(config.Warnings[0], "POST /items (createItem)") {
+		t.Errorf("warning does not identify the operation: %q", config.Warnings[0])
+	}
+	if len(c.Warnings()) != 1 {
+		t.Errorf("expected Warnings() to expose the report, got %v", c.Warnings())
+	}
+}
+
+func TestConvert_InputSchemaMetadata(t *testing.T) {
+	spec := `openapi: 3.0.3
+info: {title: Metadata, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      responses:
+        '200': {description: OK}
+    post:
+      operationId: createPet
+      responses:
+        '201': {description: Created}
+`
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options ConvertOptions
+		want    map[string]map[string]interface{}
+	}{
+		{
+			name: "title only by default",
+			want: map[string]map[string]interface{}{
+				"listPets":  {"title": "List all pets"},
+				"createPet": {"title": "createPet"},
+			},
+		},
+		{
+			name:    "id with prefix",
+			options: ConvertOptions{SchemaIDPrefix: "https://example.com/schemas/"},
+			want: map[string]map[string]interface{}{
+				"listPets":  {"title": "List all pets", "$id": "https://example.com/schemas/listPets"},
+				"createPet": {"title": "createPet", "$id": "https://example.com/schemas/createPet"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewConverterWithOptions(parser, tt.options).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			for _, tool := range config.Tools {
+				var schema map[string]interface{}
+				if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+					t.Fatalf("invalid schema for %s: %v", tool.Name, err)
+				}
+				want := tt.want[tool.Name]
+				if schema["title"] != want["title"] {
+					t.Errorf("%s title = %v, want %v", tool.Name, schema["title"], want["title"])
+				}
+				if schema["$id"] != want["$id"] {
+					t.Errorf("%s $id = %v, want %v", tool.Name, schema["$id"], want["$id"])
+				}
+			}
+		})
+	}
+}
+
+func TestConvert_Tags(t *testing.T) {
+	spec := `openapi: 3.0.3
+info: {title: Tags, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets, store]
+      responses:
+        '200': {description: OK}
+  /ping:
+    get:
+      operationId: ping
+      responses:
+        '200': {description: OK}
+`
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	want := map[string][]string{"listPets": {"pets", "store"}, "ping": nil}
+	for _, tool := range config.Tools {
+		if !reflect.DeepEqual(tool.Tags, want[tool.Name]) {
+			t.Errorf("%s tags = %v, want %v", tool.Name, tool.Tags, want[tool.Name])
+		}
+	}
+}
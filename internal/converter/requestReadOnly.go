@@ -0,0 +1,93 @@
+package converter
+
+import "sort"
+
+// stripReadOnly removes readOnly properties, and their required entries, from a request body
+// schema. Those fields are owned by the server (ids, timestamps), so the model should not be asked
+// to supply them. Response templates are built from the spec directly and keep them.
+//
+// A property that is both required and readOnly cannot be supplied by any client, so the spec is
+// inconsistent: the required entry is dropped with the property (including entries listed by a
+// sibling allOf branch, which would otherwise leave the schema unsatisfiable) and a warning is
+// reported. Required properties with a default are reported too, as the default never applies.
+func (c *Converter) stripReadOnly(s *Schema) {
+	c.stripReadOnlyIn(s, "body", make(map[*Schema]bool))
+}
+
+func (c *Converter) stripReadOnlyIn(s *Schema, path string, visited map[*Schema]bool) {
+	if s == nil || visited[s] {
+		return
+	}
+	visited[s] = true
+
+	if len(s.AllOf) > 0 {
+		c.dropReadOnlyRequired(s, path)
+	}
+	if object := s.Object; object != nil {
+		names := make([]string, 0, len(object.Properties))
+		for name := range object.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop := object.Properties[name]
+			if prop != nil && prop.ReadOnly {
+				if contains(object.Required, name) {
+					c.warnf("%s: %s.%s is both required and readOnly, leaving it out of the request body", c.location, path, name)
+				}
+				delete(object.Properties, name)
+				object.Required = removeString(object.Required, name)
+				continue
+			}
+			if prop != nil && prop.Default != nil && contains(object.Required, name) {
+				c.warnf("%s: %s.%s is required but has a default, which never applies", c.location, path, name)
+			}
+			c.stripReadOnlyIn(prop, path+"."+name, visited)
+		}
+		c.stripReadOnlyIn(object.AdditionalProperties, path+".*", visited)
+		for _, dependent := range object.DependentSchemas {
+			c.stripReadOnlyIn(dependent, path, visited)
+		}
+	}
+	if array := s.Array; array != nil {
+		c.stripReadOnlyIn(array.Items, path+"[]", visited)
+		c.stripReadOnlyIn(array.Contains, path+"[]", visited)
+	}
+	for _, branches := range [][]*Schema{s.AllOf, s.AnyOf, s.OneOf} {
+		for _, branch := range branches {
+			c.stripReadOnlyIn(branch, path, visited)
+		}
+	}
+	c.stripReadOnlyIn(s.If, path, visited)
+	c.stripReadOnlyIn(s.Then, path, visited)
+	c.stripReadOnlyIn(s.Else, path, visited)
+}
+
+// dropReadOnlyRequired removes properties declared readOnly by one allOf branch from the required
+// lists of the other branches and of the schema itself, since allOf requires all of them to hold
+func (c *Converter) dropReadOnlyRequired(s *Schema, path string) {
+	owners := make(map[string]*ObjectValidation)
+	var objects []*ObjectValidation
+	for _, schema := range append([]*Schema{s}, s.AllOf...) {
+		if schema == nil || schema.Object == nil {
+			continue
+		}
+		objects = append(objects, schema.Object)
+		for name, prop := range schema.Object.Properties {
+			if prop != nil && prop.ReadOnly {
+				owners[name] = schema.Object
+			}
+		}
+	}
+
+	for _, object := range objects {
+		for _, name := range append([]string(nil), object.Required...) {
+			owner, ok := owners[name]
+			if !ok || owner == object {
+				continue
+			}
+			c.warnf("%s: %s.%s is both required and readOnly, leaving it out of the request body", c.location, path, name)
+			object.Required = removeString(object.Required, name)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package converter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const readOnlySpec = `openapi: 3.0.3
+info: {title: ReadOnly, version: "1.0"}
+paths:
+  /todos:
+    post:
+      operationId: createTodo
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Todo'}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Todo'}
+  /todos/{id}:
+    put:
+      operationId: replaceTodo
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Todo'}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Todo'}
+    patch:
+      operationId: updateTodo
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Todo'
+                - type: object
+                  properties:
+                    revision: {type: integer, readOnly: true}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Todo'}
+components:
+  schemas:
+    Todo:
+      type: object
+      required: [id, title, createdAt]
+      properties:
+        id: {type: string, readOnly: true}
+        title: {type: string}
+        createdAt: {type: string, format: date-time, readOnly: true}
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              name: {type: string}
+              addedAt: {type: string, readOnly: true}
+`
+
+func TestConvert_StripsReadOnlyFromRequestBodies(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(readOnlySpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tools := make(map[string]Tool)
+	for _, tool := range config.Tools {
+		tools[tool.Name] = tool
+	}
+
+	for _, name := range []string{"createTodo", "replaceTodo", "updateTodo"} {
+		t.Run(name, func(t *testing.T) {
+			tool, ok := tools[name]
+			if !ok {
+				t.Fatalf("tool %s not generated", name)
+			}
+			var schema map[string]interface{}
+			if err := json.Unmarshal([]byte(tool.RawInputSchema), &schema); err != nil {
+				t.Fatalf("invalid input schema: %v", err)
+			}
+			body := schemaAt(t, schema, []string{"properties", "body"})
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				t.Fatalf("failed to encode body schema: %v", err)
+			}
+			bodySchema := string(encoded)
+			for _, field := range []string{`"readOnly"`, `"id"`, `"createdAt"`, `"addedAt"`, `"revision"`} {
+				if strings.Contains(bodySchema, field) {
+					t.Errorf("body schema still mentions %s:\n%s", field, bodySchema)
+				}
+			}
+			if !strings.Contains(bodySchema, `"title"`) || !strings.Contains(bodySchema, `"name"`) {
+				t.Errorf("body schema lost writable fields:\n%s", bodySchema)
+			}
+			if required, ok := body["required"].([]interface{}); ok {
+				for _, field := range required {
+					if field == "id" || field == "createdAt" {
+						t.Errorf("readOnly field %v is still required", field)
+					}
+				}
+			}
+
+			var documented bool
+			for _, response := range tool.Responses {
+				documented = documented || strings.Contains(response.PrependBody, "createdAt")
+			}
+			if !documented {
+				t.Errorf("response template should keep readOnly fields")
+			}
+		})
+	}
+}
+
+const readOnlyConflictSpec = `openapi: 3.0.3
+info: {title: ReadOnly, version: "1.0"}
+paths:
+  /todos:
+    post:
+      operationId: createTodo
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/CreateTodo'
+                - type: object
+                  required: [id, owner]
+                  properties:
+                    owner: {type: string}
+      responses:
+        '201': {description: Created}
+components:
+  schemas:
+    CreateTodo:
+      type: object
+      required: [title, createdAt, priority]
+      properties:
+        id: {type: string, readOnly: true}
+        title: {type: string}
+        createdAt: {type: string, format: date-time, readOnly: true}
+        priority: {type: integer, default: 3}
+`
+
+func TestConvert_RequiredReadOnlyConflicts(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(readOnlyConflictSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	bodySchema := config.Tools[0].RawInputSchema
+	for _, field := range []string{`"id"`, `"createdAt"`} {
+		if strings.Contains(bodySchema, field) {
+			t.Errorf("input schema still mentions %s:\n%s", field, bodySchema)
+		}
+	}
+	for _, field := range []string{`"title"`, `"owner"`, `"priority"`} {
+		if !strings.Contains(bodySchema, field) {
+			t.Errorf("input schema lost %s:\n%s", field, bodySchema)
+		}
+	}
+
+	warnings := strings.Join(config.Warnings, "\n")
+	for _, want := range []string{
+		"request body application/json: body.createdAt is both required and readOnly, leaving it out of the request body",
+		"request body application/json: body.id is both required and readOnly, leaving it out of the request body",
+		"request body application/json: body.priority is required but has a default, which never applies",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("expected warning %q, got:\n%s", want, warnings)
+		}
+	}
+	if strings.Contains(warnings, "body.title") || strings.Contains(warnings, "body.owner") {
+		t.Errorf("expected no warnings for consistent fields, got:\n%s", warnings)
+	}
+}
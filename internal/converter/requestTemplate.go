@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -8,18 +10,9 @@ import (
 
 // createRequestTemplate creates an MCP request template from an OpenAPI operation
 func (c *Converter) createRequestTemplate(path, method string, operation *openapi3.Operation) (*RequestTemplate, error) {
-	// Get the server URL from the OpenAPI specification
-	var serverURL string
-	if servers := c.parser.GetDocument().Servers; len(servers) > 0 {
-		serverURL = servers[0].URL
-	}
-
-	// Remove trailing slash from server URL if present
-	serverURL = strings.TrimSuffix(serverURL, "/")
-
 	// Create the request template
 	template := &RequestTemplate{
-		URL:     serverURL + path,
+		URL:     joinServerURL(c.serverURL(), path),
 		Method:  strings.ToUpper(method),
 		Headers: []Header{},
 	}
@@ -36,7 +29,137 @@ func (c *Converter) createRequestTemplate(path, method string, operation *openap
 		}
 	}
 
+	c.addSecurityHeaders(template, operation)
+
 	return template, nil
 }
 
+// addSecurityHeaders adds header placeholders for the operation's security requirement.
+// Only the first alternative of the requirement list is used. Credentials are never written
+// into the template: header values reference environment variables as ${NAME}, resolved at runtime.
+func (c *Converter) addSecurityHeaders(template *RequestTemplate, operation *openapi3.Operation) {
+	doc := c.parser.GetDocument()
+	requirements := doc.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+	if len(requirements) == 0 || doc.Components == nil {
+		return
+	}
+
+	schemeNames := make([]string, 0, len(requirements[0]))
+	for name := range requirements[0] {
+		schemeNames = append(schemeNames, name)
+	}
+	sort.Strings(schemeNames)
+
+	for _, name := range schemeNames {
+		schemeRef := doc.Components.SecuritySchemes[name]
+		if schemeRef == nil || schemeRef.Value == nil {
+			continue
+		}
+		template.Security = append(template.Security, ToolSecurityRequirement{ID: name})
 
+		scheme := schemeRef.Value
+		placeholder := "${" + envVarName(name) + "}"
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "header" && scheme.Name != "":
+			template.Headers = append(template.Headers, Header{Key: scheme.Name, Value: placeholder})
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+			template.Headers = append(template.Headers, Header{Key: "Authorization", Value: "Bearer " + placeholder})
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			template.Headers = append(template.Headers, Header{Key: "Authorization", Value: "Basic " + placeholder})
+		default:
+			c.unsupportedf("security scheme %s (%s)", name, describeSecurityScheme(scheme))
+		}
+	}
+}
+
+// describeSecurityScheme summarizes a security scheme's type for unsupported feature reports
+func describeSecurityScheme(scheme *openapi3.SecurityScheme) string {
+	switch {
+	case scheme.Type == "apiKey":
+		return "apiKey in " + scheme.In
+	case scheme.Type == "http":
+		return "http " + scheme.Scheme
+	}
+	return scheme.Type
+}
+
+// envVarName turns a security scheme name into an environment variable name (api-key -> API_KEY)
+func envVarName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// serverURL returns the spec's first server URL with its variables resolved, or "" when none is declared
+func (c *Converter) serverURL() string {
+	if servers := c.parser.GetDocument().Servers; len(servers) > 0 {
+		return resolveServerURL(servers[0])
+	}
+	return ""
+}
+
+// apiVersion returns the spec's info.version, or "" when the spec has no info object
+func (c *Converter) apiVersion() string {
+	if info := c.parser.GetDocument().Info; info != nil {
+		return info.Version
+	}
+	return ""
+}
+
+// resolveServerURL substitutes server variables ({region}) with their default values, falling
+// back to the first enum value. Variables without either are left untouched.
+func resolveServerURL(server *openapi3.Server) string {
+	if server == nil {
+		return ""
+	}
+	resolved := server.URL
+	for name, variable := range server.Variables {
+		value := serverVariableDefault(variable)
+		if value == "" {
+			continue
+		}
+		resolved = strings.ReplaceAll(resolved, "{"+name+"}", value)
+	}
+	return resolved
+}
+
+// joinServerURL joins a server URL and an operation path with exactly one slash between them.
+// If the path already starts with the server's base path (e.g. server ".../v1" and path "/v1/users"),
+// the base path is not repeated.
+func joinServerURL(serverURL, path string) string {
+	serverURL = strings.TrimRight(serverURL, "/")
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if serverURL == "" {
+		return path
+	}
+
+	basePath := serverURL
+	if parsed, err := url.Parse(serverURL); err == nil && parsed.Host != "" {
+		basePath = parsed.Path
+	}
+	basePath = strings.TrimRight(basePath, "/")
+	if basePath != "" && (path == basePath || strings.HasPrefix(path, basePath+"/")) {
+		path = strings.TrimPrefix(path, basePath)
+	}
+
+	return serverURL + path
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -110,3 +111,184 @@ func TestCreateRequestTemplate_ServerNoTrailingSlash(t *testing.T) {
 		t.Errorf("expected Content-Type header with application/json, got %+v", template.Headers)
 	}
 }
+
+func TestCreateRequestTemplate_TemplatedServer(t *testing.T) {
+	doc := &openapi3.T{
+		Servers: openapi3.Servers{
+			&openapi3.Server{
+				URL: "https://{region}.api.example.com/{version}/",
+				Variables: map[string]*openapi3.ServerVariable{
+					"region":  {Default: "eu", Enum: []string{"eu", "us"}},
+					"version": {Default: "v2"},
+				},
+			},
+		},
+	}
+	parser := &Parser{doc: doc}
+	c := &Converter{parser: parser}
+
+	template, err := c.createRequestTemplate("/users", "get", &openapi3.Operation{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.URL != "https://eu.api.example.com/v2/users" {
+		t.Errorf("expected URL 'https://eu.api.example.com/v2/users', got %q", template.URL)
+	}
+}
+
+func TestJoinServerURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		serverURL string
+		path      string
+		want      string
+	}{
+		{"plain join", "https://api.example.com", "/users", "https://api.example.com/users"},
+		{"trailing slash", "https://api.example.com/", "/users", "https://api.example.com/users"},
+		{"missing leading slash", "https://api.example.com", "users", "https://api.example.com/users"},
+		{"base path", "https://api.example.com/v1", "/users", "https://api.example.com/v1/users"},
+		{"base path already in path", "https://api.example.com/v1", "/v1/users", "https://api.example.com/v1/users"},
+		{"base path equals path", "https://api.example.com/v1/", "/v1", "https://api.example.com/v1"},
+		{"base path prefix but not segment", "https://api.example.com/v1", "/v10/users", "https://api.example.com/v1/v10/users"},
+		{"relative server", "/api", "/api/users", "/api/users"},
+		{"no server", "", "/users", "/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinServerURL(tt.serverURL, tt.path); got != tt.want {
+				t.Errorf("joinServerURL(%q, %q) = %q, want %q", tt.serverURL, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveServerURL(t *testing.T) {
+	server := &openapi3.Server{
+		URL: "https://{tenant}.example.com:{port}",
+		Variables: map[string]*openapi3.ServerVariable{
+			"tenant": {Default: "acme"},
+			"port":   {},
+		},
+	}
+	if got := resolveServerURL(server); got != "https://acme.example.com:{port}" {
+		t.Errorf("resolveServerURL() = %q, want %q", got, "https://acme.example.com:{port}")
+	}
+	if got := resolveServerURL(nil); got != "" {
+		t.Errorf("resolveServerURL(nil) = %q, want empty", got)
+	}
+}
+
+const serverVariablesSpec = `openapi: 3.0.3
+info: {title: Regions, version: "1.0"}
+servers:
+  - url: https://{region}.api.example.com/{version}
+    variables:
+      region:
+        default: us
+        enum: [eu, us, ap]
+        description: Where the data is stored
+      version:
+        enum: [v2, v1]
+      tier:
+        default: gold
+        enum: [free, pro]
+paths: {}
+`
+
+func TestConvert_ServerVariables(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(serverVariablesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if want := "https://us.api.example.com/v2"; config.Server.URL != want {
+		t.Errorf("Server.URL = %q, want %q", config.Server.URL, want)
+	}
+	if want := "https://{region}.api.example.com/{version}"; config.Server.URLTemplate != want {
+		t.Errorf("Server.URLTemplate = %q, want %q", config.Server.URLTemplate, want)
+	}
+	wantVariables := []ServerVariable{
+		{Name: "region", Default: "us", Enum: []string{"eu", "us", "ap"}, Description: "Where the data is stored"},
+		{Name: "tier", Default: "gold", Enum: []string{"free", "pro"}},
+		{Name: "version", Default: "v2", Enum: []string{"v2", "v1"}},
+	}
+	if !reflect.DeepEqual(config.Server.Variables, wantVariables) {
+		t.Errorf("Server.Variables = %+v, want %+v", config.Server.Variables, wantVariables)
+	}
+
+	wantWarnings := []string{
+		`server variable tier: default "gold" is not one of its enum values free, pro`,
+		`server variable version has no default, using its first enum value "v2"`,
+	}
+	if !reflect.DeepEqual(config.Warnings, wantWarnings) {
+		t.Errorf("Warnings = %q, want %q", config.Warnings, wantWarnings)
+	}
+}
+
+func TestCreateRequestTemplate_SecurityHeaders(t *testing.T) {
+	doc := &openapi3.T{
+		Servers:  openapi3.Servers{&openapi3.Server{URL: "https://api.example.com"}},
+		Security: openapi3.SecurityRequirements{{"api-key": {}}},
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"api-key":    {Value: &openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}},
+				"bearerAuth": {Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"}},
+				"queryKey":   {Value: &openapi3.SecurityScheme{Type: "apiKey", In: "query", Name: "key"}},
+			},
+		},
+	}
+	c := &Converter{parser: &Parser{doc: doc}}
+
+	// Document-level security applies by default
+	template, err := c.createRequestTemplate("/items", "get", &openapi3.Operation{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Header{{Key: "X-API-Key", Value: "${API_KEY}"}}
+	if !reflect.DeepEqual(template.Headers, want) {
+		t.Errorf("headers = %+v, want %+v", template.Headers, want)
+	}
+	if len(template.Security) != 1 || template.Security[0].ID != "api-key" {
+		t.Errorf("security = %+v, want [api-key]", template.Security)
+	}
+
+	// Operation-level security overrides the document default
+	opSecurity := openapi3.SecurityRequirements{{"bearerAuth": {}, "queryKey": {}}}
+	template, err = c.createRequestTemplate("/items", "get", &openapi3.Operation{Security: &opSecurity})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []Header{{Key: "Authorization", Value: "Bearer ${BEARERAUTH}"}}
+	if !reflect.DeepEqual(template.Headers, want) {
+		t.Errorf("headers = %+v, want %+v", template.Headers, want)
+	}
+
+	// An empty operation-level requirement list disables security
+	noSecurity := openapi3.SecurityRequirements{}
+	template, err = c.createRequestTemplate("/items", "get", &openapi3.Operation{Security: &noSecurity})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(template.Headers) != 0 {
+		t.Errorf("expected no headers, got %+v", template.Headers)
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	tests := map[string]string{
+		"api_key":    "API_KEY",
+		"api-key":    "API_KEY",
+		"bearerAuth": "BEARERAUTH",
+		"2fa token":  "_2FA_TOKEN",
+	}
+	for in, want := range tests {
+		if got := envVarName(in); got != want {
+			t.Errorf("envVarName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseResponseCodes parses a comma-separated list of status codes to document: single codes
+// ("200"), ranges ("400-404"), classes ("4xx") and "default". An empty list selects every response.
+func ParseResponseCodes(list string) (ResponseCodeSet, error) {
+	var set ResponseCodeSet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == "default" {
+			set.Default = true
+			continue
+		}
+		codeRange, err := parseResponseCodeRange(entry)
+		if err != nil {
+			return ResponseCodeSet{}, err
+		}
+		set.Ranges = append(set.Ranges, codeRange)
+	}
+	return set, nil
+}
+
+// parseResponseCodeRange parses "200", "400-404" or "4xx"
+func parseResponseCodeRange(entry string) (ResponseCodeRange, error) {
+	if class, ok := statusCodeClass(entry); ok {
+		return ResponseCodeRange{Min: class * 100, Max: class*100 + 99}, nil
+	}
+
+	minText, maxText, isRange := strings.Cut(entry, "-")
+	if !isRange {
+		maxText = minText
+	}
+	minCode, errMin := strconv.Atoi(strings.TrimSpace(minText))
+	maxCode, errMax := strconv.Atoi(strings.TrimSpace(maxText))
+	if errMin != nil || errMax != nil || minCode < 100 || maxCode > 599 || minCode > maxCode {
+		return ResponseCodeRange{}, fmt.Errorf("invalid response code %q: use a status code (200), a range (400-404), a class (4xx) or default", entry)
+	}
+	return ResponseCodeRange{Min: minCode, Max: maxCode}, nil
+}
+
+// statusCodeClass returns the leading digit of a status code class such as "4XX" or "4xx"
+func statusCodeClass(code string) (int, bool) {
+	if len(code) != 3 || !strings.EqualFold(code[1:], "xx") || code[0] < '1' || code[0] > '5' {
+		return 0, false
+	}
+	return int(code[0] - '0'), true
+}
+
+// Includes reports whether a response key from the spec ("200", "4XX" or "default") is selected.
+// A class such as "4XX" is selected when any code it covers is.
+func (s ResponseCodeSet) Includes(code string) bool {
+	if len(s.Ranges) == 0 && !s.Default {
+		return true
+	}
+	if code == "default" {
+		return s.Default
+	}
+
+	low, high := 0, -1
+	if class, ok := statusCodeClass(code); ok {
+		low, high = class*100, class*100+99
+	} else if status, err := strconv.Atoi(code); err == nil {
+		low, high = status, status
+	}
+	for _, codeRange := range s.Ranges {
+		if codeRange.Min <= high && low <= codeRange.Max {
+			return true
+		}
+	}
+	return false
+}
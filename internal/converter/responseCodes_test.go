@@ -0,0 +1,101 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParseResponseCodes(t *testing.T) {
+	got, err := ParseResponseCodes("200, 201,4xx,500-503,default")
+	if err != nil {
+		t.Fatalf("ParseResponseCodes failed: %v", err)
+	}
+	want := ResponseCodeSet{
+		Ranges:  []ResponseCodeRange{{200, 200}, {201, 201}, {400, 499}, {500, 503}},
+		Default: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseResponseCodes = %+v, want %+v", got, want)
+	}
+
+	if got, err := ParseResponseCodes(""); err != nil || !reflect.DeepEqual(got, ResponseCodeSet{}) {
+		t.Errorf("ParseResponseCodes(\"\") = %+v, %v; want the zero set", got, err)
+	}
+
+	for _, invalid := range []string{"abc", "99", "600", "404-400", "6xx", "2x"} {
+		if _, err := ParseResponseCodes(invalid); err == nil {
+			t.Errorf("ParseResponseCodes(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestResponseCodeSet_Includes(t *testing.T) {
+	set := ResponseCodeSet{Ranges: []ResponseCodeRange{{200, 201}, {404, 404}}}
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"200", true},
+		{"201", true},
+		{"204", false},
+		{"404", true},
+		{"400", false},
+		{"4XX", true},
+		{"5XX", false},
+		{"default", false},
+	}
+	for _, tt := range tests {
+		if got := set.Includes(tt.code); got != tt.want {
+			t.Errorf("Includes(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+
+	all := ResponseCodeSet{}
+	for _, code := range []string{"200", "5XX", "default"} {
+		if !all.Includes(code) {
+			t.Errorf("zero set should include %q", code)
+		}
+	}
+	if !(ResponseCodeSet{Default: true}).Includes("default") || (ResponseCodeSet{Default: true}).Includes("200") {
+		t.Error("a default-only set should select only the default response")
+	}
+}
+
+func TestCreateResponseTemplates_ResponseCodes(t *testing.T) {
+	op := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	for _, code := range []string{"200", "201", "404", "500", "default"} {
+		op.Responses.Set(code, &openapi3.ResponseRef{Value: &openapi3.Response{
+			Description: func(s string) *string { return &s }("response " + code),
+			Content: openapi3.Content{
+				"application/json": &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: openapi3.NewObjectSchema()}},
+			},
+		}})
+	}
+
+	codes, err := ParseResponseCodes("200-299,404")
+	if err != nil {
+		t.Fatalf("ParseResponseCodes failed: %v", err)
+	}
+	c := &Converter{options: ConvertOptions{ResponseCodes: codes}}
+	templates, err := c.createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("createResponseTemplates failed: %v", err)
+	}
+	var got []int
+	for _, template := range templates {
+		got = append(got, template.StatusCode)
+	}
+	if !reflect.DeepEqual(got, []int{200, 201, 404}) {
+		t.Errorf("documented status codes = %v, want [200 201 404]", got)
+	}
+
+	all, err := (&Converter{}).createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("createResponseTemplates failed: %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected every response to be documented by default, got %d templates", len(all))
+	}
+}
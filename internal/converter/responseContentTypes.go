@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ParseContentTypes parses a comma-separated list of media types, such as "text/html,image/*",
+// into lowercase media types without parameters. A subtype of * matches every subtype of the type.
+func ParseContentTypes(list string) ([]string, error) {
+	var contentTypes []string
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		mediaType, _, err := mime.ParseMediaType(entry)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, fmt.Errorf("invalid content type %q: use a media type such as text/html or text/*", entry)
+		}
+		contentTypes = append(contentTypes, mediaType)
+	}
+	return contentTypes, nil
+}
+
+// normalizeMediaType returns a content type's media type without parameters, lowercased; content
+// types that do not parse are only trimmed and lowercased
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// excludesResponseContentType reports whether ExcludeResponseContentTypes lists a content type,
+// ignoring its parameters and case; text/* excludes every text subtype
+func (c *Converter) excludesResponseContentType(contentType string) bool {
+	mediaType := normalizeMediaType(contentType)
+	for _, excluded := range c.options.ExcludeResponseContentTypes {
+		excluded = strings.ToLower(excluded)
+		if mediaType == excluded {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(excluded, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// documentedResponseContent returns a response's content without the excluded content types,
+// or content itself when nothing is excluded
+func (c *Converter) documentedResponseContent(content openapi3.Content) openapi3.Content {
+	if len(c.options.ExcludeResponseContentTypes) == 0 {
+		return content
+	}
+	documented := make(openapi3.Content, len(content))
+	for contentType, mediaType := range content {
+		if !c.excludesResponseContentType(contentType) {
+			documented[contentType] = mediaType
+		}
+	}
+	return documented
+}
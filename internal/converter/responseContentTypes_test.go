@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const responseContentTypesSpec = `openapi: 3.0.3
+info: {title: Pages, version: "1.0"}
+paths:
+  /pages/{id}:
+    get:
+      operationId: getPage
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: A page
+          content:
+            application/json:
+              schema: {type: object, properties: {title: {type: string}}}
+            text/html; charset=utf-8:
+              schema: {type: string}
+        '500':
+          description: Error page
+          content:
+            text/plain:
+              schema: {type: string}
+`
+
+func TestCreateResponseTemplates_ExcludeContentTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		exclude []string
+		want    []string
+	}{
+		{name: "nothing excluded", want: []string{"200 application/json", "200 text/html; charset=utf-8", "500 text/plain"}},
+		{name: "exact media type", exclude: []string{"text/html"}, want: []string{"200 application/json", "500 text/plain"}},
+		{name: "wildcard", exclude: []string{"TEXT/*"}, want: []string{"200 application/json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(responseContentTypesSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			config, err := NewConverterWithOptions(parser, ConvertOptions{ExcludeResponseContentTypes: tt.exclude}).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			var got []string
+			for _, response := range config.Tools[0].Responses {
+				got = append(got, fmt.Sprintf("%d %s", response.StatusCode, response.ContentType))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("response templates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseContentTypes(t *testing.T) {
+	got, err := ParseContentTypes(" Text/HTML , image/*,, application/xml; charset=utf-8")
+	if err != nil {
+		t.Fatalf("ParseContentTypes failed: %v", err)
+	}
+	if want := []string{"text/html", "image/*", "application/xml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseContentTypes() = %v, want %v", got, want)
+	}
+	for _, invalid := range []string{"html", "text/html;;="} {
+		if _, err := ParseContentTypes(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestNormalizeMediaType(t *testing.T) {
+	tests := map[string]string{
+		"application/json":                  "application/json",
+		"Application/Problem+JSON":          "application/problem+json",
+		"text/html; charset=utf-8":          "text/html",
+		" application/xml ":                 "application/xml",
+		"Application/JSON; charset=\"utf-8": "application/json; charset=\"utf-8",
+	}
+	for contentType, want := range tests {
+		if got := normalizeMediaType(contentType); got != want {
+			t.Errorf("normalizeMediaType(%q) = %q, want %q", contentType, got, want)
+		}
+	}
+}
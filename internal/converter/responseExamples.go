@@ -0,0 +1,225 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// responseExample is a media-type level example of a response body
+type responseExample struct {
+	Label       string
+	ContentType string
+	Value       interface{}
+	// ExternalValue is the URL of an example that is not embedded in the spec
+	ExternalValue string
+}
+
+// formatPlaceholders are the values synthesized for strings of well-known formats
+var formatPlaceholders = map[string]string{
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"email":     "user@example.com",
+	"date":      "2024-01-01",
+	"date-time": "2024-01-01T00:00:00Z",
+	"time":      "00:00:00Z",
+	"ipv4":      "192.0.2.1",
+	"uri":       "https://example.com",
+}
+
+// collectResponseExamples gathers the media-type level examples of a response's content types, in
+// the given order. The single `example` comes first, then named `examples` sorted by name;
+// examples that only point at an externalValue keep its URL.
+func (c *Converter) collectResponseExamples(content openapi3.Content, contentTypes []string) []responseExample {
+	var examples []responseExample
+	for _, contentType := range contentTypes {
+		mediaType := content[contentType]
+		if mediaType == nil {
+			continue
+		}
+		if mediaType.Example != nil {
+			examples = append(examples, responseExample{ContentType: contentType, Value: mediaType.Example})
+		}
+
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			exampleRef := mediaType.Examples[name]
+			if exampleRef == nil {
+				continue
+			}
+			if exampleRef.Value == nil {
+				c.warnf("%s: unresolved example $ref %q, leaving example %s out of the response template", c.location, exampleRef.Ref, name)
+				continue
+			}
+			if exampleRef.Value.Value == nil && exampleRef.Value.ExternalValue == "" {
+				continue
+			}
+			label := exampleRef.Value.Summary
+			if label == "" {
+				label = name
+			}
+			examples = append(examples, responseExample{
+				Label:         label,
+				ContentType:   contentType,
+				Value:         exampleRef.Value.Value,
+				ExternalValue: exampleRef.Value.ExternalValue,
+			})
+		}
+	}
+	return examples
+}
+
+// writeResponseExamples adds an "## Example Response" section with the documented examples as
+// fenced blocks. Without documented examples, an example synthesized from the schema is written
+// instead when ConvertOptions.SynthesizeResponseExamples is set and the content type is JSON.
+// Fences use tildes because templates are embedded in Go raw strings, which cannot hold backticks.
+func (c *Converter) writeResponseExamples(
+	b *strings.Builder,
+	examples []responseExample,
+	contentTypes []string,
+	schema *openapi3.Schema,
+) {
+	if len(examples) == 0 {
+		if !c.options.SynthesizeResponseExamples || schema == nil || mediaTypeSyntax(contentTypes[0]) != "json" {
+			return
+		}
+		value := synthesizeExample(schema, map[*openapi3.Schema]bool{})
+		if value == nil {
+			return
+		}
+		examples = []responseExample{{Label: "Synthesized from the schema", ContentType: contentTypes[0], Value: value}}
+	}
+
+	if !strings.HasSuffix(b.String(), "\n\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("## Example Response\n\n")
+	for _, example := range examples {
+		label := example.Label
+		if label == "" {
+			label = "Example"
+		}
+		if len(contentTypes) > 1 {
+			label = fmt.Sprintf("%s (%s)", label, example.ContentType)
+		}
+		if example.Value == nil {
+			b.WriteString(fmt.Sprintf("%s: see %s\n\n", label, example.ExternalValue))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s:\n\n", label))
+		fence := "~~~"
+		if syntax := mediaTypeSyntax(example.ContentType); syntax == "json" || syntax == "xml" {
+			fence += syntax
+		}
+		b.WriteString(fence + "\n")
+		b.WriteString(strings.ReplaceAll(formatExampleValue(example.Value), "`", "'"))
+		b.WriteString("\n~~~\n\n")
+	}
+}
+
+// responseExampleValue returns the first documented example of a response's content types, in
+// the order collectResponseExamples lists them, or an example synthesized from the schema when the
+// content type is JSON. Examples that only point at an externalValue are skipped.
+func responseExampleValue(content openapi3.Content, contentTypes []string, schema *openapi3.Schema) interface{} {
+	for _, contentType := range contentTypes {
+		mediaType := content[contentType]
+		if mediaType == nil {
+			continue
+		}
+		if mediaType.Example != nil {
+			return mediaType.Example
+		}
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if exampleRef := mediaType.Examples[name]; exampleRef != nil && exampleRef.Value != nil && exampleRef.Value.Value != nil {
+				return exampleRef.Value.Value
+			}
+		}
+	}
+	if schema == nil || mediaTypeSyntax(contentTypes[0]) != "json" {
+		return nil
+	}
+	return synthesizeExample(schema, map[*openapi3.Schema]bool{})
+}
+
+// synthesizeExample builds an example value from a schema: its own example, default or first enum
+// value when documented, otherwise a placeholder of its type. Objects list their non-writeOnly
+// properties, arrays hold one item, and oneOf/anyOf take their first option. Recursive schemas
+// are cut off where they recurse; nil is returned when nothing can be derived.
+func synthesizeExample(schema *openapi3.Schema, visiting map[*openapi3.Schema]bool) interface{} {
+	if schema == nil || visiting[schema] {
+		return nil
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := map[string]interface{}{}
+		for _, part := range schema.AllOf {
+			if part == nil {
+				continue
+			}
+			if object, ok := synthesizeExample(part.Value, visiting).(map[string]interface{}); ok {
+				for name, value := range object {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, options := range [][]*openapi3.SchemaRef{schema.OneOf, schema.AnyOf} {
+		if len(options) > 0 && options[0] != nil {
+			return synthesizeExample(options[0].Value, visiting)
+		}
+	}
+
+	switch {
+	case isObject(schema) || len(schema.Properties) > 0:
+		object := map[string]interface{}{}
+		for name, propRef := range schema.Properties {
+			if propRef == nil || propRef.Value == nil || propRef.Value.WriteOnly {
+				continue
+			}
+			if value := synthesizeExample(propRef.Value, visiting); value != nil {
+				object[name] = value
+			}
+		}
+		return object
+	case isArray(schema):
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		if item := synthesizeExample(schema.Items.Value, visiting); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case schema.Type.Includes("string"):
+		if placeholder, ok := formatPlaceholders[schema.Format]; ok {
+			return placeholder
+		}
+		return "string"
+	case schema.Type.Includes("integer"), schema.Type.Includes("number"):
+		return 0
+	case schema.Type.Includes("boolean"):
+		return false
+	}
+	return nil
+}
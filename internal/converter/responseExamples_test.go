@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const responseExamplesSpec = `openapi: 3.0.3
+info: {title: Examples, version: "1.0"}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: A pet
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: string}
+              examples:
+                dog:
+                  summary: A dog
+                  value: {name: "Rex ` + "`the dog`" + `"}
+                cat:
+                  value: {name: Tom}
+                remote:
+                  externalValue: https://example.com/pet.json
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code: {type: integer}
+                  message: {type: string, example: not found}
+                  since: {type: string, format: date-time}
+                  tags: {type: array, items: {type: string, enum: [a, b]}}
+                  secret: {type: string, writeOnly: true}
+`
+
+func convertResponseExamplesSpec(t *testing.T, options ConvertOptions) map[int]string {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(responseExamplesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverterWithOptions(parser, options).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	templates := map[int]string{}
+	for _, response := range config.Tools[0].Responses {
+		templates[response.StatusCode] = response.PrependBody
+	}
+	return templates
+}
+
+func TestResponseTemplate_Examples(t *testing.T) {
+	templates := convertResponseExamplesSpec(t, ConvertOptions{})
+	ok := templates[200]
+	for _, want := range []string{
+		"## Example Response\n\n",
+		"cat:\n\n~~~json\n{\n  \"name\": \"Tom\"\n}\n~~~\n\n",
+		"A dog:\n\n~~~json\n{\n  \"name\": \"Rex 'the dog'\"\n}\n~~~\n\n",
+		"remote: see https://example.com/pet.json\n\n",
+	} {
+		if !strings.Contains(ok, want) {
+			t.Errorf("200 template missing %q\n%s", want, ok)
+		}
+	}
+	if strings.Index(ok, "cat:") > strings.Index(ok, "A dog:") {
+		t.Errorf("expected examples sorted by name\n%s", ok)
+	}
+	if strings.Contains(ok, "`") {
+		t.Errorf("template contains a backtick, which cannot be embedded in a Go raw string\n%s", ok)
+	}
+
+	// Without documented examples nothing is synthesized by default
+	if strings.Contains(templates[404], "## Example Response") {
+		t.Errorf("expected no example in the 404 template\n%s", templates[404])
+	}
+}
+
+func TestResponseTemplate_SynthesizedExamples(t *testing.T) {
+	templates := convertResponseExamplesSpec(t, ConvertOptions{SynthesizeResponseExamples: true})
+	want := "Synthesized from the schema:\n\n~~~json\n{\n" +
+		"  \"code\": 0,\n  \"message\": \"not found\",\n  \"since\": \"2024-01-01T00:00:00Z\",\n  \"tags\": [\n    \"a\"\n  ]\n}\n~~~\n\n"
+	if !strings.Contains(templates[404], want) {
+		t.Errorf("404 template missing %q\n%s", want, templates[404])
+	}
+	// Documented examples take precedence
+	if strings.Contains(templates[200], "Synthesized") {
+		t.Errorf("expected only the documented examples in the 200 template\n%s", templates[200])
+	}
+}
+
+func Test_synthesizeExample_Recursive(t *testing.T) {
+	node := openapi3.NewObjectSchema()
+	node.Properties = openapi3.Schemas{
+		"value":    openapi3.NewBoolSchema().NewRef(),
+		"children": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: &openapi3.SchemaRef{Value: node}}},
+	}
+	got := synthesizeExample(node, map[*openapi3.Schema]bool{})
+	want := map[string]interface{}{"value": false, "children": []interface{}{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("synthesizeExample() = %v, want %v", got, want)
+	}
+}
+
+func TestResponseTemplate_ExampleValue(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(responseExamplesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	examples := map[int]interface{}{}
+	for _, response := range config.Tools[0].Responses {
+		examples[response.StatusCode] = response.Example
+	}
+
+	// The first documented example in name order
+	if want := map[string]interface{}{"name": "Tom"}; !reflect.DeepEqual(examples[200], want) {
+		t.Errorf("200 example = %v, want %v", examples[200], want)
+	}
+	// Synthesized from the schema, even without SynthesizeResponseExamples
+	want := map[string]interface{}{"code": 0, "message": "not found", "since": "2024-01-01T00:00:00Z", "tags": []interface{}{"a"}}
+	if !reflect.DeepEqual(examples[404], want) {
+		t.Errorf("404 example = %#v, want %#v", examples[404], want)
+	}
+}
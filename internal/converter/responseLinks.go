@@ -0,0 +1,172 @@
+package converter
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// writeResponseLinks adds a "## Related Operations" section documenting the response's OpenAPI
+// links: the tool each link leads to and which values of this response or request feed its
+// parameters and body, so the model can chain calls. Links to operations that cannot be found
+// are reported as warnings and left out.
+func (c *Converter) writeResponseLinks(b *strings.Builder, links openapi3.Links) {
+	names := make([]string, 0, len(links))
+	for name := range links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		linkRef := links[name]
+		if linkRef == nil {
+			continue
+		}
+		if linkRef.Value == nil {
+			c.warnf("%s: unresolved link $ref %q, leaving link %s out of the response template", c.location, linkRef.Ref, name)
+			continue
+		}
+		link := linkRef.Value
+		toolName, ok := c.linkedToolName(link)
+		if !ok {
+			target := link.OperationID
+			if target == "" {
+				target = link.OperationRef
+			}
+			c.warnf("%s: link %s targets unknown operation %q, leaving it out of the response template", c.location, name, target)
+			continue
+		}
+
+		var sentences []string
+		if desc := strings.TrimSpace(link.Description); desc != "" {
+			sentences = append(sentences, strings.TrimSuffix(desc, ".")+".")
+		}
+		params := make([]string, 0, len(link.Parameters))
+		for param := range link.Parameters {
+			params = append(params, param)
+		}
+		sort.Strings(params)
+		for _, param := range params {
+			sentences = append(sentences, fmt.Sprintf("Use %s as '%s'.", describeLinkValue(link.Parameters[param]), param))
+		}
+		if link.RequestBody != nil {
+			sentences = append(sentences, fmt.Sprintf("Use %s as the request body.", describeLinkValue(link.RequestBody)))
+		}
+		line := fmt.Sprintf("- **%s**", toolName)
+		if len(sentences) > 0 {
+			line += ": " + strings.Join(sentences, " ")
+		}
+		lines = append(lines, strings.ReplaceAll(line, "`", "'"))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	if !strings.HasSuffix(b.String(), "\n\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("## Related Operations\n\n")
+	b.WriteString("The following tools can be called next with values from this response:\n\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+}
+
+// linkedToolName returns the name of the tool a link leads to, as it is registered: the override
+// name when one is configured, otherwise the target's operationId with its first letter capitalized.
+// The target is looked up by operationId or by a local operationRef such as
+// "#/paths/~1todos~1{id}/get"; without a document, an operationId is trusted as is.
+func (c *Converter) linkedToolName(link *openapi3.Link) (string, bool) {
+	var doc *openapi3.T
+	if c.parser != nil {
+		doc = c.parser.GetDocument()
+	}
+	if doc == nil || doc.Paths == nil {
+		if link.OperationID == "" {
+			return "", false
+		}
+		return c.registeredToolName(link.OperationID), true
+	}
+
+	ref := link.OperationRef
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	for path, pathItem := range doc.Paths.Map() {
+		for method, operation := range pathItem.Operations() {
+			if link.OperationID != "" && operation.OperationID == link.OperationID ||
+				link.OperationID == "" && ref == operationRef(path, method) {
+				return c.registeredToolName(c.parser.GetOperationID(path, method, operation)), true
+			}
+		}
+	}
+	return "", false
+}
+
+// registeredToolName returns the name a tool converted from the named operation is registered under
+func (c *Converter) registeredToolName(name string) string {
+	if override := c.options.ToolOverrides[name].Name; override != "" {
+		return override
+	}
+	return Tool{Name: name}.RegisteredName()
+}
+
+// operationRef returns the local JSON pointer reference to an operation, with the path escaped as
+// JSON pointers require (e.g. "#/paths/~1todos~1{id}/get")
+func operationRef(path, method string) string {
+	escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(path)
+	return "#/paths/" + escaped + "/" + strings.ToLower(method)
+}
+
+// describeLinkValue describes a link parameter or request body value in words: runtime
+// expressions like $response.body#/id name the value they select, constants are quoted
+func describeLinkValue(value interface{}) string {
+	expression, ok := value.(string)
+	if !ok || !strings.HasPrefix(expression, "$") {
+		return formatEnumValue(value)
+	}
+
+	source, pointer, _ := strings.Cut(expression, "#")
+	field := ""
+	if pointer != "" {
+		field = jsonPointerField(pointer)
+	}
+	switch {
+	case source == "$response.body" && field != "":
+		return fmt.Sprintf("'%s' from this response body", field)
+	case source == "$response.body":
+		return "this response body"
+	case source == "$request.body" && field != "":
+		return fmt.Sprintf("'%s' from the request body", field)
+	case source == "$request.body":
+		return "the request body"
+	case strings.HasPrefix(source, "$response.header."):
+		return fmt.Sprintf("the %s response header", strings.TrimPrefix(source, "$response.header."))
+	case strings.HasPrefix(source, "$request."):
+		location, name, _ := strings.Cut(strings.TrimPrefix(source, "$request."), ".")
+		return fmt.Sprintf("the request's '%s' %s parameter", name, location)
+	case source == "$url":
+		return "the request URL"
+	case source == "$method":
+		return "the request method"
+	case source == "$statusCode":
+		return "the response status code"
+	}
+	return "'" + expression + "'"
+}
+
+// jsonPointerField renders a JSON pointer such as /items/0/id as a dotted field path (items.0.id)
+func jsonPointerField(pointer string) string {
+	if unescaped, err := url.PathUnescape(pointer); err == nil {
+		pointer = unescaped
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return strings.Join(tokens, ".")
+}
@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const responseLinksSpec = `openapi: 3.0.3
+info: {title: Todos, version: "1.0"}
+paths:
+  /todos:
+    post:
+      operationId: createTodo
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}
+          links:
+            GetTodo:
+              operationId: getTodoById
+              description: Fetch the created todo
+              parameters:
+                todoId: $response.body#/id
+            DeleteTodo:
+              operationRef: '#/paths/~1todos~1{todoId}/delete'
+              parameters:
+                todoId: $response.body#/id
+                force: true
+            Missing:
+              operationId: archiveTodo
+  /todos/{todoId}:
+    parameters:
+      - {name: todoId, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getTodoById
+      responses:
+        '200': {description: OK}
+    delete:
+      operationId: deleteTodo
+      responses:
+        '204': {description: Deleted}
+`
+
+func TestResponseTemplate_Links(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(responseLinksSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	options := ConvertOptions{ToolOverrides: map[string]ToolOverride{"deleteTodo": {Name: "remove_todo"}}}
+	config, err := NewConverterWithOptions(parser, options).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var markdown string
+	for _, tool := range config.Tools {
+		if tool.Name == "createTodo" {
+			markdown = tool.Responses[0].PrependBody
+		}
+	}
+	want := "## Related Operations\n\n" +
+		"The following tools can be called next with values from this response:\n\n" +
+		"- **remove_todo**: Use true as 'force'. Use 'id' from this response body as 'todoId'.\n" +
+		"- **GetTodoById**: Fetch the created todo. Use 'id' from this response body as 'todoId'.\n"
+	if !strings.HasSuffix(markdown, want) {
+		t.Errorf("response template does not end with %q\n%s", want, markdown)
+	}
+
+	found := false
+	for _, warning := range config.Warnings {
+		if strings.Contains(warning, `link Missing targets unknown operation "archiveTodo"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about the unknown link target, got %v", config.Warnings)
+	}
+}
+
+func Test_describeLinkValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"$response.body#/items/0/id", "'items.0.id' from this response body"},
+		{"$response.body", "this response body"},
+		{"$response.header.Location", "the Location response header"},
+		{"$request.path.id", "the request's 'id' path parameter"},
+		{"$request.body#/a~1b", "'a/b' from the request body"},
+		{"$statusCode", "the response status code"},
+		{"literal", "'literal'"},
+		{42, "42"},
+	}
+	for _, tt := range tests {
+		if got := describeLinkValue(tt.value); got != tt.want {
+			t.Errorf("describeLinkValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
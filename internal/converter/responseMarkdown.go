@@ -7,9 +7,10 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-// buildResponseMarkdown builds the Markdown documentation for a response.
+// buildResponseMarkdown builds the Markdown documentation for a response served as any of contentTypes.
 func (c *Converter) buildResponseMarkdown(
-	code, contentType string,
+	code string,
+	contentTypes []string,
 	responseRef *openapi3.ResponseRef,
 	schema *openapi3.Schema,
 ) string {
@@ -18,12 +19,25 @@ func (c *Converter) buildResponseMarkdown(
 	b.WriteString("Below is the response template for this API endpoint.\n\n")
 	b.WriteString("The template shows a possible response, including its status code and content type, to help you understand and generate correct outputs.\n\n")
 	b.WriteString(fmt.Sprintf("**Status Code:** %s\n\n", code))
-	b.WriteString(fmt.Sprintf("**Content-Type:** %s\n\n", contentType))
+	b.WriteString(fmt.Sprintf("**Content-Type:** %s\n\n", strings.Join(contentTypes, ", ")))
 	if desc := getResponseDescription(responseRef); desc != "" {
 		b.WriteString(fmt.Sprintf("> %s\n\n", desc))
 	}
-	b.WriteString("## Response Structure\n\n")
-	c.writeSchemaMarkdown(&b, schema, 0, "")
+	if isProblemDetails(contentTypes[0], schema) {
+		writeProblemDetailsMarkdown(&b)
+	}
+	if schema != nil {
+		b.WriteString("## Response Structure\n\n")
+		c.writeSchemaMarkdown(&b, schema, 0, "")
+	}
+	var examples []responseExample
+	if responseRef != nil && responseRef.Value != nil {
+		examples = c.collectResponseExamples(responseRef.Value.Content, contentTypes)
+	}
+	c.writeResponseExamples(&b, examples, contentTypes, schema)
+	if responseRef != nil && responseRef.Value != nil {
+		c.writeResponseLinks(&b, responseRef.Value.Links)
+	}
 	return b.String()
 }
 
@@ -63,6 +77,7 @@ func (c *Converter) writeSchemaMarkdown(
 }
 
 // writeSchemaProperties documents object properties and array items.
+// writeOnly properties are skipped: servers never return them in responses.
 func (c *Converter) writeSchemaProperties(
 	b *strings.Builder,
 	schema *openapi3.Schema,
@@ -71,14 +86,28 @@ func (c *Converter) writeSchemaProperties(
 	// Object properties
 	if isObject(schema) && len(schema.Properties) > 0 {
 		for propName, propRef := range schema.Properties {
-			if propRef != nil && propRef.Value != nil {
+			restore := c.at("property " + propName)
+			if propRef == nil || propRef.Value == nil {
+				ref := ""
+				if propRef != nil {
+					ref = propRef.Ref
+				}
+				c.warnMissingSchema(ref, "leaving it out of the response template")
+			} else if !propRef.Value.WriteOnly {
 				c.writeSchemaMarkdown(b, propRef.Value, indent+1, propName)
 			}
+			restore()
 		}
 	}
 	// Array items
-	if isArray(schema) && schema.Items != nil && schema.Items.Value != nil {
-		c.writeSchemaMarkdown(b, schema.Items.Value, indent+1, "Items")
+	if isArray(schema) && schema.Items != nil {
+		restore := c.at("items")
+		if schema.Items.Value == nil {
+			c.warnMissingSchema(schema.Items.Ref, "leaving them out of the response template")
+		} else {
+			c.writeSchemaMarkdown(b, schema.Items.Value, indent+1, "Items")
+		}
+		restore()
 	}
 }
 
@@ -113,7 +142,7 @@ func (c *Converter) writeSchemaCombinators(
 	}
 }
 
-// writeAdditionalProperties documents additionalProperties for objects.
+// writeAdditionalProperties documents additionalProperties and propertyNames for objects.
 func (c *Converter) writeAdditionalProperties(
 	b *strings.Builder,
 	schema *openapi3.Schema,
@@ -126,6 +155,11 @@ func (c *Converter) writeAdditionalProperties(
 	} else if isObject(schema) && schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
 		b.WriteString(fmt.Sprintf("%s  - **Allows Additional Properties**\n", ind))
 	}
+	// kin-openapi keeps the OpenAPI 3.1 propertyNames keyword in Extensions
+	if keys, err := extensionSchema(schema.Extensions, "propertyNames"); err == nil && keys != nil && isObject(schema) {
+		b.WriteString(fmt.Sprintf("%s  - **Keys must satisfy**:\n", ind))
+		c.writeSchemaMarkdown(b, keys, indent+2, "property name")
+	}
 }
 
 // writeSchemaDetails adds validation rules, examples, and default values in Markdown.
@@ -137,11 +171,12 @@ func (c *Converter) writeSchemaDetails(
 	ind := strings.Repeat("  ", indent)
 	var details []string
 
-	// String validations
+	// String validations. kin-openapi reads an absent minLength as 0, which constrains nothing either,
+	// while maxLength and maxItems are pointers so that an explicit 0 (empty only) is kept.
 	if schema.MinLength > 0 {
 		details = append(details, fmt.Sprintf("Min Length: %d", schema.MinLength))
 	}
-	if schema.MaxLength != nil && *schema.MaxLength > 0 {
+	if schema.MaxLength != nil {
 		details = append(details, fmt.Sprintf("Max Length: %d", *schema.MaxLength))
 	}
 	if schema.Pattern != "" {
@@ -150,10 +185,10 @@ func (c *Converter) writeSchemaDetails(
 
 	// Numeric validations
 	if schema.Min != nil {
-		details = append(details, fmt.Sprintf("Minimum: %v", *schema.Min))
+		details = append(details, fmt.Sprintf("Minimum: %v", numberValue(*schema.Min, schema.Type.Includes("integer"))))
 	}
 	if schema.Max != nil {
-		details = append(details, fmt.Sprintf("Maximum: %v", *schema.Max))
+		details = append(details, fmt.Sprintf("Maximum: %v", numberValue(*schema.Max, schema.Type.Includes("integer"))))
 	}
 	if schema.ExclusiveMin {
 		details = append(details, "Exclusive Minimum: true")
@@ -169,7 +204,7 @@ func (c *Converter) writeSchemaDetails(
 	if schema.MinItems > 0 {
 		details = append(details, fmt.Sprintf("Min Items: %d", schema.MinItems))
 	}
-	if schema.MaxItems != nil && *schema.MaxItems > 0 {
+	if schema.MaxItems != nil {
 		details = append(details, fmt.Sprintf("Max Items: %d", *schema.MaxItems))
 	}
 	if schema.UniqueItems {
@@ -193,7 +228,7 @@ func (c *Converter) writeSchemaDetails(
 	if len(schema.Enum) > 0 {
 		var enumStrings []string
 		for _, e := range schema.Enum {
-			enumStrings = append(enumStrings, fmt.Sprintf("'%s'", formatForGoRawString(schema, e)))
+			enumStrings = append(enumStrings, formatEnumValue(e))
 		}
 		details = append(details, fmt.Sprintf("Enum: [%s]", strings.Join(enumStrings, ", ")))
 	}
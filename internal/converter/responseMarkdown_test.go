@@ -31,6 +31,28 @@ func TestWriteSchemaDetails_StringValidations(t *testing.T) {
 	}
 }
 
+func TestWriteSchemaDetails_ZeroLengths(t *testing.T) {
+	c := &Converter{}
+	zero := uint64(0)
+	schemaType := openapi3.Types{"string"}
+	var b strings.Builder
+	c.writeSchemaDetails(&b, &openapi3.Schema{Type: &schemaType, MinLength: 0, MaxLength: &zero}, 0)
+	out := b.String()
+	if !strings.Contains(out, "Max Length: 0") {
+		t.Errorf("expected an explicit maxLength of 0 to be kept, got: %q", out)
+	}
+	if strings.Contains(out, "Min Length") {
+		t.Errorf("expected minLength 0 to be left out, got: %q", out)
+	}
+
+	arrayType := openapi3.Types{"array"}
+	b.Reset()
+	c.writeSchemaDetails(&b, &openapi3.Schema{Type: &arrayType, MaxItems: &zero}, 0)
+	if !strings.Contains(b.String(), "Max Items: 0") {
+		t.Errorf("expected an explicit maxItems of 0 to be kept, got: %q", b.String())
+	}
+}
+
 func TestWriteSchemaDetails_NumericValidations(t *testing.T) {
 	c := &Converter{}
 	min := 1.5
@@ -164,8 +186,37 @@ func TestWriteSchemaDetails_Enum_NonStringType(t *testing.T) {
 	var b strings.Builder
 	c.writeSchemaDetails(&b, schema, 0)
 	out := b.String()
-	if !strings.Contains(out, `Enum: ['{"a":1}', '{"b":2}']`) {
-		t.Errorf("expected Enum: ['{\"a\":1}', '{\"b\":2}'], got: %q", out)
+	if !strings.Contains(out, `Enum: [{"a":1}, {"b":2}]`) {
+		t.Errorf("expected Enum: [{\"a\":1}, {\"b\":2}], got: %q", out)
+	}
+}
+
+func TestWriteSchemaDetails_Enum_ByValueType(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		enum       []interface{}
+		want       string
+	}{
+		{"integer", "integer", []interface{}{float64(1), float64(2), float64(3)}, "Enum: [1, 2, 3]"},
+		{"number", "number", []interface{}{1.5, float64(-2)}, "Enum: [1.5, -2]"},
+		{"boolean", "boolean", []interface{}{true, false}, "Enum: [true, false]"},
+		{"mixed", "", []interface{}{"1", float64(1), true, nil, []interface{}{"a"}}, `Enum: ['1', 1, true, null, ["a"]]`},
+		{"numbers on a string schema", "string", []interface{}{float64(200), "ok"}, "Enum: [200, 'ok']"},
+		{"integer keys", "object", []interface{}{map[interface{}]interface{}{1: "one"}}, `Enum: [{"1":"one"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &openapi3.Schema{Enum: tt.enum}
+			if tt.schemaType != "" {
+				schema.Type = &openapi3.Types{tt.schemaType}
+			}
+			var b strings.Builder
+			(&Converter{}).writeSchemaDetails(&b, schema, 0)
+			if !strings.Contains(b.String(), tt.want) {
+				t.Errorf("expected %s, got: %q", tt.want, b.String())
+			}
+		})
 	}
 }
 
@@ -561,7 +612,7 @@ func TestBuildResponseMarkdown_Basic(t *testing.T) {
 			Description: func(s string) *string { return &s }("A test response"),
 		},
 	}
-	md := c.buildResponseMarkdown("200", "application/json", resp, schema)
+	md := c.buildResponseMarkdown("200", []string{"application/json"}, resp, schema)
 	if !strings.Contains(md, "# API Response Information") {
 		t.Errorf("expected header, got: %q", md)
 	}
@@ -594,7 +645,7 @@ func TestBuildResponseMarkdown_NoDescription(t *testing.T) {
 	resp := &openapi3.ResponseRef{
 		Value: &openapi3.Response{},
 	}
-	md := c.buildResponseMarkdown("404", "text/plain", resp, schema)
+	md := c.buildResponseMarkdown("404", []string{"text/plain"}, resp, schema)
 	if !strings.Contains(md, "**Status Code:** 404") {
 		t.Errorf("expected status code, got: %q", md)
 	}
@@ -643,4 +694,68 @@ func TestWriteSchemaMarkdown_NilSchema(t *testing.T) {
 	if out != "" {
 		t.Errorf("expected no output for nil schema, got: %q", out)
 	}
-}
\ No newline at end of file
+}
+func TestWriteSchemaProperties_SkipsWriteOnly(t *testing.T) {
+	c := &Converter{}
+	schemaType := openapi3.Types{"object"}
+	stringType := openapi3.Types{"string"}
+	schema := &openapi3.Schema{
+		Type: &schemaType,
+		Properties: map[string]*openapi3.SchemaRef{
+			"username": {Value: &openapi3.Schema{Type: &stringType, Description: "Login name"}},
+			"password": {Value: &openapi3.Schema{Type: &stringType, Description: "Secret", WriteOnly: true}},
+		},
+	}
+	var b strings.Builder
+	c.writeSchemaMarkdown(&b, schema, 0, "")
+	out := b.String()
+	if !strings.Contains(out, "**username**") {
+		t.Errorf("expected username to be documented, got: %q", out)
+	}
+	if strings.Contains(out, "password") {
+		t.Errorf("expected writeOnly password to be skipped, got: %q", out)
+	}
+
+	// writeOnly fields stay in the request input schema
+	result, err := c.applySchema(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password := result.Object.Properties["password"]; password == nil || !password.WriteOnly {
+		t.Errorf("expected writeOnly password in the request schema, got %+v", password)
+	}
+}
+
+func TestWriteSchemaMarkdown_PropertyNames(t *testing.T) {
+	c := &Converter{}
+	schema := openapi3.NewObjectSchema()
+	schema.AdditionalProperties = openapi3.AdditionalProperties{
+		Schema: &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+	}
+	schema.Extensions = map[string]interface{}{
+		"propertyNames": map[string]interface{}{"type": "string", "pattern": "^[a-z]+$"},
+	}
+
+	var b strings.Builder
+	c.writeSchemaMarkdown(&b, schema, 0, "")
+	out := b.String()
+	if !strings.Contains(out, "**Keys must satisfy**") {
+		t.Errorf("expected a keys section, got: %q", out)
+	}
+	if !strings.Contains(out, "**property name** (Type: string)") || !strings.Contains(out, "Pattern: '^[a-z]+$'") {
+		t.Errorf("expected the key schema to be documented, got: %q", out)
+	}
+}
+
+func TestWriteSchemaDetails_IntegerBounds(t *testing.T) {
+	minimum, maximum := 1.0, 1e7
+	schema := &openapi3.Schema{Type: &openapi3.Types{"integer"}, Min: &minimum, Max: &maximum}
+	var b strings.Builder
+	(&Converter{}).writeSchemaDetails(&b, schema, 0)
+	out := b.String()
+	for _, want := range []string{"Minimum: 1\n", "Maximum: 10000000\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got: %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// problemDetailsFields are the standard RFC 7807 members, in documentation order.
+var problemDetailsFields = []struct {
+	Name        string
+	Type        string
+	Description string
+}{
+	{"type", "string, uri", "A URI reference identifying the problem type (defaults to about:blank)"},
+	{"title", "string", "A short, human-readable summary of the problem type"},
+	{"status", "integer", "The HTTP status code generated by the origin server"},
+	{"detail", "string", "A human-readable explanation specific to this occurrence"},
+	{"instance", "string, uri", "A URI reference identifying this specific occurrence"},
+}
+
+// isProblemMediaType reports whether a content type is RFC 7807 problem details (JSON or XML).
+func isProblemMediaType(contentType string) bool {
+	mediaType := normalizeMediaType(contentType)
+	return mediaType == "application/problem+json" || mediaType == "application/problem+xml"
+}
+
+// isProblemSchema reports whether an object schema has the shape of RFC 7807 problem details:
+// a title and a status plus either type or detail.
+func isProblemSchema(schema *openapi3.Schema) bool {
+	if schema == nil || len(schema.Properties) == 0 {
+		return false
+	}
+	has := func(name string) bool { return schema.Properties[name] != nil }
+	return has("title") && has("status") && (has("type") || has("detail"))
+}
+
+// isProblemDetails detects problem details by media type or schema shape.
+func isProblemDetails(contentType string, schema *openapi3.Schema) bool {
+	return isProblemMediaType(contentType) || isProblemSchema(schema)
+}
+
+// writeProblemDetailsMarkdown documents the standard problem details fields.
+func writeProblemDetailsMarkdown(b *strings.Builder) {
+	b.WriteString("## Problem Details (RFC 7807)\n\n")
+	b.WriteString("This is an error response. The standard problem details fields are:\n\n")
+	for _, field := range problemDetailsFields {
+		b.WriteString(fmt.Sprintf("- **%s** (Type: %s): %s\n", field.Name, field.Type, field.Description))
+	}
+	b.WriteString("\n")
+}
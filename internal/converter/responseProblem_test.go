@@ -0,0 +1,112 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestIsProblemMediaType(t *testing.T) {
+	tests := map[string]bool{
+		"application/problem+json":                true,
+		"application/problem+json; charset=utf-8": true,
+		"Application/Problem+JSON":                true,
+		"application/problem+xml":                 true,
+		"application/json":                        false,
+		"text/plain":                              false,
+	}
+	for contentType, want := range tests {
+		if got := isProblemMediaType(contentType); got != want {
+			t.Errorf("isProblemMediaType(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
+
+func TestIsProblemSchema(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	integer := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}
+
+	problem := &openapi3.Schema{Properties: openapi3.Schemas{"type": str, "title": str, "status": integer, "detail": str}}
+	if !isProblemSchema(problem) {
+		t.Error("expected problem-shaped schema to be detected")
+	}
+	notProblem := &openapi3.Schema{Properties: openapi3.Schemas{"title": str, "body": str}}
+	if isProblemSchema(notProblem) {
+		t.Error("did not expect a plain schema to be detected as problem details")
+	}
+	if isProblemSchema(nil) {
+		t.Error("did not expect nil schema to be detected as problem details")
+	}
+}
+
+func TestCreateResponseTemplates_ProblemJSON(t *testing.T) {
+	spec := `openapi: 3.0.3
+info: {title: Problems, version: "1.0"}
+paths:
+  /items/{id}:
+    get:
+      operationId: getItem
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}
+        '404':
+          description: Not found
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                properties:
+                  type: {type: string}
+                  title: {type: string}
+                  status: {type: integer}
+                  detail: {type: string}
+        '500':
+          description: Server error
+          content:
+            application/problem+json: {}
+`
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverter(parser)
+	op := parser.GetPaths()["/items/{id}"].Get
+
+	templates, err := c.createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 3 {
+		t.Fatalf("expected 3 templates (schema-less problem+json included), got %d", len(templates))
+	}
+
+	if templates[0].ProblemDetails {
+		t.Error("200 response should not be problem details")
+	}
+	if strings.Contains(templates[0].PrependBody, "RFC 7807") {
+		t.Error("200 response should not document problem details")
+	}
+
+	for _, tmpl := range templates[1:] {
+		if !tmpl.ProblemDetails {
+			t.Errorf("%d response should be flagged as problem details", tmpl.StatusCode)
+		}
+		for _, field := range []string{"## Problem Details (RFC 7807)", "**type**", "**title**", "**status**", "**detail**", "**instance**"} {
+			if !strings.Contains(tmpl.PrependBody, field) {
+				t.Errorf("%d response missing %q in:\n%s", tmpl.StatusCode, field, tmpl.PrependBody)
+			}
+		}
+	}
+	if strings.Contains(templates[2].PrependBody, "## Response Structure") {
+		t.Error("schema-less problem response should not have a structure section")
+	}
+}
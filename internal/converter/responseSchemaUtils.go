@@ -63,3 +63,30 @@ func hasArrayType(schema *openapi3.Schema) bool {
 func hasObjectType(schema *openapi3.Schema) bool {
 	return schema != nil && schema.Type != nil && contains(*schema.Type, "object")
 }
+
+// Keyword helpers for untyped schemas, which are common inside if/then/else branches.
+// Type-specific keywords still apply to them, so they must not be dropped.
+func isUntyped(schema *openapi3.Schema) bool {
+	return schema != nil && (schema.Type == nil || len(*schema.Type) == 0)
+}
+
+func hasStringKeywords(schema *openapi3.Schema) bool {
+	return isUntyped(schema) &&
+		(schema.Pattern != "" || schema.MinLength > 0 || schema.MaxLength != nil)
+}
+
+func hasNumericKeywords(schema *openapi3.Schema) bool {
+	return isUntyped(schema) &&
+		(schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil)
+}
+
+func hasArrayKeywords(schema *openapi3.Schema) bool {
+	return isUntyped(schema) &&
+		(schema.Items != nil || schema.MinItems > 0 || schema.MaxItems != nil || schema.UniqueItems)
+}
+
+func hasObjectKeywords(schema *openapi3.Schema) bool {
+	return isUntyped(schema) &&
+		(len(schema.Properties) > 0 || len(schema.Required) > 0 || schema.MinProps > 0 || schema.MaxProps != nil ||
+			schema.Extensions["propertyNames"] != nil)
+}
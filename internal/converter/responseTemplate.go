@@ -1,7 +1,10 @@
 package converter
 
 import (
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -19,25 +22,134 @@ func (c *Converter) createResponseTemplates(
 
 	for _, code := range sortedCodes {
 		responseRef := operation.Responses.Map()[code]
-		if responseRef == nil || responseRef.Value == nil {
+		if responseRef == nil || !c.options.ResponseCodes.Includes(code) {
+			continue
+		}
+		restore := c.at("response " + code)
+		if responseRef.Value == nil {
+			c.warnf("%s: unresolved response $ref %q, no response template is generated", c.location, responseRef.Ref)
+			restore()
 			continue
 		}
 		statusCode, _ := strconv.Atoi(code)
-		contentTypes := sortedContentTypes(responseRef.Value.Content)
+		content := c.documentedResponseContent(responseRef.Value.Content)
 
-		for _, contentType := range contentTypes {
-			mediaType := responseRef.Value.Content[contentType]
-			if !hasSchema(mediaType) {
-				continue
+		for _, contentType := range sortedContentTypes(content) {
+			if mediaType := content[contentType]; mediaType != nil && mediaType.Schema != nil && mediaType.Schema.Value == nil {
+				restoreContentType := c.at(contentType)
+				c.warnMissingSchema(mediaType.Schema.Ref, "no response template is generated for it")
+				restoreContentType()
+			}
+		}
+		for _, group := range groupResponseContentTypes(content) {
+			contentType, schema := group.contentTypes[0], group.schema
+			restoreContentType := c.at(contentType)
+			markdown := c.buildResponseMarkdown(code, group.contentTypes, responseRef, schema)
+			restoreContentType()
+			response := ResponseTemplate{
+				PrependBody:           markdown,
+				StatusCode:            statusCode,
+				ContentType:           contentType,
+				AlternateContentTypes: group.contentTypes[1:],
+				Suffix:                responseSuffix(code, contentType),
+				ProblemDetails:        isProblemDetails(contentType, schema),
+				ErrorFields:           errorFields(code, schema),
+				Example:               responseExampleValue(responseRef.Value.Content, group.contentTypes, schema),
 			}
-			schema := mediaType.Schema.Value
-			markdown := c.buildResponseMarkdown(code, contentType, responseRef, schema)
-			templates = append(templates, ResponseTemplate{
-				PrependBody: markdown,
-				StatusCode:  statusCode,
-				ContentType: contentType,
-			})
+			if c.options.ResponseSchemas && statusCode >= 200 && statusCode < 300 && schema != nil && mediaTypeSyntax(contentType) == "json" {
+				response.Schema = c.responseBodySchema(schema)
+				if ref := content[contentType].Schema.Ref; strings.HasPrefix(ref, "#/") {
+					response.SchemaName = componentSchemaName(ref)
+				}
+			}
+			templates = append(templates, response)
+		}
+		restore()
+	}
+	return dedupeSuffixes(templates), nil
+}
+
+// responseBodySchema converts a response body schema for typed responses, or returns nil when it
+// cannot be converted. Its warnings and unsupported features are not reported: the response
+// template documents the schema as the spec writes it.
+func (c *Converter) responseBodySchema(schema *openapi3.Schema) *Schema {
+	warnings, unsupported := len(c.warnings), len(c.unsupported)
+	converted, err := c.applySchema(schema)
+	c.warnings, c.unsupported = c.warnings[:warnings], c.unsupported[:unsupported]
+	if err != nil {
+		return nil
+	}
+	return converted
+}
+
+// responseContentGroup is a set of content types of one response that share a structurally equal schema
+type responseContentGroup struct {
+	contentTypes []string
+	schema       *openapi3.Schema
+}
+
+// groupResponseContentTypes groups a response's content types by schema, in sorted content type order,
+// so flavors of the same format (e.g. application/json and application/hal+json) share one template.
+// Only content types with the same structured syntax (see mediaTypeSyntax) and structurally equal
+// schemas are grouped. Content types without a schema are skipped unless they are problem details,
+// and problem details content types only group with each other since their templates document the
+// problem fields.
+func groupResponseContentTypes(content openapi3.Content) []responseContentGroup {
+	var groups []responseContentGroup
+	for _, contentType := range sortedContentTypes(content) {
+		mediaType := content[contentType]
+		var schema *openapi3.Schema
+		if hasSchema(mediaType) {
+			schema = mediaType.Schema.Value
+		} else if !isProblemMediaType(contentType) {
+			continue
 		}
+
+		grouped := false
+		for i := range groups {
+			group := &groups[i]
+			if schema != nil && group.schema != nil &&
+				mediaTypeSyntax(group.contentTypes[0]) == mediaTypeSyntax(contentType) &&
+				isProblemDetails(group.contentTypes[0], group.schema) == isProblemDetails(contentType, schema) &&
+				reflect.DeepEqual(group.schema, schema) {
+				group.contentTypes = append(group.contentTypes, contentType)
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			groups = append(groups, responseContentGroup{contentTypes: []string{contentType}, schema: schema})
+		}
+	}
+	return groups
+}
+
+// mediaTypeSyntax returns "json" or "xml" for JSON and XML media types, including structured syntax
+// suffixes like +json, and the bare media type for anything else
+func mediaTypeSyntax(contentType string) string {
+	mediaType := normalizeMediaType(contentType)
+	for _, syntax := range []string{"json", "xml"} {
+		if strings.HasSuffix(mediaType, "/"+syntax) || strings.HasSuffix(mediaType, "+"+syntax) {
+			return syntax
+		}
+	}
+	return mediaType
+}
+
+// isErrorCode reports whether a response code documents an error: 4xx, 5xx, their ranges or default
+func isErrorCode(code string) bool {
+	return code == "default" || strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5")
+}
+
+// errorFields returns the sorted top-level property names of an error response schema
+func errorFields(code string, schema *openapi3.Schema) []string {
+	if !isErrorCode(code) || schema == nil || len(schema.Properties) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		fields = append(fields, name)
 	}
-	return assignSuffixes(templates), nil
+	sort.Strings(fields)
+	return fields
 }
@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -100,3 +102,235 @@ func TestCreateResponseTemplates(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateResponseTemplates_NoDocumentedSchema(t *testing.T) {
+	c := &Converter{}
+
+	noContent := openapi3.NewResponses()
+	noContent.Set("204", &openapi3.ResponseRef{
+		Value: &openapi3.Response{Description: func(s string) *string { return &s }("No Content")},
+	})
+
+	tests := []struct {
+		name string
+		op   *openapi3.Operation
+	}{
+		{name: "nil operation", op: nil},
+		{name: "nil responses", op: &openapi3.Operation{}},
+		{name: "empty responses", op: &openapi3.Operation{Responses: openapi3.NewResponses()}},
+		{name: "responses without content", op: &openapi3.Operation{Responses: noContent}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := c.createResponseTemplates(tt.op)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(templates) != 0 {
+				t.Errorf("expected no response templates, got %d", len(templates))
+			}
+		})
+	}
+}
+
+func TestCreateResponseTemplates_StableSuffixes(t *testing.T) {
+	c := &Converter{}
+	okResponse := func(contentTypes ...string) *openapi3.ResponseRef {
+		content := openapi3.Content{}
+		for _, ct := range contentTypes {
+			content[ct] = &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{}}}
+		}
+		return &openapi3.ResponseRef{Value: &openapi3.Response{Content: content}}
+	}
+
+	before := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	before.Responses.Set("200", okResponse("text/plain"))
+	before.Responses.Set("404", okResponse("application/json"))
+
+	// Adding a content type and a status code in the middle must not shift existing suffixes
+	after := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	after.Responses.Set("200", okResponse("application/json", "text/plain"))
+	after.Responses.Set("400", okResponse("application/json"))
+	after.Responses.Set("404", okResponse("application/json"))
+
+	suffixes := func(op *openapi3.Operation) map[string]string {
+		templates, err := c.createResponseTemplates(op)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := make(map[string]string)
+		for _, tmpl := range templates {
+			result[fmt.Sprintf("%d %s", tmpl.StatusCode, tmpl.ContentType)] = tmpl.Suffix
+		}
+		return result
+	}
+
+	beforeSuffixes, afterSuffixes := suffixes(before), suffixes(after)
+	for key, suffix := range beforeSuffixes {
+		if afterSuffixes[key] != suffix {
+			t.Errorf("suffix for %s changed from %q to %q", key, suffix, afterSuffixes[key])
+		}
+	}
+	if beforeSuffixes["200 text/plain"] != "200_text_plain" {
+		t.Errorf("suffix for 200 text/plain = %q, want %q", beforeSuffixes["200 text/plain"], "200_text_plain")
+	}
+}
+
+func TestCreateResponseTemplates_ErrorFields(t *testing.T) {
+	c := &Converter{}
+	objectResponse := func(properties ...string) *openapi3.ResponseRef {
+		schema := openapi3.NewObjectSchema()
+		for _, name := range properties {
+			schema.WithProperty(name, openapi3.NewStringSchema())
+		}
+		return &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.NewContentWithJSONSchema(schema)}}
+	}
+
+	op := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	op.Responses.Set("200", objectResponse("id"))
+	op.Responses.Set("404", objectResponse("message", "code"))
+	op.Responses.Set("5XX", objectResponse("error"))
+	op.Responses.Set("default", objectResponse("detail"))
+
+	templates, err := c.createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]string{
+		"200_application_json":     nil,
+		"404_application_json":     {"code", "message"},
+		"5xx_application_json":     {"error"},
+		"default_application_json": {"detail"},
+	}
+	if len(templates) != len(want) {
+		t.Fatalf("expected %d templates, got %d", len(want), len(templates))
+	}
+	for _, tmpl := range templates {
+		expected, ok := want[tmpl.Suffix]
+		if !ok {
+			t.Errorf("unexpected template suffix %q", tmpl.Suffix)
+			continue
+		}
+		if !reflect.DeepEqual(tmpl.ErrorFields, expected) {
+			t.Errorf("%s: ErrorFields = %v, want %v", tmpl.Suffix, tmpl.ErrorFields, expected)
+		}
+	}
+}
+
+func TestCreateResponseTemplates_ResponseSchemas(t *testing.T) {
+	pet := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+	op := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	op.Responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.Content{
+		"application/json": &openapi3.MediaType{Schema: &openapi3.SchemaRef{Ref: "#/components/schemas/Pet", Value: pet}},
+		"text/plain":       openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema()),
+	}}})
+	op.Responses.Set("201", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.NewContentWithJSONSchema(pet)}})
+	op.Responses.Set("404", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.NewContentWithJSONSchema(pet)}})
+
+	templates, err := (&Converter{}).createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tmpl := range templates {
+		if tmpl.Schema != nil {
+			t.Errorf("%s: expected no schema without ResponseSchemas", tmpl.Suffix)
+		}
+	}
+
+	c := &Converter{options: ConvertOptions{ResponseSchemas: true}}
+	templates, err = c.createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kept := map[string]string{}
+	for _, tmpl := range templates {
+		if tmpl.Schema == nil {
+			continue
+		}
+		if tmpl.Schema.Object == nil || tmpl.Schema.Object.Properties["name"] == nil {
+			t.Errorf("%s: schema = %+v, want the converted pet schema", tmpl.Suffix, tmpl.Schema)
+		}
+		kept[tmpl.Suffix] = tmpl.SchemaName
+	}
+	want := map[string]string{"200_application_json": "Pet", "201_application_json": ""}
+	if !reflect.DeepEqual(kept, want) {
+		t.Errorf("schemas kept for %v, want JSON success responses only: %v", kept, want)
+	}
+}
+
+func TestCreateResponseTemplates_CollapsesEqualSchemas(t *testing.T) {
+	c := &Converter{}
+	pet := func() *openapi3.Schema {
+		return openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+	}
+	content := func(schemas map[string]*openapi3.Schema) *openapi3.ResponseRef {
+		c := openapi3.Content{}
+		for contentType, schema := range schemas {
+			c[contentType] = &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: schema}}
+		}
+		return &openapi3.ResponseRef{Value: &openapi3.Response{Content: c}}
+	}
+
+	op := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	op.Responses.Set("200", content(map[string]*openapi3.Schema{
+		"application/json":     pet(),
+		"application/hal+json": pet(),
+		"application/xml":      pet(),
+		"text/plain":           pet(),
+	}))
+	op.Responses.Set("201", content(map[string]*openapi3.Schema{
+		"application/json":     pet(),
+		"application/hal+json": pet().WithProperty("_links", openapi3.NewObjectSchema()),
+	}))
+	op.Responses.Set("400", content(map[string]*openapi3.Schema{
+		"application/json":         pet(),
+		"application/problem+json": pet(),
+	}))
+
+	templates, err := c.createResponseTemplates(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, tmpl := range templates {
+		got[tmpl.Suffix] = append([]string{tmpl.ContentType}, tmpl.AlternateContentTypes...)
+	}
+	want := map[string][]string{
+		"200_application_hal_json":     {"application/hal+json", "application/json"},
+		"200_application_xml":          {"application/xml"},
+		"200_text_plain":               {"text/plain"},
+		"201_application_hal_json":     {"application/hal+json"},
+		"201_application_json":         {"application/json"},
+		"400_application_json":         {"application/json"},
+		"400_application_problem_json": {"application/problem+json"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("templates by suffix = %v, want %v", got, want)
+	}
+
+	for _, tmpl := range templates {
+		if tmpl.Suffix == "200_application_hal_json" && !strings.Contains(tmpl.PrependBody, "**Content-Type:** application/hal+json, application/json\n") {
+			t.Errorf("collapsed template does not list its content types:\n%s", tmpl.PrependBody)
+		}
+	}
+}
+
+func Test_mediaTypeSyntax(t *testing.T) {
+	tests := map[string]string{
+		"application/json":                "json",
+		"application/hal+json":            "json",
+		"application/json; charset=utf-8": "json",
+		"text/xml":                        "xml",
+		"application/atom+xml":            "xml",
+		"text/plain":                      "text/plain",
+		"Application/Octet-Stream":        "application/octet-stream",
+	}
+	for contentType, want := range tests {
+		if got := mediaTypeSyntax(contentType); got != want {
+			t.Errorf("mediaTypeSyntax(%q) = %q, want %q", contentType, got, want)
+		}
+	}
+}
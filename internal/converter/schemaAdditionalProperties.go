@@ -0,0 +1,58 @@
+package converter
+
+import "fmt"
+
+// ParseAdditionalPropertiesMode parses the permissive and strict mode names
+func ParseAdditionalPropertiesMode(name string) (AdditionalPropertiesMode, error) {
+	switch name {
+	case "permissive", "":
+		return AdditionalPropertiesPermissive, nil
+	case "strict":
+		return AdditionalPropertiesStrict, nil
+	}
+	return AdditionalPropertiesPermissive, fmt.Errorf("unknown additional properties mode %q: use permissive or strict", name)
+}
+
+// closeObjects sets additionalProperties to false on the objects of a schema tree that declare
+// properties but leave additionalProperties unset. Objects whose valid properties are not all
+// declared in one place stay open: objects combined with allOf, oneOf, anyOf, if/then/else or
+// dependentSchemas, and the parts of such combinations that are validated against the same
+// instance as the object's own properties. composed reports whether s is such a part.
+// Schemas under not are left alone, since closing them would loosen validation.
+func closeObjects(s *Schema, composed bool) {
+	if s == nil {
+		return
+	}
+	o := s.Object
+	declares := o != nil && len(o.Properties) > 0
+	combined := len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 ||
+		s.If != nil || s.Then != nil || s.Else != nil || o != nil && len(o.DependentSchemas) > 0
+	if declares && !composed && !combined && o.AdditionalProperties == nil && !o.DisallowAdditionalProperties {
+		o.DisallowAdditionalProperties = true
+	}
+
+	// allOf parts and if/then/else branches always apply alongside their siblings; oneOf and anyOf
+	// options do when the object declares properties of its own
+	for _, part := range s.AllOf {
+		closeObjects(part, true)
+	}
+	for _, branch := range []*Schema{s.If, s.Then, s.Else} {
+		closeObjects(branch, true)
+	}
+	for _, option := range append(append([]*Schema{}, s.OneOf...), s.AnyOf...) {
+		closeObjects(option, composed || declares)
+	}
+	if s.Array != nil {
+		closeObjects(s.Array.Items, false)
+		closeObjects(s.Array.Contains, false)
+	}
+	if o != nil {
+		for _, prop := range o.Properties {
+			closeObjects(prop, false)
+		}
+		for _, dependent := range o.DependentSchemas {
+			closeObjects(dependent, true)
+		}
+		closeObjects(o.AdditionalProperties, false)
+	}
+}
@@ -0,0 +1,120 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const additionalPropertiesSpec = `openapi: 3.0.3
+info: {title: Objects, version: "1.0"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                owner:
+                  type: object
+                  properties:
+                    id: {type: string}
+                labels:
+                  type: object
+                  additionalProperties: {type: string}
+                extra:
+                  type: object
+                  additionalProperties: true
+                  properties:
+                    a: {type: string}
+                freeform: {type: object}
+                tags:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      key: {type: string}
+                combined:
+                  allOf:
+                    - type: object
+                      properties:
+                        a: {type: string}
+                    - type: object
+                      properties:
+                        b: {type: string}
+                choice:
+                  oneOf:
+                    - type: object
+                      properties:
+                        cat: {type: string}
+                    - type: object
+                      properties:
+                        dog: {type: string}
+      responses:
+        '200': {description: OK}
+`
+
+func TestInputSchema_AdditionalPropertiesStrict(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(additionalPropertiesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	convert := func(mode AdditionalPropertiesMode) map[string]interface{} {
+		config, err := NewConverterWithOptions(parser, ConvertOptions{AdditionalProperties: mode}).Convert()
+		if err != nil {
+			t.Fatalf("Convert failed: %v", err)
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+			t.Fatalf("invalid input schema: %v", err)
+		}
+		props := schema["properties"].(map[string]interface{})
+		return props["body"].(map[string]interface{})
+	}
+	prop := func(schema map[string]interface{}, name string) map[string]interface{} {
+		return schema["properties"].(map[string]interface{})[name].(map[string]interface{})
+	}
+
+	body := convert(AdditionalPropertiesStrict)
+	closed := map[string]map[string]interface{}{
+		"body":                body,
+		"owner":               prop(body, "owner"),
+		"tags items":          prop(body, "tags")["items"].(map[string]interface{}),
+		"choice first option": prop(body, "choice")["oneOf"].([]interface{})[0].(map[string]interface{}),
+	}
+	for name, schema := range closed {
+		if schema["additionalProperties"] != false {
+			t.Errorf("%s: additionalProperties = %v, want false", name, schema["additionalProperties"])
+		}
+	}
+
+	open := map[string]map[string]interface{}{
+		"freeform":       prop(body, "freeform"),
+		"allOf part":     prop(body, "combined")["allOf"].([]interface{})[0].(map[string]interface{}),
+		"explicit true":  prop(body, "extra"),
+		"labels (typed)": prop(body, "labels"),
+	}
+	for name, schema := range open {
+		if schema["additionalProperties"] == false {
+			t.Errorf("%s: additionalProperties = false, want it left open", name)
+		}
+	}
+
+	if _, ok := convert(AdditionalPropertiesPermissive)["additionalProperties"]; ok {
+		t.Errorf("expected no additionalProperties by default")
+	}
+}
+
+func TestParseAdditionalPropertiesMode(t *testing.T) {
+	for name, want := range map[string]AdditionalPropertiesMode{"": AdditionalPropertiesPermissive, "permissive": AdditionalPropertiesPermissive, "strict": AdditionalPropertiesStrict} {
+		if got, err := ParseAdditionalPropertiesMode(name); err != nil || got != want {
+			t.Errorf("ParseAdditionalPropertiesMode(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseAdditionalPropertiesMode("closed"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,220 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mergeAllOfObjects flattens result.AllOf into result when every branch is a plain object schema.
+// Properties, required fields and dependencies are unioned, so fields required by any branch stay
+// required; when two branches define the same property differently the first definition wins and
+// a warning is recorded, as it is for required fields no branch defines. Branches carrying
+// other constraints (combinators, if/then/else, enum, ...) keep the allOf, since flattening would drop them.
+// So does a branch restricting additionalProperties while another branch adds properties it does not
+// list: the allOf rejects those properties, the merged object would accept them.
+func (c *Converter) mergeAllOfObjects(result *Schema) {
+	if len(result.AllOf) == 0 {
+		return
+	}
+	for _, branch := range result.AllOf {
+		if !isObjectSchema(branch) || !onlyObjectConstraints(branch) {
+			return
+		}
+	}
+	if len(result.Types) > 0 && !contains(result.Types, "object") {
+		return
+	}
+
+	objects := make([]*ObjectValidation, 0, len(result.AllOf)+1)
+	if result.Object != nil {
+		objects = append(objects, result.Object)
+	}
+	for _, branch := range result.AllOf {
+		if branch.Object != nil {
+			objects = append(objects, branch.Object)
+		}
+	}
+	if reason := additionalPropertiesConflict(objects); reason != "" {
+		c.warnf("allOf merge%s: keeping the allOf because %s", titleSuffix(result.Title), reason)
+		return
+	}
+
+	merged := &ObjectValidation{}
+	if result.Object != nil {
+		*merged = *result.Object
+		merged.Properties = copyProperties(result.Object.Properties)
+		merged.Required = append([]string(nil), result.Object.Required...)
+	}
+
+	for _, branch := range result.AllOf {
+		if result.Description == "" {
+			result.Description = branch.Description
+		}
+		if branch.Object == nil {
+			continue
+		}
+		c.mergeObjectValidation(merged, branch.Object, result.Title)
+	}
+
+	// A branch such as {required: [name]} can require a property another branch defines; one no
+	// branch defines is likely a typo and cannot be satisfied when additional properties are denied
+	for _, name := range merged.Required {
+		if _, ok := merged.Properties[name]; !ok {
+			c.warnf("allOf merge%s: required property %q is not defined by any branch", titleSuffix(result.Title), name)
+		}
+	}
+
+	if len(merged.Properties) == 0 {
+		merged.Properties = nil
+	}
+	if len(result.Types) == 0 {
+		result.Types = []string{"object"}
+	}
+	result.Object = merged
+	result.AllOf = nil
+}
+
+// mergeObjectValidation folds src into dst, keeping dst's definition of conflicting properties
+func (c *Converter) mergeObjectValidation(dst, src *ObjectValidation, schemaTitle string) {
+	names := make([]string, 0, len(src.Properties))
+	for name := range src.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := src.Properties[name]
+		if existing, ok := dst.Properties[name]; ok {
+			if !reflect.DeepEqual(existing, prop) {
+				c.warnf("allOf merge%s: property %q is defined differently in several branches; keeping the first definition",
+					titleSuffix(schemaTitle), name)
+			}
+			continue
+		}
+		if dst.Properties == nil {
+			dst.Properties = make(map[string]*Schema)
+		}
+		dst.Properties[name] = prop
+	}
+
+	for _, name := range src.Required {
+		if !contains(dst.Required, name) {
+			dst.Required = append(dst.Required, name)
+		}
+	}
+
+	if src.DisallowAdditionalProperties {
+		dst.DisallowAdditionalProperties = true
+	}
+	if dst.AdditionalProperties == nil {
+		dst.AdditionalProperties = src.AdditionalProperties
+	}
+	if src.MinProperties > dst.MinProperties {
+		dst.MinProperties = src.MinProperties
+	}
+	if src.MaxProperties != nil && (dst.MaxProperties == nil || *src.MaxProperties < *dst.MaxProperties) {
+		dst.MaxProperties = src.MaxProperties
+	}
+
+	// Keys must satisfy every branch's propertyNames
+	switch {
+	case dst.PropertyNames == nil:
+		dst.PropertyNames = src.PropertyNames
+	case src.PropertyNames != nil && !reflect.DeepEqual(dst.PropertyNames, src.PropertyNames):
+		dst.PropertyNames = &Schema{AllOf: []*Schema{dst.PropertyNames, src.PropertyNames}}
+	}
+
+	for name, required := range src.DependentRequired {
+		if _, ok := dst.DependentRequired[name]; ok {
+			continue
+		}
+		if dst.DependentRequired == nil {
+			dst.DependentRequired = make(map[string][]string)
+		}
+		dst.DependentRequired[name] = required
+	}
+	for name, dependent := range src.DependentSchemas {
+		if _, ok := dst.DependentSchemas[name]; ok {
+			continue
+		}
+		if dst.DependentSchemas == nil {
+			dst.DependentSchemas = make(map[string]*Schema)
+		}
+		dst.DependentSchemas[name] = dependent
+	}
+	dst.DependentKeywords = dst.DependentKeywords || src.DependentKeywords
+}
+
+// additionalPropertiesConflict explains why merging objects would loosen their additionalProperties,
+// or returns "" when it would not: every property must be listed by each object restricting
+// additional properties, and objects giving an additionalProperties schema must agree on it
+func additionalPropertiesConflict(objects []*ObjectValidation) string {
+	var additional *Schema
+	for _, restricting := range objects {
+		if restricting.AdditionalProperties != nil {
+			if additional != nil && !reflect.DeepEqual(additional, restricting.AdditionalProperties) {
+				return "branches give different additionalProperties schemas"
+			}
+			additional = restricting.AdditionalProperties
+		}
+		if !restricting.DisallowAdditionalProperties && restricting.AdditionalProperties == nil {
+			continue
+		}
+		for _, other := range objects {
+			names := make([]string, 0, len(other.Properties))
+			for name := range other.Properties {
+				if _, ok := restricting.Properties[name]; !ok {
+					names = append(names, strconv.Quote(name))
+				}
+			}
+			if len(names) > 0 {
+				sort.Strings(names)
+				return fmt.Sprintf("a branch restricting additionalProperties does not list %s", strings.Join(names, ", "))
+			}
+		}
+	}
+	return ""
+}
+
+// onlyObjectConstraints reports whether an object branch constrains nothing beyond its object
+// validation, so merging its ObjectValidation loses no constraint
+func onlyObjectConstraints(s *Schema) bool {
+	return len(s.OneOf) == 0 && len(s.AnyOf) == 0 && len(s.AllOf) == 0 &&
+		s.Not == nil && s.If == nil && s.Discriminator == nil &&
+		len(s.Enum) == 0 && s.Const == nil &&
+		s.String == nil && s.Number == nil && s.Array == nil
+}
+
+// isObjectSchema reports whether a converted schema describes an object
+func isObjectSchema(s *Schema) bool {
+	if s == nil {
+		return false
+	}
+	if contains(s.Types, "object") {
+		return true
+	}
+	return len(s.Types) == 0 && s.Object != nil
+}
+
+// copyProperties returns a shallow copy of a property map
+func copyProperties(properties map[string]*Schema) map[string]*Schema {
+	if properties == nil {
+		return nil
+	}
+	copied := make(map[string]*Schema, len(properties))
+	for name, prop := range properties {
+		copied[name] = prop
+	}
+	return copied
+}
+
+// titleSuffix formats an optional schema title for warning messages
+func titleSuffix(title string) string {
+	if strings.TrimSpace(title) == "" {
+		return ""
+	}
+	return " in " + title
+}
@@ -0,0 +1,246 @@
+package converter
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const allOfSpec = `openapi: 3.0.3
+info: {title: AllOf, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Base:
+      type: object
+      required: [id]
+      properties:
+        id: {type: string}
+        createdAt: {type: string, format: date-time}
+    Pet:
+      description: A pet
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [name]
+          properties:
+            name: {type: string}
+    NewPet:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name: {type: string}
+        - required: [createdAt, name, nickname]
+    Conflicting:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            id: {type: integer}
+    Mixed:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: string
+    Constrained:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name: {type: string}
+          oneOf:
+            - required: [id]
+            - required: [name]
+    Dependent:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            card: {type: string}
+            billing: {type: string}
+          dependentRequired:
+            card: [billing]
+    Closed:
+      allOf:
+        - type: object
+          properties:
+            a: {type: string}
+          additionalProperties: false
+        - type: object
+          properties:
+            b: {type: string}
+    ClosedCovering:
+      allOf:
+        - type: object
+          properties:
+            a: {type: string}
+            b: {type: string}
+          additionalProperties: false
+        - type: object
+          required: [b]
+          properties:
+            b: {type: string}
+    TypedAdditional:
+      allOf:
+        - type: object
+          properties:
+            a: {type: string}
+          additionalProperties: {type: string}
+        - type: object
+          properties:
+            b: {type: integer}
+`
+
+func applyAllOfSchema(t *testing.T, options ConvertOptions, name string) (*Converter, *Schema) {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(allOfSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverterWithOptions(parser, options)
+	result, err := c.applySchema(parser.GetDocument().Components.Schemas[name].Value)
+	if err != nil {
+		t.Fatalf("applySchema() error = %v", err)
+	}
+	return c, result
+}
+
+func TestMergeAllOf_Disabled(t *testing.T) {
+	_, result := applyAllOfSchema(t, ConvertOptions{}, "Pet")
+	if len(result.AllOf) != 2 {
+		t.Errorf("expected raw allOf to be kept by default, got %d branches", len(result.AllOf))
+	}
+	if result.Object != nil {
+		t.Errorf("expected no merged object, got %+v", result.Object)
+	}
+}
+
+func TestMergeAllOf_BaseAndExtension(t *testing.T) {
+	c, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "Pet")
+
+	if len(result.AllOf) != 0 {
+		t.Fatalf("expected allOf to be merged away, got %d branches", len(result.AllOf))
+	}
+	if !reflect.DeepEqual(result.Types, []string{"object"}) {
+		t.Errorf("Types = %v, want [object]", result.Types)
+	}
+	if result.Description != "A pet" {
+		t.Errorf("Description = %q, want %q", result.Description, "A pet")
+	}
+
+	var names []string
+	for name := range result.Object.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"createdAt", "id", "name"}) {
+		t.Errorf("properties = %v, want [createdAt id name]", names)
+	}
+	if !reflect.DeepEqual(result.Object.Required, []string{"id", "name"}) {
+		t.Errorf("required = %v, want [id name]", result.Object.Required)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Errorf("expected no warnings, got %v", c.Warnings())
+	}
+
+	draft7, err := schemaToDraft7Map(result)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	if _, hasAllOf := draft7["allOf"]; hasAllOf {
+		t.Errorf("expected no allOf in draft7 output, got %v", draft7)
+	}
+}
+
+func TestMergeAllOf_RequiredOnlyBranch(t *testing.T) {
+	c, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "NewPet")
+
+	if len(result.AllOf) != 0 {
+		t.Fatalf("expected allOf to be merged away, got %d branches", len(result.AllOf))
+	}
+	want := []string{"id", "createdAt", "name", "nickname"}
+	if !reflect.DeepEqual(result.Object.Required, want) {
+		t.Errorf("required = %v, want %v", result.Object.Required, want)
+	}
+	if len(c.Warnings()) != 1 || !strings.Contains(c.Warnings()[0], `required property "nickname" is not defined by any branch`) {
+		t.Errorf("expected a warning for the undefined required property, got %v", c.Warnings())
+	}
+
+	draft7, err := schemaToDraft7Map(result)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	if got := draft7["required"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("draft7 required = %v, want %v", got, want)
+	}
+}
+
+func TestMergeAllOf_ConflictWarns(t *testing.T) {
+	c, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "Conflicting")
+
+	id := result.Object.Properties["id"]
+	if id == nil || !reflect.DeepEqual(id.Types, []string{"string"}) {
+		t.Errorf("expected the first definition of id to win, got %+v", id)
+	}
+	if len(c.Warnings()) != 1 || !strings.Contains(c.Warnings()[0], `property "id"`) {
+		t.Errorf("expected a conflict warning for id, got %v", c.Warnings())
+	}
+}
+
+func TestMergeAllOf_NonObjectBranchKeepsAllOf(t *testing.T) {
+	_, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "Mixed")
+	if len(result.AllOf) != 2 {
+		t.Errorf("expected allOf with a non-object branch to be kept, got %d branches", len(result.AllOf))
+	}
+}
+
+func TestMergeAllOf_ConstrainedBranchKeepsAllOf(t *testing.T) {
+	_, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "Constrained")
+	if len(result.AllOf) != 2 {
+		t.Fatalf("expected allOf with a oneOf branch to be kept, got %d branches", len(result.AllOf))
+	}
+	if len(result.AllOf[1].OneOf) != 2 {
+		t.Errorf("expected the branch's oneOf to survive, got %+v", result.AllOf[1])
+	}
+}
+
+func TestMergeAllOf_MergesDependencies(t *testing.T) {
+	_, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "Dependent")
+	if len(result.AllOf) != 0 {
+		t.Fatalf("expected allOf to be merged away, got %d branches", len(result.AllOf))
+	}
+	if !reflect.DeepEqual(result.Object.DependentRequired, map[string][]string{"card": {"billing"}}) {
+		t.Errorf("DependentRequired = %v, want map[card:[billing]]", result.Object.DependentRequired)
+	}
+}
+
+func TestMergeAllOf_RestrictedAdditionalPropertiesKeepsAllOf(t *testing.T) {
+	for _, name := range []string{"Closed", "TypedAdditional"} {
+		t.Run(name, func(t *testing.T) {
+			c, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, name)
+			if len(result.AllOf) != 2 {
+				t.Fatalf("expected allOf to be kept, got %d branches", len(result.AllOf))
+			}
+			if len(c.Warnings()) != 1 || !strings.Contains(c.Warnings()[0], `does not list "b"`) {
+				t.Errorf("expected a warning naming b, got %v", c.Warnings())
+			}
+		})
+	}
+}
+
+func TestMergeAllOf_ClosedBranchListingAllProperties(t *testing.T) {
+	c, result := applyAllOfSchema(t, ConvertOptions{MergeAllOf: true}, "ClosedCovering")
+	if len(result.AllOf) != 0 {
+		t.Fatalf("expected allOf to be merged away, got %d branches", len(result.AllOf))
+	}
+	if !result.Object.DisallowAdditionalProperties {
+		t.Error("expected the merged object to deny additional properties")
+	}
+	if !reflect.DeepEqual(result.Object.Required, []string{"b"}) {
+		t.Errorf("Required = %v, want [b]", result.Object.Required)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Errorf("unexpected warnings: %v", c.Warnings())
+	}
+}
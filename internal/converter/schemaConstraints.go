@@ -0,0 +1,164 @@
+package converter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// describeArgConstraints appends constraint hints to the descriptions of an argument's schema and
+// of the properties nested in it. The argument's own hints go where buildPropertySchema takes the
+// property description from: the schema's description when it has one, otherwise the argument's.
+func describeArgConstraints(arg Arg) Arg {
+	schemas := []*Schema{arg.Schema}
+	if arg.Source == "body" {
+		schemas = make([]*Schema, 0, len(arg.ContentTypes))
+		for _, schema := range arg.ContentTypes {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	for _, s := range schemas {
+		if s == nil {
+			continue
+		}
+		describePropertyConstraints(s)
+		// Several body content types become oneOf branches that each keep their own description
+		if s.Description != "" || len(schemas) > 1 {
+			s.Description = withConstraints(s.Description, s)
+		} else {
+			arg.Description = withConstraints(arg.Description, s)
+		}
+	}
+	return arg
+}
+
+// describePropertyConstraints appends constraint hints to the description of every object
+// property in a schema tree
+func describePropertyConstraints(s *Schema) {
+	if s == nil {
+		return
+	}
+
+	children := make([]*Schema, 0, len(s.OneOf)+len(s.AnyOf)+len(s.AllOf)+4)
+	children = append(children, s.OneOf...)
+	children = append(children, s.AnyOf...)
+	children = append(children, s.AllOf...)
+	children = append(children, s.Not, s.If, s.Then, s.Else)
+	if s.Array != nil {
+		children = append(children, s.Array.Items, s.Array.Contains)
+	}
+	if s.Object != nil {
+		for _, prop := range s.Object.Properties {
+			if prop != nil {
+				prop.Description = withConstraints(prop.Description, prop)
+			}
+			children = append(children, prop)
+		}
+		for _, dependent := range s.Object.DependentSchemas {
+			children = append(children, dependent)
+		}
+		children = append(children, s.Object.AdditionalProperties, s.Object.PropertyNames)
+	}
+
+	for _, child := range children {
+		describePropertyConstraints(child)
+	}
+}
+
+// withConstraints appends the schema's constraint hints to description in parentheses, e.g.
+// "Page size (1–100, default 20)". A schema without constraints leaves description unchanged.
+func withConstraints(description string, s *Schema) string {
+	hints := constraintHints(s)
+	if len(hints) == 0 {
+		return description
+	}
+	if description == "" {
+		return strings.Join(hints, ", ")
+	}
+	return fmt.Sprintf("%s (%s)", description, strings.Join(hints, ", "))
+}
+
+// constraintHints describes a schema's validation keywords, enum and default in words
+func constraintHints(s *Schema) []string {
+	var hints []string
+	if s.Number != nil {
+		integer := slices.Contains(s.Types, "integer")
+		hints = append(hints, numberRangeHint(s.Number, integer)...)
+		if s.Number.MultipleOf != nil {
+			hints = append(hints, fmt.Sprintf("multiple of %v", numberValue(*s.Number.MultipleOf, integer)))
+		}
+	}
+	if s.String != nil {
+		if hint := countRangeHint(s.String.MinLength, s.String.MaxLength, "character"); hint != "" {
+			hints = append(hints, hint)
+		}
+		if s.String.Pattern != "" {
+			hints = append(hints, "pattern "+s.String.Pattern)
+		}
+	}
+	if s.Array != nil {
+		if hint := countRangeHint(s.Array.MinItems, s.Array.MaxItems, "item"); hint != "" {
+			hints = append(hints, hint)
+		}
+		if s.Array.UniqueItems {
+			hints = append(hints, "unique items")
+		}
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, value := range s.Enum {
+			values[i] = formatEnumValue(value)
+		}
+		hints = append(hints, "one of "+strings.Join(values, ", "))
+	}
+	if s.Default != nil {
+		hints = append(hints, "default "+formatEnumValue(s.Default))
+	}
+	return hints
+}
+
+// numberRangeHint describes numeric bounds as "1–100" when both are inclusive, otherwise as
+// separate comparisons such as "> 0"
+func numberRangeHint(n *NumberValidation, integer bool) []string {
+	if n.Minimum != nil && n.Maximum != nil && !n.ExclusiveMinimum && !n.ExclusiveMaximum {
+		return []string{fmt.Sprintf("%v–%v", numberValue(*n.Minimum, integer), numberValue(*n.Maximum, integer))}
+	}
+	var hints []string
+	if n.Minimum != nil {
+		op := ">="
+		if n.ExclusiveMinimum {
+			op = ">"
+		}
+		hints = append(hints, fmt.Sprintf("%s %v", op, numberValue(*n.Minimum, integer)))
+	}
+	if n.Maximum != nil {
+		op := "<="
+		if n.ExclusiveMaximum {
+			op = "<"
+		}
+		hints = append(hints, fmt.Sprintf("%s %v", op, numberValue(*n.Maximum, integer)))
+	}
+	return hints
+}
+
+// countRangeHint describes length or item count bounds, e.g. "1–64 characters" or "at most 10 items"
+func countRangeHint(min uint64, max *uint64, unit string) string {
+	switch {
+	case max != nil && min > 0:
+		return fmt.Sprintf("%d–%d %ss", min, *max, unit)
+	case max != nil:
+		return fmt.Sprintf("at most %d %s", *max, plural(*max, unit))
+	case min > 0:
+		return fmt.Sprintf("at least %d %s", min, plural(min, unit))
+	}
+	return ""
+}
+
+// plural returns unit with an "s" unless count is one
+func plural(count uint64, unit string) string {
+	if count == 1 {
+		return unit
+	}
+	return unit + "s"
+}
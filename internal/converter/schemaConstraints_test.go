@@ -0,0 +1,119 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const schemaConstraintsSpec = `openapi: 3.1.0
+info: {title: Constraints, version: "1.0"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - name: limit
+          in: query
+          description: Page size
+          schema: {type: integer, minimum: 1, maximum: 100, default: 20}
+        - name: sort
+          in: query
+          schema: {type: string, enum: [name, age]}
+        - name: X-Trace
+          in: header
+          schema: {type: string, description: Trace identifier, pattern: "^[a-f0-9]+$"}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string, description: Pet name, minLength: 1, maxLength: 64}
+                weight: {type: number, minimum: 0, exclusiveMinimum: true}
+                tags:
+                  type: array
+                  maxItems: 5
+                  uniqueItems: true
+                  items: {type: string}
+                owner:
+                  type: object
+                  properties:
+                    age: {type: integer, description: Owner age, minimum: 18}
+      responses:
+        '200':
+          description: OK
+`
+
+func TestInputSchema_DescribeConstraints(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(schemaConstraintsSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	convert := func(options ConvertOptions) map[string]interface{} {
+		config, err := NewConverterWithOptions(parser, options).Convert()
+		if err != nil {
+			t.Fatalf("Convert failed: %v", err)
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+			t.Fatalf("invalid input schema: %v", err)
+		}
+		return schema
+	}
+	description := func(schema map[string]interface{}, path ...string) interface{} {
+		for _, name := range path {
+			props, _ := schema["properties"].(map[string]interface{})
+			schema, _ = props[name].(map[string]interface{})
+		}
+		return schema["description"]
+	}
+
+	schema := convert(ConvertOptions{DescribeConstraints: true})
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{[]string{"limit"}, "Page size (1–100, default 20)"},
+		{[]string{"sort"}, "one of 'name', 'age'"},
+		{[]string{"X-Trace"}, "Trace identifier (pattern ^[a-f0-9]+$)"},
+		{[]string{"body", "name"}, "Pet name (1–64 characters)"},
+		{[]string{"body", "weight"}, "> 0"},
+		{[]string{"body", "tags"}, "at most 5 items, unique items"},
+		{[]string{"body", "owner", "age"}, "Owner age (>= 18)"},
+	}
+	for _, tt := range tests {
+		if got := description(schema, tt.path...); got != tt.want {
+			t.Errorf("description of %v = %v, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	// Off by default, and descriptions that are omitted get no hints either
+	if got := description(convert(ConvertOptions{}), "limit"); got != "Page size" {
+		t.Errorf("description of limit without DescribeConstraints = %v, want %q", got, "Page size")
+	}
+	if got := description(convert(ConvertOptions{DescribeConstraints: true, OmitSchemaDescriptions: true}), "limit"); got != nil {
+		t.Errorf("description of limit with OmitSchemaDescriptions = %v, want none", got)
+	}
+}
+
+func Test_constraintHints(t *testing.T) {
+	min, max := 0.5, 2.0
+	maxLength := uint64(8)
+	tests := []struct {
+		name   string
+		schema *Schema
+		want   string
+	}{
+		{"none", &Schema{Types: []string{"string"}}, ""},
+		{"exclusive bounds", &Schema{Types: []string{"number"}, Number: &NumberValidation{Minimum: &min, Maximum: &max, ExclusiveMaximum: true}}, ">= 0.5, < 2"},
+		{"multiple of", &Schema{Types: []string{"integer"}, Number: &NumberValidation{MultipleOf: &max}}, "multiple of 2"},
+		{"max length", &Schema{String: &StringValidation{MaxLength: &maxLength}}, "at most 8 characters"},
+		{"min items", &Schema{Array: &ArrayValidation{MinItems: 1}}, "at least 1 item"},
+		{"default object", &Schema{Default: map[string]interface{}{"a": 1}}, `default {"a":1}`},
+	}
+	for _, tt := range tests {
+		if got := withConstraints("", tt.schema); got != tt.want {
+			t.Errorf("%s: withConstraints() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
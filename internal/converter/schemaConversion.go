@@ -5,12 +5,34 @@ import (
 	"fmt"
 )
 
+// SchemaMetadata identifies a generated root schema. Empty fields are left out.
+type SchemaMetadata struct {
+	Title string
+	ID    string // emitted as $id
+	// Examples are complete instances of the root schema, emitted as examples
+	Examples []interface{}
+}
+
 // GenerateJSONSchemaDraft7 converts a slice of Arg structs into a JSON Schema Draft 7 string.
 // It creates a root object schema with properties for each argument.
 func GenerateJSONSchemaDraft7(args []Arg) (string, error) {
+	return GenerateJSONSchemaDraft7WithMetadata(args, SchemaMetadata{})
+}
+
+// GenerateJSONSchemaDraft7WithMetadata is GenerateJSONSchemaDraft7 with a title, $id and examples on the root schema
+func GenerateJSONSchemaDraft7WithMetadata(args []Arg, metadata SchemaMetadata) (string, error) {
 	rootSchema := map[string]interface{}{
 		"type": "object",
 	}
+	if metadata.ID != "" {
+		rootSchema["$id"] = metadata.ID
+	}
+	if metadata.Title != "" {
+		rootSchema["title"] = metadata.Title
+	}
+	if len(metadata.Examples) > 0 {
+		rootSchema["examples"] = metadata.Examples
+	}
 
 	properties := make(map[string]interface{})
 	requiredProperties := []string{}
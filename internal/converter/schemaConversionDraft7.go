@@ -1,6 +1,10 @@
 package converter
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"slices"
+)
 
 func schemaToDraft7Map(s *Schema) (map[string]interface{}, error) {
 	if s == nil {
@@ -17,6 +21,7 @@ func schemaToDraft7Map(s *Schema) (map[string]interface{}, error) {
 	if err := addCombinators(result, s); err != nil {
 		return nil, err
 	}
+	addDiscriminatorChain(result, s)
 	if err := addArrayValidation(result, s); err != nil {
 		return nil, err
 	}
@@ -40,12 +45,19 @@ func addBasicMetadata(result map[string]interface{}, s *Schema) {
 	if s.Default != nil {
 		result["default"] = s.Default
 	}
-	if s.Example != nil {
-		result["example"] = s.Example
+	// Examples already holds the OpenAPI 3.0 example; the singular keyword is not JSON Schema
+	switch {
+	case len(s.Examples) > 0:
+		result["examples"] = s.Examples
+	case s.Example != nil:
+		result["examples"] = []interface{}{s.Example}
 	}
 	if len(s.Enum) > 0 {
 		result["enum"] = s.Enum
 	}
+	if s.Const != nil {
+		result["const"] = s.Const
+	}
 	if s.ReadOnly {
 		result["readOnly"] = true
 	}
@@ -86,9 +98,96 @@ func addCombinators(result map[string]interface{}, s *Schema) error {
 		}
 		result["not"] = notSchemaMap
 	}
+	return addConditional(result, s)
+}
+
+// addConditional emits if/then/else. then and else are only meaningful alongside if, so
+// they are dropped when the schema has no if.
+func addConditional(result map[string]interface{}, s *Schema) error {
+	if s.If == nil {
+		return nil
+	}
+	branches := []struct {
+		keyword string
+		schema  *Schema
+	}{{"if", s.If}, {"then", s.Then}, {"else", s.Else}}
+	for _, branch := range branches {
+		if branch.schema == nil {
+			continue
+		}
+		branchMap, err := schemaToDraft7Map(branch.schema)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s sub-schema: %w", branch.keyword, err)
+		}
+		result[branch.keyword] = branchMap
+	}
 	return nil
 }
 
+// addDiscriminatorChain replaces a discriminated oneOf with an if/then/else chain keyed on the
+// discriminator property. It leaves oneOf untouched unless every branch pins the property to a
+// single value (via const or a one-value enum).
+func addDiscriminatorChain(result map[string]interface{}, s *Schema) {
+	if s.Discriminator == nil || len(s.OneOf) == 0 {
+		return
+	}
+	if _, hasIf := result["if"]; hasIf {
+		return
+	}
+	branches, ok := result["oneOf"].([]map[string]interface{})
+	if !ok || len(branches) != len(s.OneOf) {
+		return
+	}
+
+	propertyName := s.Discriminator.PropertyName
+	values := make([]interface{}, len(s.OneOf))
+	for i, branch := range s.OneOf {
+		value, found := discriminatorValue(branch, propertyName)
+		if !found {
+			return
+		}
+		values[i] = value
+	}
+
+	// Build the chain from the last branch backwards; an unknown tag matches no branch.
+	var chain interface{} = false
+	for i := len(branches) - 1; i >= 0; i-- {
+		chain = map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{
+					propertyName: map[string]interface{}{"const": values[i]},
+				},
+				"required": []string{propertyName},
+			},
+			"then": branches[i],
+			"else": chain,
+		}
+	}
+
+	delete(result, "oneOf")
+	for key, value := range chain.(map[string]interface{}) {
+		result[key] = value
+	}
+}
+
+// discriminatorValue returns the single value a oneOf branch allows for the discriminator property
+func discriminatorValue(branch *Schema, propertyName string) (interface{}, bool) {
+	if branch == nil || branch.Object == nil {
+		return nil, false
+	}
+	property := branch.Object.Properties[propertyName]
+	if property == nil {
+		return nil, false
+	}
+	if property.Const != nil {
+		return property.Const, true
+	}
+	if len(property.Enum) == 1 {
+		return property.Enum[0], true
+	}
+	return nil, false
+}
+
 func convertSubSchemas(subSchemas []*Schema) ([]map[string]interface{}, error) {
 	result := make([]map[string]interface{}, len(subSchemas))
 	for i, subSchema := range subSchemas {
@@ -116,6 +215,8 @@ func addStringValidation(result map[string]interface{}, s *Schema) {
 	if s.String == nil {
 		return
 	}
+	// An explicit minLength of 0 cannot be told apart from an absent one, and allows every string
+	// just the same, so it is left out
 	if s.String.MinLength > 0 {
 		result["minLength"] = s.String.MinLength
 	}
@@ -131,25 +232,35 @@ func addNumberValidation(result map[string]interface{}, s *Schema) {
 	if s.Number == nil {
 		return
 	}
+	integer := slices.Contains(s.Types, "integer")
 	if s.Number.Minimum != nil {
 		if s.Number.ExclusiveMinimum {
-			result["exclusiveMinimum"] = *s.Number.Minimum
+			result["exclusiveMinimum"] = numberValue(*s.Number.Minimum, integer)
 		} else {
-			result["minimum"] = *s.Number.Minimum
+			result["minimum"] = numberValue(*s.Number.Minimum, integer)
 		}
 	}
 	if s.Number.Maximum != nil {
 		if s.Number.ExclusiveMaximum {
-			result["exclusiveMaximum"] = *s.Number.Maximum
+			result["exclusiveMaximum"] = numberValue(*s.Number.Maximum, integer)
 		} else {
-			result["maximum"] = *s.Number.Maximum
+			result["maximum"] = numberValue(*s.Number.Maximum, integer)
 		}
 	}
 	if s.Number.MultipleOf != nil {
-		result["multipleOf"] = *s.Number.MultipleOf
+		result["multipleOf"] = numberValue(*s.Number.MultipleOf, integer)
 	}
 }
 
+// numberValue returns a bound of an integer schema as an int64 when it is a whole number, so it
+// is emitted exactly and without float artifacts such as 1e+21. Other values stay float64.
+func numberValue(v float64, integer bool) interface{} {
+	if integer && v == math.Trunc(v) && math.Abs(v) < math.MaxInt64 {
+		return int64(v)
+	}
+	return v
+}
+
 func addArrayValidation(result map[string]interface{}, s *Schema) error {
 	if s.Array == nil {
 		return nil
@@ -172,6 +283,21 @@ func addArrayValidation(result map[string]interface{}, s *Schema) error {
 	if s.Array.UniqueItems {
 		result["uniqueItems"] = true
 	}
+	if s.Array.Contains != nil {
+		containsSchemaMap, err := schemaToDraft7Map(s.Array.Contains)
+		if err != nil {
+			return fmt.Errorf("failed to convert array contains schema: %w", err)
+		}
+		if containsSchemaMap != nil {
+			result["contains"] = containsSchemaMap
+		}
+	}
+	if s.Array.MinContains != nil {
+		result["minContains"] = *s.Array.MinContains
+	}
+	if s.Array.MaxContains != nil {
+		result["maxContains"] = *s.Array.MaxContains
+	}
 	return nil
 }
 
@@ -203,6 +329,13 @@ func addObjectValidation(result map[string]interface{}, s *Schema) error {
 	if s.Object.MaxProperties != nil {
 		result["maxProperties"] = *s.Object.MaxProperties
 	}
+	if s.Object.PropertyNames != nil {
+		propertyNamesMap, err := schemaToDraft7Map(s.Object.PropertyNames)
+		if err != nil {
+			return fmt.Errorf("failed to convert propertyNames schema: %w", err)
+		}
+		result["propertyNames"] = propertyNamesMap
+	}
 
 	// Handle additionalProperties mapping
 	if s.Object.DisallowAdditionalProperties {
@@ -219,5 +352,52 @@ func addObjectValidation(result map[string]interface{}, s *Schema) error {
 		}
 
 	}
+	return addDependencies(result, s.Object)
+}
+
+// addDependencies emits dependentRequired and dependentSchemas. Draft 7 only knows the combined
+// `dependencies` keyword, so unless the Draft 2019-09 keywords were requested they are folded into
+// it; a property with both becomes a schema requiring the listed properties alongside the subschema.
+func addDependencies(result map[string]interface{}, o *ObjectValidation) error {
+	if len(o.DependentRequired) == 0 && len(o.DependentSchemas) == 0 {
+		return nil
+	}
+
+	schemaMaps := make(map[string]interface{}, len(o.DependentSchemas))
+	for propName, dependent := range o.DependentSchemas {
+		dependentMap, err := schemaToDraft7Map(dependent)
+		if err != nil {
+			return fmt.Errorf("failed to convert dependent schema for '%s': %w", propName, err)
+		}
+		if dependentMap != nil {
+			schemaMaps[propName] = dependentMap
+		}
+	}
+
+	if o.DependentKeywords {
+		if len(o.DependentRequired) > 0 {
+			result["dependentRequired"] = o.DependentRequired
+		}
+		if len(schemaMaps) > 0 {
+			result["dependentSchemas"] = schemaMaps
+		}
+		return nil
+	}
+
+	dependencies := make(map[string]interface{}, len(o.DependentRequired)+len(schemaMaps))
+	for propName, required := range o.DependentRequired {
+		dependencies[propName] = required
+	}
+	for propName, dependentMap := range schemaMaps {
+		if required, ok := o.DependentRequired[propName]; ok {
+			dependencies[propName] = map[string]interface{}{
+				"required": required,
+				"allOf":    []interface{}{dependentMap},
+			}
+			continue
+		}
+		dependencies[propName] = dependentMap
+	}
+	result["dependencies"] = dependencies
 	return nil
 }
@@ -2,7 +2,9 @@ package converter
 
 
 import (
+    "encoding/json"
     "reflect"
+    "strings"
     "testing"
 )
 
@@ -68,7 +70,7 @@ func TestSchemaToDraft7Map_Basic(t *testing.T) {
         "description":      "Test Description",
         "format":           "date-time",
         "default":          "default",
-        "example":          "example",
+        "examples":         []interface{}{"example"},
         "enum":             []interface{}{"A", "B"},
         "readOnly":         true,
         "type":             "string",
@@ -111,6 +113,7 @@ func TestAddBasicMetadata(t *testing.T) {
         Format:      "date",
         Default:     42,
         Example:     "foo",
+        Examples:    []interface{}{"foo", "bar"},
         Enum:        []interface{}{"A", "B"},
         ReadOnly:    true,
         WriteOnly:   true,
@@ -123,7 +126,7 @@ func TestAddBasicMetadata(t *testing.T) {
         "description": "My Description",
         "format":      "date",
         "default":     42,
-        "example":     "foo",
+        "examples":    []interface{}{"foo", "bar"},
         "enum":        []interface{}{"A", "B"},
         "readOnly":    true,
         "writeOnly":   true,
@@ -169,6 +172,35 @@ func TestAddCombinators(t *testing.T) {
 }
 
 
+func TestAddCombinators_Conditional(t *testing.T) {
+    s := &Schema{
+        If:   &Schema{Const: "US"},
+        Then: &Schema{String: &StringValidation{Pattern: "^[0-9]{5}$"}},
+        Else: &Schema{String: &StringValidation{Pattern: "^[A-Z0-9 ]+$"}},
+    }
+    result := make(map[string]interface{})
+    if err := addCombinators(result, s); err != nil {
+        t.Fatalf("addCombinators() error = %v", err)
+    }
+    want := map[string]interface{}{
+        "if":   map[string]interface{}{"const": "US"},
+        "then": map[string]interface{}{"pattern": "^[0-9]{5}$"},
+        "else": map[string]interface{}{"pattern": "^[A-Z0-9 ]+$"},
+    }
+    if !reflect.DeepEqual(result, want) {
+        t.Errorf("addCombinators() = %v, want %v", result, want)
+    }
+
+    // then/else without if carry no meaning and are dropped
+    result = make(map[string]interface{})
+    if err := addCombinators(result, &Schema{Then: s.Then}); err != nil {
+        t.Fatalf("addCombinators() error = %v", err)
+    }
+    if len(result) != 0 {
+        t.Errorf("addCombinators() = %v, want empty map", result)
+    }
+}
+
 func TestConvertSubSchemas(t *testing.T) {
     subs := []*Schema{
         {Title: "A"},
@@ -225,6 +257,43 @@ func TestAddStringValidation(t *testing.T) {
 }
 
 
+func TestAddStringValidation_ZeroLengths(t *testing.T) {
+	parser := NewParser(false)
+	spec := `openapi: 3.0.3
+info: {title: Lengths, version: "1.0"}
+paths:
+  /notes:
+    post:
+      operationId: createNote
+      parameters:
+        - {name: empty, in: query, schema: {type: string, maxLength: 0}}
+        - {name: any, in: query, schema: {type: string, minLength: 0}}
+      responses:
+        '204': {description: Created}
+`
+	if err := parser.Parse([]byte(spec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+
+	want := map[string]interface{}{"type": "string", "maxLength": float64(0)}
+	if got := schemaAt(t, schema, []string{"properties", "empty"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("maxLength 0 schema = %v, want %v", got, want)
+	}
+	// minLength 0 allows every string, like an absent minLength, which kin-openapi also reads as 0
+	want = map[string]interface{}{"type": "string"}
+	if got := schemaAt(t, schema, []string{"properties", "any"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("minLength 0 schema = %v, want %v", got, want)
+	}
+}
+
 func TestAddNumberValidation(t *testing.T) {
     min := 1.0
     max := 5.0
@@ -281,6 +350,117 @@ func TestAddArrayValidation(t *testing.T) {
     }
 }
 
+func TestAddArrayValidation_Contains(t *testing.T) {
+    var minContains, maxContains uint64 = 2, 4
+    s := &Schema{
+        Array: &ArrayValidation{
+            Contains:    &Schema{Types: []string{"string"}},
+            MinContains: &minContains,
+            MaxContains: &maxContains,
+        },
+    }
+    result := make(map[string]interface{})
+    if err := addArrayValidation(result, s); err != nil {
+        t.Fatalf("addArrayValidation() error = %v", err)
+    }
+    contains, ok := result["contains"].(map[string]interface{})
+    if !ok || contains["type"] != "string" {
+        t.Errorf("addArrayValidation() contains = %v, want type=string", result["contains"])
+    }
+    if result["minContains"] != minContains {
+        t.Errorf("addArrayValidation() minContains = %v, want %v", result["minContains"], minContains)
+    }
+    if result["maxContains"] != maxContains {
+        t.Errorf("addArrayValidation() maxContains = %v, want %v", result["maxContains"], maxContains)
+    }
+
+    // Draft 7 output has no minContains/maxContains set on the schema
+    result = make(map[string]interface{})
+    s.Array.MinContains, s.Array.MaxContains = nil, nil
+    if err := addArrayValidation(result, s); err != nil {
+        t.Fatalf("addArrayValidation() error = %v", err)
+    }
+    if _, ok := result["minContains"]; ok {
+        t.Errorf("addArrayValidation() emitted minContains without a value")
+    }
+}
+
+func TestAddObjectValidation_Dependencies(t *testing.T) {
+    object := &ObjectValidation{
+        DependentRequired: map[string][]string{
+            "creditCard":     {"billingAddress"},
+            "billingAddress": {"postalCode"},
+        },
+        DependentSchemas: map[string]*Schema{
+            "billingAddress": {Object: &ObjectValidation{Required: []string{"country"}}},
+        },
+    }
+
+    // Draft 7 folds both keywords into dependencies
+    result := make(map[string]interface{})
+    if err := addObjectValidation(result, &Schema{Object: object}); err != nil {
+        t.Fatalf("addObjectValidation() error = %v", err)
+    }
+    wantDependencies := map[string]interface{}{
+        "creditCard": []string{"billingAddress"},
+        "billingAddress": map[string]interface{}{
+            "required": []string{"postalCode"},
+            "allOf":    []interface{}{map[string]interface{}{"required": []string{"country"}}},
+        },
+    }
+    if !reflect.DeepEqual(result["dependencies"], wantDependencies) {
+        t.Errorf("dependencies = %v, want %v", result["dependencies"], wantDependencies)
+    }
+    if _, ok := result["dependentRequired"]; ok {
+        t.Errorf("did not expect dependentRequired in Draft 7 output")
+    }
+
+    // Draft 2019-09+ keeps the split keywords
+    object.DependentKeywords = true
+    result = make(map[string]interface{})
+    if err := addObjectValidation(result, &Schema{Object: object}); err != nil {
+        t.Fatalf("addObjectValidation() error = %v", err)
+    }
+    if !reflect.DeepEqual(result["dependentRequired"], object.DependentRequired) {
+        t.Errorf("dependentRequired = %v, want %v", result["dependentRequired"], object.DependentRequired)
+    }
+    wantSchemas := map[string]interface{}{
+        "billingAddress": map[string]interface{}{"required": []string{"country"}},
+    }
+    if !reflect.DeepEqual(result["dependentSchemas"], wantSchemas) {
+        t.Errorf("dependentSchemas = %v, want %v", result["dependentSchemas"], wantSchemas)
+    }
+    if _, ok := result["dependencies"]; ok {
+        t.Errorf("did not expect dependencies when dependent keywords are requested")
+    }
+}
+
+func TestAddObjectValidation_PropertyNames(t *testing.T) {
+	maxLength := uint64(16)
+	s := &Schema{
+		Object: &ObjectValidation{
+			PropertyNames: &Schema{String: &StringValidation{Pattern: "^[a-z_]+$", MaxLength: &maxLength}},
+		},
+	}
+	result := make(map[string]interface{})
+	if err := addObjectValidation(result, s); err != nil {
+		t.Fatalf("addObjectValidation() error = %v", err)
+	}
+	want := map[string]interface{}{"pattern": "^[a-z_]+$", "maxLength": maxLength}
+	if !reflect.DeepEqual(result["propertyNames"], want) {
+		t.Errorf("addObjectValidation() propertyNames = %v, want %v", result["propertyNames"], want)
+	}
+
+	result = make(map[string]interface{})
+	s.Object.PropertyNames = nil
+	if err := addObjectValidation(result, s); err != nil {
+		t.Fatalf("addObjectValidation() error = %v", err)
+	}
+	if _, ok := result["propertyNames"]; ok {
+		t.Errorf("addObjectValidation() emitted propertyNames without a schema")
+	}
+}
+
 func TestAddObjectValidation(t *testing.T) {
     var maxProps uint64 = 2
     s := &Schema{
@@ -313,3 +493,159 @@ func TestAddObjectValidation(t *testing.T) {
         t.Errorf("addObjectValidation() properties = %v, want foo", result["properties"])
     }
 }
+
+const taggedUnionSpec = `openapi: 3.1.0
+info: {title: Pets, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - type: object
+          properties:
+            kind: {type: string, const: cat}
+            meows: {type: boolean}
+        - type: object
+          properties:
+            kind: {type: string, const: dog}
+            barks: {type: boolean}
+        - type: object
+          properties:
+            kind: {type: string, enum: [bird]}
+            sings: {type: boolean}
+      discriminator:
+        propertyName: kind
+`
+
+func convertTaggedUnion(t *testing.T, options ConvertOptions) map[string]interface{} {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(taggedUnionSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	c := NewConverterWithOptions(parser, options)
+	schema, err := c.applySchema(parser.GetDocument().Components.Schemas["Pet"].Value)
+	if err != nil {
+		t.Fatalf("applySchema() error = %v", err)
+	}
+	got, err := schemaToDraft7Map(schema)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	return got
+}
+
+func TestSchemaToDraft7Map_DiscriminatorIfThenChain(t *testing.T) {
+	got := convertTaggedUnion(t, ConvertOptions{DiscriminatorIfThen: true})
+
+	if _, hasOneOf := got["oneOf"]; hasOneOf {
+		t.Fatalf("expected oneOf to be replaced by an if/then chain, got %v", got)
+	}
+
+	wantTags := []string{"cat", "dog", "bird"}
+	wantProps := []string{"meows", "barks", "sings"}
+	node := got
+	for i, tag := range wantTags {
+		ifClause, ok := node["if"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("branch %d: missing if clause in %v", i, node)
+		}
+		wantIf := map[string]interface{}{
+			"properties": map[string]interface{}{
+				"kind": map[string]interface{}{"const": tag},
+			},
+			"required": []string{"kind"},
+		}
+		if !reflect.DeepEqual(ifClause, wantIf) {
+			t.Errorf("branch %d: if = %v, want %v", i, ifClause, wantIf)
+		}
+		thenClause, ok := node["then"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("branch %d: missing then clause", i)
+		}
+		props, _ := thenClause["properties"].(map[string]interface{})
+		if _, ok := props[wantProps[i]]; !ok {
+			t.Errorf("branch %d: then clause missing property %q: %v", i, wantProps[i], thenClause)
+		}
+		if i == len(wantTags)-1 {
+			if node["else"] != false {
+				t.Errorf("expected final else to be false, got %v", node["else"])
+			}
+			break
+		}
+		node, ok = node["else"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("branch %d: missing else clause", i)
+		}
+	}
+}
+
+func TestSchemaToDraft7Map_DiscriminatorDisabledKeepsOneOf(t *testing.T) {
+	got := convertTaggedUnion(t, ConvertOptions{})
+
+	branches, ok := got["oneOf"].([]map[string]interface{})
+	if !ok || len(branches) != 3 {
+		t.Fatalf("expected 3 oneOf branches, got %v", got["oneOf"])
+	}
+	if _, hasIf := got["if"]; hasIf {
+		t.Errorf("did not expect an if clause without DiscriminatorIfThen")
+	}
+	kind := branches[0]["properties"].(map[string]interface{})["kind"].(map[string]interface{})
+	if kind["const"] != "cat" {
+		t.Errorf("expected const to pass through, got %v", kind)
+	}
+}
+
+func TestAddDiscriminatorChain_BranchWithoutTagKeepsOneOf(t *testing.T) {
+	s := &Schema{
+		Discriminator: &Discriminator{PropertyName: "kind"},
+		OneOf: []*Schema{
+			{Object: &ObjectValidation{Properties: map[string]*Schema{"kind": {Const: "a"}}}},
+			{Object: &ObjectValidation{Properties: map[string]*Schema{"kind": {Types: []string{"string"}}}}},
+		},
+	}
+	got, err := schemaToDraft7Map(s)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map() error = %v", err)
+	}
+	if _, hasOneOf := got["oneOf"]; !hasOneOf {
+		t.Errorf("expected oneOf to be kept when a branch has no discriminator value, got %v", got)
+	}
+}
+
+func TestAddNumberValidation_IntegerBounds(t *testing.T) {
+    min, max, mult, big := 0.0, 1000.0, 5.0, 2147483647.0
+    s := &Schema{
+        Types: []string{"integer", "null"},
+        Number: &NumberValidation{Minimum: &min, Maximum: &max, MultipleOf: &mult},
+    }
+    result := make(map[string]interface{})
+    addNumberValidation(result, s)
+    want := map[string]interface{}{
+        "minimum":    int64(0),
+        "maximum":    int64(1000),
+        "multipleOf": int64(5),
+    }
+    if !reflect.DeepEqual(result, want) {
+        t.Errorf("addNumberValidation() = %#v, want %#v", result, want)
+    }
+
+    // int32 bounds, as used by limit/offset parameters, render as plain integers
+    s.Number = &NumberValidation{Maximum: &big}
+    raw, err := GenerateJSONSchemaDraft7([]Arg{{Name: "limit", Schema: s}})
+    if err != nil {
+        t.Fatalf("GenerateJSONSchemaDraft7() error = %v", err)
+    }
+    if !strings.Contains(raw, `"maximum": 2147483647`) {
+        t.Errorf("expected an integer maximum, got:\n%s", raw)
+    }
+
+    // Fractional bounds and number schemas keep their float values
+    half := 0.5
+    number := &Schema{Types: []string{"number"}, Number: &NumberValidation{Minimum: &max, MultipleOf: &half}}
+    result = make(map[string]interface{})
+    addNumberValidation(result, number)
+    if result["minimum"] != 1000.0 || result["multipleOf"] != 0.5 {
+        t.Errorf("addNumberValidation() = %#v, want float bounds", result)
+    }
+}
@@ -3,6 +3,7 @@ package converter
 import (
 	"encoding/json"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -86,3 +87,141 @@ func TestGenerateJSONSchemaDraft7(t *testing.T) {
 		t.Errorf("bar.type = %v, want integer", bar["type"])
 	}
 }
+
+const nestedValidationSpec = `openapi: 3.1.0
+info: {title: Nested, version: "1.0"}
+paths:
+  /groups:
+    post:
+      operationId: createGroups
+      parameters:
+        - name: q
+          in: query
+          schema: {type: [string, "null"], minLength: 2, maxLength: 8}
+        - name: n
+          in: query
+          schema: {type: [integer, string], minimum: 1, maximum: 10, pattern: "^[0-9]+$"}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                groups:
+                  type: array
+                  minItems: 1
+                  items:
+                    type: object
+                    properties:
+                      tags:
+                        type: array
+                        maxItems: 5
+                        items: {type: string, minLength: 3, maxLength: 9, pattern: "^[a-z]+$"}
+                      scores:
+                        type: array
+                        items: {type: [number, "null"], minimum: 0, maximum: 5, multipleOf: 0.5}
+                      nested:
+                        type: [object, "null"]
+                        minProperties: 1
+                        properties:
+                          deep:
+                            type: array
+                            items:
+                              type: array
+                              uniqueItems: true
+                              items: {type: string, minLength: 1}
+                labels:
+                  type: object
+                  additionalProperties: {type: string, maxLength: 5}
+                choice:
+                  anyOf:
+                    - {type: string, minLength: 4}
+                    - {type: integer, minimum: 3}
+                untyped:
+                  items: {minLength: 2}
+                conditional:
+                  type: object
+                  if: {properties: {kind: {const: a}}}
+                  then:
+                    properties:
+                      value: {type: string, maxLength: 1}
+      responses:
+        '200': {description: OK}
+`
+
+// TestGenerateJSONSchemaDraft7_NestedValidations checks that type-specific validations survive
+// however deep they are nested and whatever types their schema allows
+func TestGenerateJSONSchemaDraft7_NestedValidations(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(nestedValidationSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &root); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+
+	body := []string{"properties", "body", "properties"}
+	group := append(append([]string{}, body...), "groups", "items", "properties")
+	tests := []struct {
+		name string
+		path []string
+		want map[string]interface{}
+	}{
+		{"nullable string parameter", []string{"properties", "q"}, map[string]interface{}{"minLength": 2.0, "maxLength": 8.0}},
+		{"multi-type parameter", []string{"properties", "n"}, map[string]interface{}{"minimum": 1.0, "maximum": 10.0, "pattern": "^[0-9]+$"}},
+		{"array of objects", append(append([]string{}, body...), "groups"), map[string]interface{}{"minItems": 1.0}},
+		{"string items two levels deep", append(append([]string{}, group...), "tags", "items"), map[string]interface{}{"minLength": 3.0, "maxLength": 9.0, "pattern": "^[a-z]+$"}},
+		{"array two levels deep", append(append([]string{}, group...), "tags"), map[string]interface{}{"maxItems": 5.0}},
+		{"nullable number items", append(append([]string{}, group...), "scores", "items"), map[string]interface{}{"minimum": 0.0, "maximum": 5.0, "multipleOf": 0.5}},
+		{"nullable object", append(append([]string{}, group...), "nested"), map[string]interface{}{"minProperties": 1.0}},
+		{"array of arrays", append(append([]string{}, group...), "nested", "properties", "deep", "items"), map[string]interface{}{"uniqueItems": true}},
+		{"string four levels deep", append(append([]string{}, group...), "nested", "properties", "deep", "items", "items"), map[string]interface{}{"minLength": 1.0}},
+		{"additionalProperties", append(append([]string{}, body...), "labels", "additionalProperties"), map[string]interface{}{"maxLength": 5.0}},
+		{"anyOf string branch", append(append([]string{}, body...), "choice", "anyOf", "0"), map[string]interface{}{"minLength": 4.0}},
+		{"anyOf integer branch", append(append([]string{}, body...), "choice", "anyOf", "1"), map[string]interface{}{"minimum": 3.0}},
+		{"untyped items", append(append([]string{}, body...), "untyped", "items"), map[string]interface{}{"minLength": 2.0}},
+		{"then branch", append(append([]string{}, body...), "conditional", "then", "properties", "value"), map[string]interface{}{"maxLength": 1.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := schemaAt(t, root, tt.path)
+			for keyword, want := range tt.want {
+				if got := node[keyword]; !reflect.DeepEqual(got, want) {
+					t.Errorf("%s = %v, want %v (schema %v)", keyword, got, want, node)
+				}
+			}
+		})
+	}
+}
+
+// schemaAt walks a decoded JSON schema along path; numeric elements index into arrays
+func schemaAt(t *testing.T, root map[string]interface{}, path []string) map[string]interface{} {
+	t.Helper()
+	var node interface{} = root
+	for _, key := range path {
+		switch current := node.(type) {
+		case map[string]interface{}:
+			node = current[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index >= len(current) {
+				t.Fatalf("invalid index %q in path %v", key, path)
+			}
+			node = current[index]
+		}
+		if node == nil {
+			t.Fatalf("no schema at %q in path %v", key, path)
+		}
+	}
+	result, ok := node.(map[string]interface{})
+	if !ok {
+		t.Fatalf("schema at %v is %T, not an object", path, node)
+	}
+	return result
+}
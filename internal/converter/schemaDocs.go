@@ -0,0 +1,72 @@
+package converter
+
+// inputSchemaArgs returns the arguments to build the tool's input schema from, with descriptions
+// and examples removed, constraint hints added or objects closed as requested by the options.
+// The returned args are copies so argument descriptions stay available to other outputs; their
+// schemas are changed in place because they only feed the input schema.
+func (c *Converter) inputSchemaArgs(args []Arg) []Arg {
+	omitDescriptions, omitExamples := c.options.OmitSchemaDescriptions, c.options.OmitSchemaExamples
+	describeConstraints := c.options.DescribeConstraints && !omitDescriptions
+	strict := c.options.AdditionalProperties == AdditionalPropertiesStrict
+	if !omitDescriptions && !omitExamples && !describeConstraints && !strict {
+		return args
+	}
+
+	result := make([]Arg, len(args))
+	for i, arg := range args {
+		if omitDescriptions {
+			arg.Description = ""
+		}
+		stripSchemaDocs(arg.Schema, omitDescriptions, omitExamples)
+		for _, schema := range arg.ContentTypes {
+			stripSchemaDocs(schema, omitDescriptions, omitExamples)
+		}
+		if describeConstraints {
+			arg = describeArgConstraints(arg)
+		}
+		if strict {
+			closeObjects(arg.Schema, false)
+			for _, schema := range arg.ContentTypes {
+				closeObjects(schema, false)
+			}
+		}
+		result[i] = arg
+	}
+	return result
+}
+
+// stripSchemaDocs clears descriptions and/or examples throughout a schema tree
+func stripSchemaDocs(s *Schema, descriptions, examples bool) {
+	if s == nil {
+		return
+	}
+	if descriptions {
+		s.Description = ""
+	}
+	if examples {
+		s.Example = nil
+		s.Examples = nil
+	}
+
+	children := make([]*Schema, 0, len(s.OneOf)+len(s.AnyOf)+len(s.AllOf)+4)
+	children = append(children, s.OneOf...)
+	children = append(children, s.AnyOf...)
+	children = append(children, s.AllOf...)
+	children = append(children, s.Not, s.If, s.Then, s.Else)
+	if s.Array != nil {
+		children = append(children, s.Array.Items, s.Array.Contains)
+	}
+	if s.Object != nil {
+		for _, prop := range s.Object.Properties {
+			children = append(children, prop)
+		}
+		for _, dependent := range s.Object.DependentSchemas {
+			children = append(children, dependent)
+		}
+		children = append(children, s.Object.AdditionalProperties, s.Object.PropertyNames)
+	}
+
+	for _, child := range children {
+		stripSchemaDocs(child, descriptions, examples)
+	}
+}
@@ -0,0 +1,99 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const schemaDocsSpec = `openapi: 3.1.0
+info: {title: Docs, version: "1.0"}
+paths:
+  /pets/{id}:
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: The pet identifier
+          schema:
+            type: string
+            example: pet-1
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              description: The pet to store
+              properties:
+                tags:
+                  type: array
+                  items:
+                    type: string
+                    description: A tag
+                    examples: [friendly]
+      responses:
+        '200':
+          description: OK
+`
+
+func convertSchemaDocsSpec(t *testing.T, options ConvertOptions) Tool {
+	t.Helper()
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(schemaDocsSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverterWithOptions(parser, options).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(config.Tools) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(config.Tools))
+	}
+	return config.Tools[0]
+}
+
+func TestInputSchema_KeepsDocsByDefault(t *testing.T) {
+	tool := convertSchemaDocsSpec(t, ConvertOptions{})
+	for _, want := range []string{`"description": "The pet identifier"`, `"description": "A tag"`, `"friendly"`, `"pet-1"`} {
+		if !strings.Contains(tool.RawInputSchema, want) {
+			t.Errorf("expected input schema to contain %s\n%s", want, tool.RawInputSchema)
+		}
+	}
+}
+
+func TestInputSchema_OmitDescriptions(t *testing.T) {
+	tool := convertSchemaDocsSpec(t, ConvertOptions{OmitSchemaDescriptions: true})
+	if strings.Contains(tool.RawInputSchema, `"description"`) {
+		t.Errorf("expected no descriptions in input schema\n%s", tool.RawInputSchema)
+	}
+	if !strings.Contains(tool.RawInputSchema, `"friendly"`) {
+		t.Errorf("expected examples to be kept\n%s", tool.RawInputSchema)
+	}
+
+	// Argument descriptions stay available for other outputs such as the manifest
+	found := false
+	for _, arg := range tool.Args {
+		if arg.Name == "id" {
+			found = true
+			if arg.Description != "The pet identifier" {
+				t.Errorf("expected arg description to be kept, got %q", arg.Description)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected an id argument, got %+v", tool.Args)
+	}
+}
+
+func TestInputSchema_OmitExamples(t *testing.T) {
+	tool := convertSchemaDocsSpec(t, ConvertOptions{OmitSchemaExamples: true})
+	for _, unwanted := range []string{`"example"`, `"examples"`} {
+		if strings.Contains(tool.RawInputSchema, unwanted) {
+			t.Errorf("expected no %s in input schema\n%s", unwanted, tool.RawInputSchema)
+		}
+	}
+	if !strings.Contains(tool.RawInputSchema, `"description": "A tag"`) {
+		t.Errorf("expected descriptions to be kept\n%s", tool.RawInputSchema)
+	}
+}
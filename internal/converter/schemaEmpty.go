@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Descriptions given to empty properties by EmptySchemaAnnotate
+const (
+	anyValueDescription   = "any value"
+	alwaysNullDescription = "always null"
+)
+
+// ParseEmptySchemaMode parses the keep, drop and annotate mode names
+func ParseEmptySchemaMode(name string) (EmptySchemaMode, error) {
+	switch name {
+	case "keep", "":
+		return EmptySchemaKeep, nil
+	case "drop":
+		return EmptySchemaDrop, nil
+	case "annotate":
+		return EmptySchemaAnnotate, nil
+	}
+	return EmptySchemaKeep, fmt.Errorf("unknown empty schemas mode %q: use keep, drop or annotate", name)
+}
+
+// handleEmptyProperties drops or annotates the properties of an object whose schema constrains
+// nothing. A property is empty when its converted schema has no type, keyword, description or
+// other annotation (`{}`, or the placeholder used for a property whose $ref did not resolve), or
+// when its only type is null (`nullable: true` without a type, or `type: "null"`).
+func (c *Converter) handleEmptyProperties(object *ObjectValidation) {
+	if c.options.EmptySchemas == EmptySchemaKeep || object == nil {
+		return
+	}
+	for name, prop := range object.Properties {
+		empty, nullOnly := isEmptySchema(prop), isNullOnlySchema(prop)
+		if !empty && !nullOnly {
+			continue
+		}
+		switch c.options.EmptySchemas {
+		case EmptySchemaDrop:
+			delete(object.Properties, name)
+			object.Required = removeString(object.Required, name)
+		case EmptySchemaAnnotate:
+			prop.Description = anyValueDescription
+			if nullOnly {
+				prop.Description = alwaysNullDescription
+			}
+		}
+	}
+	if len(object.Properties) == 0 {
+		object.Properties = nil
+	}
+	if len(object.Required) == 0 {
+		object.Required = nil
+	}
+}
+
+// isEmptySchema reports whether a converted schema constrains and describes nothing
+func isEmptySchema(s *Schema) bool {
+	if s == nil {
+		return true
+	}
+	stripped := *s
+	stripped.Types = nil
+	return len(s.Types) == 0 && reflect.DeepEqual(stripped, Schema{})
+}
+
+// isNullOnlySchema reports whether a converted schema only allows null and says nothing else
+func isNullOnlySchema(s *Schema) bool {
+	if s == nil || len(s.Types) != 1 || s.Types[0] != "null" {
+		return false
+	}
+	stripped := *s
+	stripped.Types = nil
+	return reflect.DeepEqual(stripped, Schema{})
+}
+
+// removeString returns values without name, preserving order
+func removeString(values []string, name string) []string {
+	result := values[:0:0]
+	for _, value := range values {
+		if value != name {
+			result = append(result, value)
+		}
+	}
+	return result
+}
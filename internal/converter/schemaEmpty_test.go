@@ -0,0 +1,106 @@
+package converter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const emptySchemaSpec = `openapi: 3.0.3
+info: {title: Empty, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Item:
+      type: object
+      required: [id, anything, nothing]
+      properties:
+        id: {type: string}
+        anything: {}
+        nothing: {nullable: true}
+        described: {description: Free-form metadata}
+        typed: {type: object}
+`
+
+func TestHandleEmptyProperties(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         EmptySchemaMode
+		wantProps    []string
+		wantRequired []string
+		wantDesc     map[string]string
+	}{
+		{
+			name:         "keep",
+			mode:         EmptySchemaKeep,
+			wantProps:    []string{"anything", "described", "id", "nothing", "typed"},
+			wantRequired: []string{"id", "anything", "nothing"},
+			wantDesc:     map[string]string{"anything": "", "nothing": ""},
+		},
+		{
+			name:         "drop",
+			mode:         EmptySchemaDrop,
+			wantProps:    []string{"described", "id", "typed"},
+			wantRequired: []string{"id"},
+		},
+		{
+			name:         "annotate",
+			mode:         EmptySchemaAnnotate,
+			wantProps:    []string{"anything", "described", "id", "nothing", "typed"},
+			wantRequired: []string{"id", "anything", "nothing"},
+			wantDesc: map[string]string{
+				"anything":  "any value",
+				"nothing":   "always null",
+				"described": "Free-form metadata",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(false)
+			if err := parser.Parse([]byte(emptySchemaSpec)); err != nil {
+				t.Fatalf("failed to parse spec: %v", err)
+			}
+			c := NewConverterWithOptions(parser, ConvertOptions{EmptySchemas: tt.mode})
+			result, err := c.applySchema(parser.GetDocument().Components.Schemas["Item"].Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var props []string
+			for name := range result.Object.Properties {
+				props = append(props, name)
+			}
+			sort.Strings(props)
+			if !reflect.DeepEqual(props, tt.wantProps) {
+				t.Errorf("properties = %v, want %v", props, tt.wantProps)
+			}
+			if !reflect.DeepEqual(result.Object.Required, tt.wantRequired) {
+				t.Errorf("required = %v, want %v", result.Object.Required, tt.wantRequired)
+			}
+			for name, want := range tt.wantDesc {
+				if got := result.Object.Properties[name].Description; got != want {
+					t.Errorf("%s description = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEmptySchemaMode(t *testing.T) {
+	for name, want := range map[string]EmptySchemaMode{
+		"":         EmptySchemaKeep,
+		"keep":     EmptySchemaKeep,
+		"drop":     EmptySchemaDrop,
+		"annotate": EmptySchemaAnnotate,
+	} {
+		got, err := ParseEmptySchemaMode(name)
+		if err != nil || got != want {
+			t.Errorf("ParseEmptySchemaMode(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseEmptySchemaMode("remove"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
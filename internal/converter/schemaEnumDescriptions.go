@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// enumDescriptionExtensions are the vendor extensions that document enum values, in order of
+// precedence. Each holds either a list parallel to enum or a map keyed by the enum values.
+var enumDescriptionExtensions = []string{"x-enum-descriptions", "x-enumDescriptions", "x-enumNames"}
+
+// enumDescription returns the schema's description followed by what each of its enum values means,
+// taken from the first enumDescriptionExtensions key the schema sets:
+//
+//	Status of the task
+//
+//	Values: 'todo' = not started yet; 'in-progress' = actively being worked on
+//
+// Extensions that do not match the enum are reported as warnings and ignored.
+func (c *Converter) enumDescription(schema *openapi3.Schema) string {
+	if len(schema.Enum) == 0 {
+		return schema.Description
+	}
+	for _, key := range enumDescriptionExtensions {
+		raw, ok := schema.Extensions[key]
+		if !ok {
+			continue
+		}
+		labels, err := enumLabels(schema.Enum, raw)
+		if err != nil {
+			c.warnf("%s: ignoring %s: %v", c.location, key, err)
+			continue
+		}
+		return appendEnumLabels(schema.Description, schema.Enum, labels)
+	}
+	return schema.Description
+}
+
+// enumLabels pairs each enum value with its label from a list in enum order or a map keyed by the
+// values; values without a label get ""
+func enumLabels(enum []interface{}, raw interface{}) ([]string, error) {
+	labels := make([]string, len(enum))
+	switch documented := raw.(type) {
+	case []interface{}:
+		if len(documented) != len(enum) {
+			return nil, fmt.Errorf("it has %d entries for %d enum values", len(documented), len(enum))
+		}
+		for i, label := range documented {
+			if label != nil {
+				labels[i] = fmt.Sprint(label)
+			}
+		}
+	case map[string]interface{}:
+		for i, value := range enum {
+			if label, ok := documented[fmt.Sprint(value)]; ok && label != nil {
+				labels[i] = fmt.Sprint(label)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("it must be a list in enum order or a map of enum values to descriptions")
+	}
+	return labels, nil
+}
+
+// appendEnumLabels adds a "Values: ..." paragraph listing the labelled enum values to a description
+func appendEnumLabels(description string, enum []interface{}, labels []string) string {
+	var values []string
+	for i, value := range enum {
+		label := strings.Join(strings.Fields(labels[i]), " ")
+		if label == "" {
+			continue
+		}
+		values = append(values, fmt.Sprintf("%s = %s", formatEnumValue(value), label))
+	}
+	if len(values) == 0 {
+		return description
+	}
+	line := "Values: " + strings.Join(values, "; ")
+	if description == "" {
+		return line
+	}
+	return strings.TrimRight(description, "\n") + "\n\n" + line
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const enumDescriptionsSpec = `openapi: 3.0.3
+info: {title: Tasks, version: "1.0"}
+paths:
+  /tasks:
+    post:
+      operationId: createTask
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                status:
+                  type: string
+                  description: Status of the task
+                  enum: [todo, in-progress, done]
+                  x-enum-descriptions:
+                    - Not started yet
+                    - Actively being worked on
+                    - Finished
+                priority:
+                  type: integer
+                  enum: [1, 2, 3]
+                  x-enumDescriptions: {"1": Low, "3": High}
+                color:
+                  type: string
+                  enum: [r, g]
+                  x-enumNames: [Red, Green]
+                size:
+                  type: string
+                  description: Size
+                  enum: [s, m]
+                  x-enum-descriptions: [Small]
+      responses:
+        '201': {description: Created}
+`
+
+func TestConvert_EnumDescriptions(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(enumDescriptionsSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var schema struct {
+		Properties map[string]struct {
+			Properties map[string]struct {
+				Description string `json:"description"`
+			} `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(config.Tools[0].RawInputSchema), &schema); err != nil {
+		t.Fatalf("invalid input schema: %v", err)
+	}
+	properties := schema.Properties["body"].Properties
+	want := map[string]string{
+		"status":   "Status of the task\n\nValues: 'todo' = Not started yet; 'in-progress' = Actively being worked on; 'done' = Finished",
+		"priority": "Values: 1 = Low; 3 = High",
+		"color":    "Values: 'r' = Red; 'g' = Green",
+		"size":     "Size",
+	}
+	for name, desc := range want {
+		if got := properties[name].Description; got != desc {
+			t.Errorf("%s description = %q, want %q", name, got, desc)
+		}
+	}
+
+	found := false
+	for _, warning := range config.Warnings {
+		if strings.Contains(warning, "property size: ignoring x-enum-descriptions: it has 1 entries for 2 enum values") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about the mismatched x-enum-descriptions, got %v", config.Warnings)
+	}
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatPatterns maps well-known string formats to regular expressions that enforce them.
+// Formats are passed through as `format` unless ConvertOptions.FormatMappings replaces them; these
+// patterns are only added when ConvertOptions.TranslateFormatsToPatterns is set and the schema has
+// no pattern of its own.
+var formatPatterns = map[string]string{
+	"uuid":      `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	"email":     `^[^@\s]+@[^@\s]+\.[^@\s]+$`,
+	"date":      `^\d{4}-\d{2}-\d{2}$`,
+	"date-time": `^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})$`,
+	"time":      `^\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})?$`,
+	"ipv4":      `^((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)$`,
+	"uri":       `^[a-zA-Z][a-zA-Z0-9+.-]*:\S*$`,
+}
+
+// knownFormats are the formats defined by JSON Schema and the OpenAPI specification
+var knownFormats = map[string]bool{
+	"date-time": true, "date": true, "time": true, "duration": true,
+	"email": true, "idn-email": true, "hostname": true, "idn-hostname": true,
+	"ipv4": true, "ipv6": true, "uri": true, "uri-reference": true, "iri": true, "iri-reference": true,
+	"uuid": true, "uri-template": true, "json-pointer": true, "relative-json-pointer": true, "regex": true,
+	"int32": true, "int64": true, "float": true, "double": true, "byte": true, "binary": true, "password": true,
+}
+
+// ParseUnknownFormatMode parses the passthrough, warn and map mode names
+func ParseUnknownFormatMode(name string) (UnknownFormatMode, error) {
+	switch name {
+	case "passthrough", "":
+		return UnknownFormatPassthrough, nil
+	case "warn":
+		return UnknownFormatWarn, nil
+	case "map":
+		return UnknownFormatMap, nil
+	}
+	return UnknownFormatPassthrough, fmt.Errorf("unknown formats mode %q: use passthrough, warn or map", name)
+}
+
+// ParseFormatMappings parses a comma-separated list of format mappings, such as
+// "decimal=double,money=", into a table; an empty target drops the format
+func ParseFormatMappings(list string) (map[string]string, error) {
+	mappings := map[string]string{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to, found := strings.Cut(entry, "=")
+		from = strings.TrimSpace(from)
+		if !found || from == "" {
+			return nil, fmt.Errorf("invalid format mapping %q: use format=replacement, e.g. decimal=double", entry)
+		}
+		mappings[from] = strings.TrimSpace(to)
+	}
+	return mappings, nil
+}
+
+// mapFormat returns the format a schema format is converted to: its FormatMappings entry in
+// UnknownFormatMap mode, the format itself otherwise
+func (c *Converter) mapFormat(format string) string {
+	if c.options.UnknownFormats == UnknownFormatMap {
+		if mapped, ok := c.options.FormatMappings[format]; ok {
+			return mapped
+		}
+	}
+	return format
+}
+
+// checkFormat warns about a format that is neither a JSON Schema nor an OpenAPI format in
+// UnknownFormatWarn mode, and in UnknownFormatMap mode when FormatMappings has no entry for it
+func (c *Converter) checkFormat(format string) {
+	if format == "" || knownFormats[format] {
+		return
+	}
+	switch c.options.UnknownFormats {
+	case UnknownFormatWarn:
+		c.warnf("%s: unknown format %q, passing it through", c.location, format)
+	case UnknownFormatMap:
+		if _, ok := c.options.FormatMappings[format]; !ok {
+			c.warnf("%s: unknown format %q has no mapping, passing it through", c.location, format)
+		}
+	}
+}
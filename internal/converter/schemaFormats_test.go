@@ -0,0 +1,179 @@
+package converter
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFormatPatterns_Match(t *testing.T) {
+	tests := []struct {
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		{"uuid", []string{"123e4567-e89b-12d3-a456-426614174000"}, []string{"123e4567", "not-a-uuid"}},
+		{"email", []string{"jane@example.com"}, []string{"jane", "jane@", "@example.com"}},
+		{"date", []string{"2024-02-29"}, []string{"2024-2-29", "2024-02-29T00:00:00Z"}},
+		{"date-time", []string{"2024-02-29T10:20:30Z", "2024-02-29T10:20:30.123+02:00"}, []string{"2024-02-29", "2024-02-29 10:20:30"}},
+		{"ipv4", []string{"192.168.0.1"}, []string{"256.1.1.1", "1.2.3"}},
+		{"uri", []string{"https://example.com/a?b=c", "urn:isbn:123"}, []string{"/relative/path", "has space:x y"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			pattern, ok := formatPatterns[tt.format]
+			if !ok {
+				t.Fatalf("no pattern registered for format %q", tt.format)
+			}
+			re := regexp.MustCompile(pattern)
+			for _, v := range tt.valid {
+				if !re.MatchString(v) {
+					t.Errorf("pattern for %q should match %q", tt.format, v)
+				}
+			}
+			for _, v := range tt.invalid {
+				if re.MatchString(v) {
+					t.Errorf("pattern for %q should not match %q", tt.format, v)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySchema_FormatPassThrough(t *testing.T) {
+	c := &Converter{}
+	for _, format := range []string{"uuid", "email", "date", "date-time", "uri", "ipv4", "custom-format"} {
+		result, err := c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: format})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		draft7, err := schemaToDraft7Map(result)
+		if err != nil {
+			t.Fatalf("schemaToDraft7Map() error = %v", err)
+		}
+		if draft7["format"] != format {
+			t.Errorf("format = %v, want %q", draft7["format"], format)
+		}
+		if _, hasPattern := draft7["pattern"]; hasPattern {
+			t.Errorf("format %q: pattern should not be added without TranslateFormatsToPatterns", format)
+		}
+	}
+}
+
+func TestApplySchema_TranslateFormatsToPatterns(t *testing.T) {
+	c := NewConverterWithOptions(nil, ConvertOptions{TranslateFormatsToPatterns: true})
+
+	for _, format := range []string{"uuid", "email", "date", "date-time"} {
+		result, err := c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: format})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		draft7, err := schemaToDraft7Map(result)
+		if err != nil {
+			t.Fatalf("schemaToDraft7Map() error = %v", err)
+		}
+		if draft7["format"] != format {
+			t.Errorf("format = %v, want %q", draft7["format"], format)
+		}
+		if draft7["pattern"] != formatPatterns[format] {
+			t.Errorf("format %q: pattern = %v, want %q", format, draft7["pattern"], formatPatterns[format])
+		}
+	}
+
+	// An explicit pattern always wins over the translated one
+	result, err := c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "email", Pattern: "^.+@corp\\.com$"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.String.Pattern != "^.+@corp\\.com$" {
+		t.Errorf("expected explicit pattern to be kept, got %q", result.String.Pattern)
+	}
+
+	// Unknown formats get no pattern
+	result, err = c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "custom-format"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.String.Pattern != "" {
+		t.Errorf("expected no pattern for unknown format, got %q", result.String.Pattern)
+	}
+}
+
+func TestApplySchema_UnknownFormats(t *testing.T) {
+	mappings := map[string]string{"decimal": "double", "money": "", "guid": "uuid"}
+	tests := []struct {
+		name         string
+		mode         UnknownFormatMode
+		wantFormats  []string
+		wantWarnings []string
+	}{
+		{
+			name:        "passthrough",
+			mode:        UnknownFormatPassthrough,
+			wantFormats: []string{"decimal", "money", "guid", "iban", "date"},
+		},
+		{
+			name:        "warn",
+			mode:        UnknownFormatWarn,
+			wantFormats: []string{"decimal", "money", "guid", "iban", "date"},
+			wantWarnings: []string{
+				`POST /pay: unknown format "decimal", passing it through`,
+				`POST /pay: unknown format "money", passing it through`,
+				`POST /pay: unknown format "guid", passing it through`,
+				`POST /pay: unknown format "iban", passing it through`,
+			},
+		},
+		{
+			name:         "map",
+			mode:         UnknownFormatMap,
+			wantFormats:  []string{"double", "", "uuid", "iban", "date"},
+			wantWarnings: []string{`POST /pay: unknown format "iban" has no mapping, passing it through`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverterWithOptions(nil, ConvertOptions{
+				UnknownFormats:             tt.mode,
+				FormatMappings:             mappings,
+				TranslateFormatsToPatterns: true,
+			})
+			c.location = "POST /pay"
+			var formats []string
+			for _, format := range []string{"decimal", "money", "guid", "iban", "date"} {
+				result, err := c.applySchema(&openapi3.Schema{Type: &openapi3.Types{"string"}, Format: format})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				formats = append(formats, result.Format)
+				if result.String.Pattern != formatPatterns[result.Format] {
+					t.Errorf("format %q: pattern = %q, want the pattern of %q", format, result.String.Pattern, result.Format)
+				}
+			}
+			if !reflect.DeepEqual(formats, tt.wantFormats) {
+				t.Errorf("formats = %q, want %q", formats, tt.wantFormats)
+			}
+			if !reflect.DeepEqual(c.warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", c.warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestParseFormatMappings(t *testing.T) {
+	got, err := ParseFormatMappings(" decimal=double, money= ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"decimal": "double", "money": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFormatMappings() = %v, want %v", got, want)
+	}
+	for _, invalid := range []string{"decimal", "=double"} {
+		if _, err := ParseFormatMappings(invalid); err == nil {
+			t.Errorf("ParseFormatMappings(%q) succeeded, want an error", invalid)
+		}
+	}
+}
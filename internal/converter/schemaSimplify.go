@@ -0,0 +1,108 @@
+package converter
+
+import "reflect"
+
+// annotationFields are the Schema fields that describe instances without constraining them.
+// When a single-branch combinator is inlined, the parent's annotations take precedence.
+var annotationFields = map[string]bool{
+	"Title":       true,
+	"Description": true,
+	"Default":     true,
+	"Example":     true,
+	"Examples":    true,
+	"ReadOnly":    true,
+	"WriteOnly":   true,
+}
+
+// simplifyCombinators inlines oneOf, anyOf and allOf compositions with a single branch into s,
+// repeating until none is left: the branch's keywords move to s and the combinator is removed.
+// A single-branch combinator validates exactly what its branch does, so this is only skipped when
+// s and the branch set the same constraint differently (e.g. two types or two sets of object
+// properties), which would otherwise need intersecting, when either sets if/then/else, and for a
+// oneOf s selects with a discriminator. Annotations such as descriptions are kept from s when both set them.
+func simplifyCombinators(s *Schema) {
+	for s != nil {
+		var branch *Schema
+		remaining := *s
+		switch {
+		case len(s.AllOf) == 1:
+			branch, remaining.AllOf = s.AllOf[0], nil
+		case len(s.AnyOf) == 1:
+			branch, remaining.AnyOf = s.AnyOf[0], nil
+		case len(s.OneOf) == 1 && s.Discriminator == nil:
+			branch, remaining.OneOf = s.OneOf[0], nil
+		}
+		if branch == nil {
+			return
+		}
+		merged, ok := mergeSchemaKeywords(remaining, *branch)
+		if !ok {
+			return
+		}
+		*s = merged
+	}
+}
+
+// mergeSchemaKeywords combines the keywords of two schemas an instance must both satisfy into
+// one schema. It fails when both set the same non-annotation keyword to different values, and
+// when either sets if, then or else: those only make sense together, and merging them keyword by
+// keyword could pair one side's if with the other's then.
+func mergeSchemaKeywords(parent, branch Schema) (Schema, bool) {
+	if hasConditional(&parent) || hasConditional(&branch) {
+		return Schema{}, false
+	}
+	merged := reflect.ValueOf(&parent).Elem()
+	other := reflect.ValueOf(branch)
+	for i := 0; i < merged.NumField(); i++ {
+		field, value := merged.Field(i), other.Field(i)
+		switch {
+		case isUnsetKeyword(value):
+		case isUnsetKeyword(field):
+			field.Set(value)
+		case annotationFields[merged.Type().Field(i).Name]:
+		case merged.Type().Field(i).Name == "String":
+			// minLength, maxLength and pattern constrain strings independently of each other
+			stringKeywords, ok := mergeStringValidations(*parent.String, *branch.String)
+			if !ok {
+				return Schema{}, false
+			}
+			parent.String = &stringKeywords
+		case !reflect.DeepEqual(field.Interface(), value.Interface()):
+			return Schema{}, false
+		}
+	}
+	return parent, true
+}
+
+// hasConditional reports whether a schema sets any of if, then and else
+func hasConditional(s *Schema) bool {
+	return s.If != nil || s.Then != nil || s.Else != nil
+}
+
+// mergeStringValidations combines two sets of string keywords, failing when both set one differently.
+// Number, array and object keywords are only merged as a whole, since some depend on their siblings
+// (exclusiveMinimum on minimum, minContains on contains, additionalProperties on properties).
+func mergeStringValidations(parent, branch StringValidation) (StringValidation, bool) {
+	merged := reflect.ValueOf(&parent).Elem()
+	other := reflect.ValueOf(branch)
+	for i := 0; i < merged.NumField(); i++ {
+		field, value := merged.Field(i), other.Field(i)
+		switch {
+		case value.IsZero():
+		case field.IsZero():
+			field.Set(value)
+		case !reflect.DeepEqual(field.Interface(), value.Interface()):
+			return StringValidation{}, false
+		}
+	}
+	return parent, true
+}
+
+// isUnsetKeyword reports whether a Schema field is unset; empty slices and maps count as unset,
+// as applySchema leaves Types empty rather than nil for schemas without a type
+func isUnsetKeyword(value reflect.Value) bool {
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Map {
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
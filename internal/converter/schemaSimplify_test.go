@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+const simplifyCombinatorsSpec = `openapi: 3.0.3
+info: {title: Combinators, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Name: {type: string, description: A name, maxLength: 20}
+    OneOf:
+      oneOf: [{$ref: '#/components/schemas/Name'}]
+    AnyOf:
+      anyOf: [{type: integer, minimum: 1}]
+    AllOf:
+      description: The owner's name
+      allOf: [{$ref: '#/components/schemas/Name'}]
+    Nested:
+      oneOf:
+        - allOf: [{type: boolean}]
+    Siblings:
+      minLength: 2
+      allOf: [{$ref: '#/components/schemas/Name'}]
+    ConflictingTypes:
+      type: string
+      allOf: [{type: integer}]
+    ConflictingObjects:
+      type: object
+      properties: {a: {type: string}}
+      allOf:
+        - type: object
+          properties: {b: {type: string}}
+    Discriminated:
+      oneOf: [{$ref: '#/components/schemas/Name'}]
+      discriminator: {propertyName: kind}
+    TwoBranches:
+      oneOf: [{type: string}, {type: integer}]
+    ConditionalBranch:
+      type: object
+      allOf:
+        - if: {required: [card]}
+          then: {required: [billing]}
+    SplitConditional:
+      type: object
+      if: {required: [card]}
+      allOf:
+        - then: {required: [billing]}
+`
+
+func TestSimplifyCombinators(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(simplifyCombinatorsSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	name := map[string]interface{}{"type": "string", "description": "A name", "maxLength": uint64(20)}
+
+	tests := []struct {
+		schema string
+		want   map[string]interface{}
+	}{
+		{"OneOf", name},
+		{"AnyOf", map[string]interface{}{"type": "integer", "minimum": int64(1)}},
+		{"AllOf", map[string]interface{}{"type": "string", "description": "The owner's name", "maxLength": uint64(20)}},
+		{"Nested", map[string]interface{}{"type": "boolean"}},
+		{"Siblings", map[string]interface{}{"type": "string", "description": "A name", "minLength": uint64(2), "maxLength": uint64(20)}},
+	}
+	for _, tt := range tests {
+		got := convertComponentDraft7(t, parser, tt.schema, ConvertOptions{SimplifyCombinators: true, DiscriminatorIfThen: true})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %#v, want %#v", tt.schema, got, tt.want)
+		}
+	}
+
+	// Combinators that would need intersecting, select with a discriminator, set if/then/else or
+	// have several branches are kept
+	for _, schema := range []string{"ConflictingTypes", "ConflictingObjects", "Discriminated", "TwoBranches",
+		"ConditionalBranch", "SplitConditional"} {
+		got := convertComponentDraft7(t, parser, schema, ConvertOptions{SimplifyCombinators: true, DiscriminatorIfThen: true})
+		want := convertComponentDraft7(t, parser, schema, ConvertOptions{DiscriminatorIfThen: true})
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s was simplified: got %#v, want %#v", schema, got, want)
+		}
+	}
+
+	// Off by default
+	if got := convertComponentDraft7(t, parser, "OneOf", ConvertOptions{}); got["oneOf"] == nil {
+		t.Errorf("expected the oneOf to be kept without SimplifyCombinators, got %#v", got)
+	}
+}
+
+func convertComponentDraft7(t *testing.T, parser *Parser, name string, options ConvertOptions) map[string]interface{} {
+	t.Helper()
+	result, err := NewConverterWithOptions(parser, options).applySchema(parser.GetDocument().Components.Schemas[name].Value)
+	if err != nil {
+		t.Fatalf("applySchema(%s) failed: %v", name, err)
+	}
+	draft7, err := schemaToDraft7Map(result)
+	if err != nil {
+		t.Fatalf("schemaToDraft7Map(%s) failed: %v", name, err)
+	}
+	return draft7
+}
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// serverURLTemplate returns the spec's first server URL as declared, or "" when none is declared
+func (c *Converter) serverURLTemplate() string {
+	if servers := c.parser.GetDocument().Servers; len(servers) > 0 && servers[0] != nil {
+		return servers[0].URL
+	}
+	return ""
+}
+
+// serverVariables lists the variables of the spec's first server, sorted by name, so the generated
+// code can document the alternatives to the defaults substituted in the server URL. A default that
+// is not one of the variable's enum values, or an enum without a default, is reported as a warning.
+func (c *Converter) serverVariables() []ServerVariable {
+	servers := c.parser.GetDocument().Servers
+	if len(servers) == 0 || servers[0] == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(servers[0].Variables))
+	for name := range servers[0].Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var variables []ServerVariable
+	for _, name := range names {
+		variable := servers[0].Variables[name]
+		if variable == nil {
+			continue
+		}
+		switch {
+		case variable.Default == "" && len(variable.Enum) > 0:
+			c.warnf("server variable %s has no default, using its first enum value %q", name, variable.Enum[0])
+		case variable.Default != "" && len(variable.Enum) > 0 && !contains(variable.Enum, variable.Default):
+			c.warnf("server variable %s: default %q is not one of its enum values %s", name, variable.Default, strings.Join(variable.Enum, ", "))
+		}
+		variables = append(variables, ServerVariable{
+			Name:        name,
+			Default:     serverVariableDefault(variable),
+			Enum:        variable.Enum,
+			Description: strings.TrimSpace(variable.Description),
+		})
+	}
+	return variables
+}
+
+// serverVariableDefault returns the value a server variable takes by default: its declared default,
+// or its first enum value when it has none
+func serverVariableDefault(variable *openapi3.ServerVariable) string {
+	if variable == nil {
+		return ""
+	}
+	if variable.Default == "" && len(variable.Enum) > 0 {
+		return variable.Enum[0]
+	}
+	return variable.Default
+}
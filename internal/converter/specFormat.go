@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFormat is the serialization format of an OpenAPI document
+type SpecFormat int
+
+const (
+	// SpecFormatAuto detects the format from the content: JSON when it starts with {, YAML otherwise
+	SpecFormatAuto SpecFormat = iota
+	// SpecFormatJSON reads the document as JSON only
+	SpecFormatJSON
+	// SpecFormatYAML reads the document as YAML, of which JSON is a subset
+	SpecFormatYAML
+)
+
+// ParseSpecFormat parses the auto, json and yaml format names
+func ParseSpecFormat(name string) (SpecFormat, error) {
+	switch name {
+	case "auto", "":
+		return SpecFormatAuto, nil
+	case "json":
+		return SpecFormatJSON, nil
+	case "yaml", "yml":
+		return SpecFormatYAML, nil
+	}
+	return SpecFormatAuto, fmt.Errorf("unknown spec format %q: use auto, json or yaml", name)
+}
+
+// detectSpecFormat sniffs the format of a document: JSON when its first character other than
+// whitespace or a byte order mark is {, YAML otherwise. File extensions are not consulted, so
+// specs saved as .txt or read from stdin are detected the same way.
+func detectSpecFormat(data []byte) SpecFormat {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return SpecFormatJSON
+	}
+	return SpecFormatYAML
+}
+
+// checkSpecSyntax reports syntax errors of a document in the given format, or in the detected one
+// for SpecFormatAuto, before it is loaded: the loader tries JSON then YAML and reports both errors
+// otherwise, which buries the relevant one. A detected JSON document that turns out to be a YAML
+// flow mapping is accepted as YAML.
+func checkSpecSyntax(data []byte, format SpecFormat) error {
+	detected := format
+	if format == SpecFormatAuto {
+		detected = detectSpecFormat(data)
+	}
+	switch detected {
+	case SpecFormatJSON:
+		err := checkJSONSyntax(data)
+		if err != nil && format == SpecFormatAuto && checkYAMLSyntax(data) == nil {
+			return nil
+		}
+		return err
+	default:
+		return checkYAMLSyntax(data)
+	}
+}
+
+// checkJSONSyntax reports where a JSON document is malformed
+func checkJSONSyntax(data []byte) error {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, column := lineAndColumn(data, syntaxErr.Offset)
+		return fmt.Errorf("invalid JSON at line %d, column %d: %w", line, column, err)
+	}
+	return fmt.Errorf("invalid JSON: %w", err)
+}
+
+// checkYAMLSyntax reports where a YAML document is malformed
+func checkYAMLSyntax(data []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return nil
+}
+
+// lineAndColumn returns the 1-based line and column of the last byte read when a JSON decoder
+// fails after reading offset bytes of data
+func lineAndColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	specFormatJSON = `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1.0"},
+ "paths": {"/pets": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}}}`
+	specFormatYAML = `openapi: 3.0.3
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200': {description: OK}
+`
+)
+
+func TestParseFile_SpecFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  SpecFormat
+		wantErr string
+	}{
+		{"JSON in a .yaml file", "spec.yaml", specFormatJSON, SpecFormatAuto, ""},
+		{"YAML in a .json file", "spec.json", specFormatYAML, SpecFormatAuto, ""},
+		{"YAML in a .txt file", "spec.txt", specFormatYAML, SpecFormatAuto, ""},
+		{"JSON read as YAML", "spec.yaml", specFormatJSON, SpecFormatYAML, ""},
+		{"JSON hint", "spec", specFormatJSON, SpecFormatJSON, ""},
+		{"YAML with a JSON hint", "spec.json", specFormatYAML, SpecFormatJSON, "invalid JSON at line 1, column 1"},
+		{"malformed JSON", "spec.yaml", `{"openapi": "3.0.3",` + "\n" + `  "info": {"title": "Pets"]}`, SpecFormatAuto, "invalid JSON at line 2, column 27"},
+		{"malformed YAML", "spec.json", "openapi: 3.0.3\ninfo:\n  title: [Pets\n", SpecFormatAuto, "invalid YAML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			p := NewParser(false)
+			p.Format = tt.format
+			err := p.ParseFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseFile() error = %v, want %q", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "yaml error") {
+					t.Errorf("expected only the error of one format, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+			if p.GetDocument().Paths.Find("/pets") == nil {
+				t.Error("expected the /pets path to be parsed")
+			}
+		})
+	}
+}
+
+func TestParse_YAMLFlowMapping(t *testing.T) {
+	// A YAML flow mapping starts like JSON but is not JSON; detection falls back to YAML
+	spec := `{openapi: 3.0.3, info: {title: Pets, version: "1.0"}, paths: {}}`
+	if err := NewParser(false).Parse([]byte(spec)); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+}
+
+func TestParseSpecFormat(t *testing.T) {
+	tests := map[string]SpecFormat{"": SpecFormatAuto, "auto": SpecFormatAuto, "json": SpecFormatJSON, "yaml": SpecFormatYAML, "yml": SpecFormatYAML}
+	for name, want := range tests {
+		got, err := ParseSpecFormat(name)
+		if err != nil || got != want {
+			t.Errorf("ParseSpecFormat(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseSpecFormat("toml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
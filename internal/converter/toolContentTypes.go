@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// consumedContentTypes returns the sorted content types of an operation's request body
+func consumedContentTypes(operation *openapi3.Operation) []string {
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return nil
+	}
+	return sortedContentTypes(operation.RequestBody.Value.Content)
+}
+
+// producedContentTypes returns the sorted content types of a tool's response templates, each once.
+// Responses without a body have no content type and are left out.
+func producedContentTypes(responses []ResponseTemplate) []string {
+	var types []string
+	for _, response := range responses {
+		if response.ContentType != "" && !contains(types, response.ContentType) {
+			types = append(types, response.ContentType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// appendContentTypes adds a "Consumes: ...; Produces: ..." line to a tool description, leaving out
+// the side without content types
+func appendContentTypes(description string, consumes, produces []string) string {
+	var parts []string
+	if len(consumes) > 0 {
+		parts = append(parts, "Consumes: "+strings.Join(consumes, ", "))
+	}
+	if len(produces) > 0 {
+		parts = append(parts, "Produces: "+strings.Join(produces, ", "))
+	}
+	if len(parts) == 0 {
+		return description
+	}
+	if description == "" {
+		return strings.Join(parts, "; ")
+	}
+	return description + "\n\n" + strings.Join(parts, "; ")
+}
@@ -0,0 +1,106 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+const toolContentTypesSpec = `openapi: 3.0.3
+info: {title: Formats, version: "1.0"}
+paths:
+  /reports:
+    post:
+      operationId: createReport
+      description: Create a report
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object}
+          application/xml:
+            schema: {type: object}
+      responses:
+        '200':
+          description: OK
+          content:
+            text/csv:
+              schema: {type: string}
+            application/json:
+              schema: {type: object}
+        '400':
+          description: Bad request
+          content:
+            application/json:
+              schema: {type: object}
+  /reports/{id}:
+    delete:
+      operationId: deleteReport
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '204': {description: Deleted}
+`
+
+func TestConvert_ToolContentTypes(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(toolContentTypesSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		options     ConvertOptions
+		description string
+	}{
+		{"metadata only by default", ConvertOptions{}, "Create a report"},
+		{"described", ConvertOptions{DescribeContentTypes: true},
+			"Create a report\n\nConsumes: application/json, application/xml; Produces: application/json, text/csv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewConverterWithOptions(parser, tt.options).Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			tools := map[string]Tool{}
+			for _, tool := range config.Tools {
+				tools[tool.Name] = tool
+			}
+
+			create := tools["createReport"]
+			if create.Description != tt.description {
+				t.Errorf("description = %q, want %q", create.Description, tt.description)
+			}
+			if want := []string{"application/json", "application/xml"}; !reflect.DeepEqual(create.Consumes, want) {
+				t.Errorf("consumes = %v, want %v", create.Consumes, want)
+			}
+			if want := []string{"application/json", "text/csv"}; !reflect.DeepEqual(create.Produces, want) {
+				t.Errorf("produces = %v, want %v", create.Produces, want)
+			}
+
+			remove := tools["deleteReport"]
+			if remove.Consumes != nil || remove.Produces != nil {
+				t.Errorf("expected no content types for deleteReport, got %v and %v", remove.Consumes, remove.Produces)
+			}
+			if remove.Description != "" {
+				t.Errorf("expected deleteReport to stay undescribed, got %q", remove.Description)
+			}
+		})
+	}
+}
+
+func Test_appendContentTypes(t *testing.T) {
+	tests := []struct {
+		description        string
+		consumes, produces []string
+		want               string
+	}{
+		{"Get a pet", nil, []string{"application/json"}, "Get a pet\n\nProduces: application/json"},
+		{"", []string{"text/plain"}, nil, "Consumes: text/plain"},
+		{"Ping", nil, nil, "Ping"},
+	}
+	for _, tt := range tests {
+		if got := appendContentTypes(tt.description, tt.consumes, tt.produces); got != tt.want {
+			t.Errorf("appendContentTypes(%q, %v, %v) = %q, want %q", tt.description, tt.consumes, tt.produces, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/oasdiff/yaml"
+)
+
+// validToolName matches the tool names accepted by MCP clients
+var validToolName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// RegisteredName returns the name the tool is registered under: the override name when one
+// was applied, otherwise the operationId with its first letter capitalized.
+func (t Tool) RegisteredName() string {
+	if t.DisplayName != "" {
+		return t.DisplayName
+	}
+	if t.Name == "" {
+		return t.Name
+	}
+	runes := []rune(t.Name)
+	runes[0] = []rune(strings.ToUpper(string(runes[0])))[0]
+	return string(runes)
+}
+
+// applyToolOverrides applies overrides keyed by operationId to the tools in config.
+// An override name replaces the registered name and an override description replaces
+// the spec-derived description; empty fields leave the spec value in place. Override
+// names must be valid MCP tool names and every registered name must stay unique.
+// Overrides for unknown operations are reported as warnings.
+func applyToolOverrides(config *MCPConfig, overrides map[string]ToolOverride) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	matched := make(map[string]bool, len(overrides))
+	for i := range config.Tools {
+		tool := &config.Tools[i]
+		override, ok := overrides[tool.Name]
+		if !ok {
+			continue
+		}
+		matched[tool.Name] = true
+
+		if override.Name != "" {
+			if !validToolName.MatchString(override.Name) {
+				return fmt.Errorf("invalid override name %q for operation %s: must be 1-64 letters, digits, '_' or '-'", override.Name, tool.Name)
+			}
+			tool.DisplayName = override.Name
+		}
+		if override.Description != "" {
+			tool.Description = override.Description
+		}
+	}
+
+	registered := make(map[string]string, len(config.Tools))
+	for _, tool := range config.Tools {
+		name := tool.RegisteredName()
+		if other, exists := registered[name]; exists {
+			return fmt.Errorf("duplicate tool name %q for operations %s and %s", name, other, tool.Name)
+		}
+		registered[name] = tool.Name
+	}
+
+	var unknown []string
+	for operationID := range overrides {
+		if !matched[operationID] {
+			unknown = append(unknown, fmt.Sprintf("tool override for unknown operation %s was ignored", operationID))
+		}
+	}
+	// Appended after the conversion warnings without reordering them
+	sort.Strings(unknown)
+	config.Warnings = append(config.Warnings, unknown...)
+
+	return nil
+}
+
+// LoadToolOverrides reads a JSON or YAML file mapping operationIds to tool overrides
+func LoadToolOverrides(path string) (map[string]ToolOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool overrides file: %w", err)
+	}
+
+	overrides := make(map[string]ToolOverride)
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse tool overrides file %s: %w", path, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,149 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func overrideTestConfig() *MCPConfig {
+	return &MCPConfig{
+		Tools: []Tool{
+			{Name: "getPet", Description: "Get a pet by ID"},
+			{Name: "listPets", Description: "List pets"},
+		},
+	}
+}
+
+func TestTool_RegisteredName(t *testing.T) {
+	if got := (Tool{Name: "getPet"}).RegisteredName(); got != "GetPet" {
+		t.Errorf("RegisteredName() = %q, want %q", got, "GetPet")
+	}
+	if got := (Tool{Name: "getPet", DisplayName: "fetch_pet"}).RegisteredName(); got != "fetch_pet" {
+		t.Errorf("RegisteredName() = %q, want %q", got, "fetch_pet")
+	}
+}
+
+func TestApplyToolOverrides(t *testing.T) {
+	config := overrideTestConfig()
+	err := applyToolOverrides(config, map[string]ToolOverride{
+		"getPet":    {Name: "fetch_pet", Description: "Look up a single pet"},
+		"listPets":  {Description: "Browse every pet"},
+		"deletePet": {Name: "remove_pet"},
+	})
+	if err != nil {
+		t.Fatalf("applyToolOverrides() error = %v", err)
+	}
+
+	getPet, listPets := config.Tools[0], config.Tools[1]
+	if getPet.Name != "getPet" || getPet.RegisteredName() != "fetch_pet" {
+		t.Errorf("getPet = (%q, %q), want operationId kept and name overridden", getPet.Name, getPet.RegisteredName())
+	}
+	if getPet.Description != "Look up a single pet" {
+		t.Errorf("getPet description = %q, want override", getPet.Description)
+	}
+	if listPets.RegisteredName() != "ListPets" {
+		t.Errorf("listPets registered name = %q, want spec-derived name", listPets.RegisteredName())
+	}
+	if listPets.Description != "Browse every pet" {
+		t.Errorf("listPets description = %q, want override", listPets.Description)
+	}
+	if len(config.Warnings) != 1 || !strings.Contains(config.Warnings[0], "deletePet") {
+		t.Errorf("expected a warning for the unknown operation, got %v", config.Warnings)
+	}
+}
+
+func TestApplyToolOverrides_KeepsWarningOrder(t *testing.T) {
+	config := overrideTestConfig()
+	config.Warnings = []string{"z: converted first", "a: converted second"}
+	err := applyToolOverrides(config, map[string]ToolOverride{"removePet": {}, "addPet": {}})
+	if err != nil {
+		t.Fatalf("applyToolOverrides() error = %v", err)
+	}
+	want := []string{
+		"z: converted first",
+		"a: converted second",
+		"tool override for unknown operation addPet was ignored",
+		"tool override for unknown operation removePet was ignored",
+	}
+	if !reflect.DeepEqual(config.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", config.Warnings, want)
+	}
+}
+
+func TestApplyToolOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]ToolOverride
+		wantErr   string
+	}{
+		{
+			name:      "invalid characters",
+			overrides: map[string]ToolOverride{"getPet": {Name: "fetch pet"}},
+			wantErr:   "invalid override name",
+		},
+		{
+			name:      "too long",
+			overrides: map[string]ToolOverride{"getPet": {Name: strings.Repeat("a", 65)}},
+			wantErr:   "invalid override name",
+		},
+		{
+			name: "duplicate overrides",
+			overrides: map[string]ToolOverride{
+				"getPet":   {Name: "pets"},
+				"listPets": {Name: "pets"},
+			},
+			wantErr: "duplicate tool name",
+		},
+		{
+			name:      "clashes with spec-derived name",
+			overrides: map[string]ToolOverride{"getPet": {Name: "ListPets"}},
+			wantErr:   "duplicate tool name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyToolOverrides(overrideTestConfig(), tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("applyToolOverrides() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadToolOverrides(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "overrides.yaml")
+	content := "getPet:\n  name: fetch_pet\n  description: Look up a single pet\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write overrides: %v", err)
+	}
+
+	overrides, err := LoadToolOverrides(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadToolOverrides() error = %v", err)
+	}
+	want := ToolOverride{Name: "fetch_pet", Description: "Look up a single pet"}
+	if overrides["getPet"] != want {
+		t.Errorf("overrides[getPet] = %+v, want %+v", overrides["getPet"], want)
+	}
+
+	jsonPath := filepath.Join(dir, "overrides.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"listPets": {"name": "browse_pets"}}`), 0644); err != nil {
+		t.Fatalf("failed to write overrides: %v", err)
+	}
+	overrides, err = LoadToolOverrides(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadToolOverrides() error = %v", err)
+	}
+	if overrides["listPets"].Name != "browse_pets" {
+		t.Errorf("overrides[listPets].Name = %q, want %q", overrides["listPets"].Name, "browse_pets")
+	}
+
+	if _, err := LoadToolOverrides(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected error for a missing file, got nil")
+	}
+}
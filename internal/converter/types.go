@@ -2,14 +2,35 @@ package converter
 
 // MCPConfig represents the top-level MCP server configuration
 type MCPConfig struct {
-	Server ServerConfig
-	Tools  []Tool
+	Server   ServerConfig
+	Tools    []Tool
+	Warnings []string // Non-fatal problems found in the spec during conversion
+	// Unsupported lists spec features the converter could not represent and left out
+	Unsupported []UnsupportedFeature
 }
 
 // ServerConfig represents the MCP server configuration
 type ServerConfig struct {
 	Config          map[string]interface{}
 	SecuritySchemes []SecurityScheme
+	// URL is the spec's first server URL with server variables set to their defaults; empty when none is declared
+	URL string
+	// Version is the API version from the spec's info.version
+	Version string
+	// URLTemplate is the spec's first server URL as declared, with its {variables}
+	URLTemplate string
+	// Variables are the variables of URLTemplate, sorted by name
+	Variables []ServerVariable
+}
+
+// ServerVariable is a variable of a templated server URL, e.g. region in https://{region}.api.example.com
+type ServerVariable struct {
+	Name string
+	// Default is the value substituted in ServerConfig.URL
+	Default string
+	// Enum lists the allowed values; empty when any value is allowed
+	Enum        []string
+	Description string
 }
 
 // SecurityScheme defines a security scheme that can be used by the tools.
@@ -30,6 +51,28 @@ type Tool struct {
 	RequestTemplate RequestTemplate
 	Responses       []ResponseTemplate
 	RawInputSchema  string
+	// Method and Path identify the operation in the spec (e.g. GET /pets/{id})
+	Method string
+	Path   string
+	// DisplayName is the name the tool is registered under when set by a ToolOverride.
+	// Name keeps the operationId so generated file and identifier names stay stable.
+	DisplayName string
+	// MaxResponseBytes overrides the generator's response size limit (x-mcp-max-response-bytes); 0 means unset
+	MaxResponseBytes int
+	// RateLimit is the limit documented by the operation's x-ratelimit extensions, if any
+	RateLimit *RateLimit
+	// Tags are the operation's tags, in spec order; the first one is its primary tag
+	Tags []string
+	// Consumes and Produces are the sorted content types of the tool's request body and responses
+	Consumes []string
+	Produces []string
+}
+
+// RateLimit is the number of requests an operation accepts per period
+type RateLimit struct {
+	Limit int
+	// Period is second, minute, hour or day; empty when the API does not document one
+	Period string
 }
 
 // RequestTemplate represents the MCP request template
@@ -61,11 +104,216 @@ type ResponseTemplate struct {
 	StatusCode  int
 	ContentType string
 	Suffix       string 
+	// AlternateContentTypes lists the status code's other content types whose schema is structurally
+	// equal to ContentType's; they share this template instead of getting near-identical copies
+	AlternateContentTypes []string
+	// ProblemDetails marks RFC 7807 error responses (application/problem+json or a matching schema)
+	ProblemDetails bool
+	// ErrorFields lists the top-level fields documented for an error (4xx, 5xx or default) response body
+	ErrorFields []string
+	// Schema is the converted body schema of a JSON 2xx response, kept with ConvertOptions.ResponseSchemas
+	Schema *Schema
+	// SchemaName is the components/schemas entry Schema was referenced from, if any
+	SchemaName string
+	// Example is a body the API could send for this response, used by the generated mock server:
+	// the first documented example, or one synthesized from the schema for JSON; nil when neither exists
+	Example interface{}
 }
 
+// DescriptionStrategy controls how a property's own description is combined with the
+// description of the component schema it references.
+type DescriptionStrategy int
+
+// DeprecatedMode selects how operations marked `deprecated: true` are turned into tools
+type DeprecatedMode int
+
+const (
+	// DeprecatedMark prefixes the tool description with [DEPRECATED] so models prefer alternatives,
+	// and ends it with the date the operation will be removed after when it sets x-sunset.
+	DeprecatedMark DeprecatedMode = iota
+	// DeprecatedInclude generates deprecated operations like any other.
+	DeprecatedInclude
+	// DeprecatedExclude skips deprecated operations.
+	DeprecatedExclude
+)
+
+// EmptySchemaMode selects what happens to object properties whose schema constrains nothing
+type EmptySchemaMode int
+
+const (
+	// EmptySchemaKeep leaves empty properties as unconstrained `{}` schemas.
+	EmptySchemaKeep EmptySchemaMode = iota
+	// EmptySchemaDrop removes empty properties (and their required entries) from the object.
+	EmptySchemaDrop
+	// EmptySchemaAnnotate keeps empty properties but describes them ("any value" / "always null").
+	EmptySchemaAnnotate
+)
+
+// AdditionalPropertiesMode selects what input schemas say about properties an object does not declare
+// when the spec leaves additionalProperties unset
+type AdditionalPropertiesMode int
+
+const (
+	// AdditionalPropertiesPermissive leaves additionalProperties unset, so any extra property is allowed.
+	AdditionalPropertiesPermissive AdditionalPropertiesMode = iota
+	// AdditionalPropertiesStrict emits `additionalProperties: false` on request objects that declare
+	// properties, as most APIs reject unknown fields.
+	AdditionalPropertiesStrict
+)
+
+// GetRequestBodyMode selects what happens to request bodies documented on GET and HEAD operations,
+// which HTTP gives no defined semantics and many servers and proxies ignore or reject
+type GetRequestBodyMode int
+
+const (
+	// GetRequestBodyHonor converts the body like on any other method and the handler sends it.
+	GetRequestBodyHonor GetRequestBodyMode = iota
+	// GetRequestBodyDrop leaves the body out of the tool's input and request, with a warning.
+	// DELETE keeps its body, as APIs use it for bulk deletes.
+	GetRequestBodyDrop
+)
+
+// UnknownFormatMode selects what happens to schema formats that are neither JSON Schema nor
+// OpenAPI formats, such as "decimal" or "money", which validators and clients cannot interpret
+type UnknownFormatMode int
+
+const (
+	// UnknownFormatPassthrough copies unknown formats into the input schemas as they are.
+	UnknownFormatPassthrough UnknownFormatMode = iota
+	// UnknownFormatWarn copies unknown formats too, reporting each occurrence as a warning.
+	UnknownFormatWarn
+	// UnknownFormatMap replaces formats listed in ConvertOptions.FormatMappings, and reports
+	// unknown formats missing from it as warnings.
+	UnknownFormatMap
+)
+
+// ResponseCodeRange is an inclusive range of HTTP status codes; Min equals Max for a single code
+type ResponseCodeRange struct {
+	Min, Max int
+}
+
+// ResponseCodeSet selects which documented responses get response templates.
+// The zero value selects every response.
+type ResponseCodeSet struct {
+	Ranges []ResponseCodeRange
+	// Default selects the spec's `default` response
+	Default bool
+}
+
+// SchemaDraft selects which JSON Schema draft's keywords may appear in generated input schemas
+type SchemaDraft int
+
+const (
+	// SchemaDraft7 emits only keywords understood by Draft 7 validators.
+	SchemaDraft7 SchemaDraft = iota
+	// SchemaDraft202012 also emits keywords added in Draft 2019-09 and 2020-12
+	// (minContains, maxContains, ...) and keeps dependentRequired and dependentSchemas
+	// instead of folding them into Draft 7 dependencies.
+	SchemaDraft202012
+)
+
+const (
+	// DescriptionPreferSite uses the property-site description and falls back to the
+	// referenced schema's description when the property has none.
+	DescriptionPreferSite DescriptionStrategy = iota
+	// DescriptionConcatenate joins both descriptions, property-site first.
+	DescriptionConcatenate
+)
+
 // ConvertOptions represents options for the conversion process
 type ConvertOptions struct {
 	ServerConfig map[string]interface{}
+	// TranslateFormatsToPatterns adds an equivalent `pattern` for well-known string formats
+	// (uuid, email, date, date-time, ...) so validators that ignore `format` still enforce them.
+	TranslateFormatsToPatterns bool
+	// DiscriminatorIfThen emits discriminated oneOf unions as an if/then/else chain keyed on the
+	// discriminator property's const value, so validators select exactly one branch deterministically.
+	DiscriminatorIfThen bool
+	// DescriptionStrategy selects how $ref descriptions are inlined onto referencing properties
+	DescriptionStrategy DescriptionStrategy
+	// MergeAllOf flattens allOf compositions whose branches are all objects into a single object
+	// schema whose required list unions the branches', e.g. a base object plus {required: [name]}.
+	// Conflicting property definitions keep the first one and are reported as warnings.
+	MergeAllOf bool
+	// SimplifyCombinators inlines oneOf, anyOf and allOf compositions that have a single branch
+	// into the schema holding them, when the result is equivalent (see simplifyCombinators)
+	SimplifyCombinators bool
+	// ToolOverrides replaces the registered name and description of tools, keyed by operationId,
+	// and can pin the request content type of tools whose body documents several.
+	// Non-empty override values take precedence over names and descriptions derived from the spec.
+	ToolOverrides map[string]ToolOverride
+	// Glossary describes parameters and properties the spec leaves undescribed, keyed by field
+	// name or by dotted path from the tool input (see applyGlossary); spec descriptions win
+	Glossary map[string]string
+	// SchemaDraft gates keywords that only newer JSON Schema drafts understand; Draft 7 by default
+	SchemaDraft SchemaDraft
+	// OmitSchemaDescriptions and OmitSchemaExamples drop descriptions / examples from the generated
+	// input schemas. Descriptions guide the LLM but can dominate the token cost of large specs;
+	// response templates and the tool manifest keep them either way.
+	OmitSchemaDescriptions bool
+	OmitSchemaExamples     bool
+	// InputSchemaExamples adds an examples array to the root of each input schema, built from the
+	// request body examples with the body wrapped in its "body" property. Ignored when
+	// OmitSchemaExamples is set.
+	InputSchemaExamples bool
+	// DescribeConstraints appends each property's bounds, pattern, enum and default to its
+	// description in the generated input schemas, e.g. "Page size (1–100, default 20)", for
+	// models that overlook the validation keywords. Ignored when OmitSchemaDescriptions is set.
+	DescribeConstraints bool
+	// DescribeContentTypes appends the content types a tool consumes and produces to its
+	// description, e.g. "Consumes: application/json; Produces: application/json, text/csv"
+	DescribeContentTypes bool
+	// DeprecatedOperations controls deprecated operations; they are marked by default
+	DeprecatedOperations DeprecatedMode
+	// EmptySchemas handles object properties that convert to an empty `{}` or null-only schema,
+	// typically from `{}`, `nullable: true` without a type or a $ref that could not be resolved
+	EmptySchemas EmptySchemaMode
+	// AdditionalProperties closes request objects that leave additionalProperties unset when it is
+	// AdditionalPropertiesStrict; they stay open by default (see closeObjects for the exceptions)
+	AdditionalProperties AdditionalPropertiesMode
+	// GetRequestBodies handles request bodies on GET and HEAD operations; they are honored by default
+	GetRequestBodies GetRequestBodyMode
+	// UnknownFormats handles nonstandard schema formats; they are passed through by default
+	UnknownFormats UnknownFormatMode
+	// FormatMappings replaces schema formats with others when UnknownFormats is UnknownFormatMap,
+	// e.g. "decimal" with "double"; mapping to "" drops the format
+	FormatMappings map[string]string
+	// SchemaIDPrefix enables an `$id` on each tool's input schema: the prefix followed by the
+	// operationId, e.g. "https://example.com/schemas/" + "getPet". Off by default because some
+	// validators try to resolve $id URIs.
+	SchemaIDPrefix string
+	// ResponseCodes limits the responses documented by response templates; all are documented by default
+	ResponseCodes ResponseCodeSet
+	// ExcludeResponseContentTypes lists media types response templates leave out, e.g. text/html
+	// error pages; text/* excludes every text subtype. Nothing is excluded by default.
+	ExcludeResponseContentTypes []string
+	// SynthesizeResponseExamples adds an example built from the schema to JSON response templates
+	// whose media type documents no examples of its own
+	SynthesizeResponseExamples bool
+	// ResponseSchemas keeps the converted body schema of JSON success responses in
+	// ResponseTemplate.Schema, which the generator builds typed responses from
+	ResponseSchemas bool
+	// NormalizeEnumNames derives the names of the API client's enum constants from cleaned string
+	// enum values (see NormalizeEnumNames): whitespace trimmed and casing made consistent. The values
+	// in the schemas stay verbatim. Applied by the generator, not the converter.
+	NormalizeEnumNames bool
+	// SpecFormat is the format the spec is read in; it is detected from the content by default.
+	// Read by the generator, not the converter.
+	SpecFormat SpecFormat
+	// OverlayPath is an OpenAPI Overlay document applied to the spec before it is parsed, so specs
+	// that cannot be edited directly can still be patched. Read by the generator, not the converter.
+	OverlayPath string
+	// AllowRemoteRefs makes the generator's parser fetch $refs to http(s) URLs instead of failing
+	// on them (see Parser.AllowRemoteRefs). Read by the generator, not the converter.
+	AllowRemoteRefs bool
+}
+
+// ToolOverride holds a friendlier tool name and/or description for an operation
+type ToolOverride struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// RequestContentType limits the tool's request body to one of its documented content types
+	RequestContentType string `json:"requestContentType,omitempty"`
 }
 
 // ToolTemplate represents a template for applying to all tools
@@ -88,8 +336,22 @@ type Arg struct {
 	Required    bool    `json:"required"`
 	Deprecated  bool    `json:"deprecated,omitempty"`
 	Schema      *Schema `json:"schema"`
+	// AllowReserved sends a query parameter's reserved characters (:/?#[]@!$&'()*+,;=) unencoded
+	AllowReserved bool `json:"allowReserved,omitempty"`
+	// AllowEmptyValue lets a query parameter be sent with an empty value
+	AllowEmptyValue bool `json:"allowEmptyValue,omitempty"`
 	// For request bodies with multiple content types
 	ContentTypes map[string]*Schema `json:"contentTypes,omitempty"`
+	// Examples holds the media-type level request body examples (example / examples)
+	Examples []RequestExample `json:"examples,omitempty"`
+}
+
+// RequestExample is a request body example taken from a media type object
+type RequestExample struct {
+	Name        string      `json:"name,omitempty"` // Empty for the single `example` field
+	Summary     string      `json:"summary,omitempty"`
+	ContentType string      `json:"contentType"`
+	Value       interface{} `json:"value"`
 }
 
 // Schema represents the structure and validation rules for data
@@ -99,18 +361,31 @@ type Schema struct {
 	AnyOf       []*Schema         `json:"anyOf,omitempty"`
 	AllOf       []*Schema         `json:"allOf,omitempty"`
 	Not         *Schema           `json:"not,omitempty"`
+	If          *Schema           `json:"if,omitempty"`
+	Then        *Schema           `json:"then,omitempty"`
+	Else        *Schema           `json:"else,omitempty"`
 	Title       string            `json:"title,omitempty"`
 	Description string            `json:"description,omitempty"`
 	Format      string            `json:"format,omitempty"`
 	Default     interface{}       `json:"default,omitempty"`
 	Example     interface{}       `json:"example,omitempty"`
+	Examples    []interface{}     `json:"examples,omitempty"` // Merged from example (3.0) and examples (3.1)
 	Enum        []interface{}     `json:"enum,omitempty"`
+	Const       interface{}       `json:"const,omitempty"`
 	ReadOnly    bool              `json:"readOnly,omitempty"`
 	WriteOnly   bool              `json:"writeOnly,omitempty"`
 	String      *StringValidation `json:"string,omitempty"`
 	Number      *NumberValidation `json:"number,omitempty"`
 	Array       *ArrayValidation  `json:"array,omitempty"`
 	Object      *ObjectValidation `json:"object,omitempty"`
+	// Discriminator is only set when ConvertOptions.DiscriminatorIfThen is enabled
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+}
+
+// Discriminator identifies the property that selects a oneOf branch in a tagged union
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
 // StringValidation contains validation rules specific to string types
@@ -135,6 +410,10 @@ type ArrayValidation struct {
 	MinItems    uint64  `json:"minItems,omitempty"`
 	MaxItems    *uint64 `json:"maxItems,omitempty"`
 	UniqueItems bool    `json:"uniqueItems,omitempty"`
+	Contains    *Schema `json:"contains,omitempty"`
+	// MinContains and MaxContains are Draft 2019-09 keywords, only set for SchemaDraft202012
+	MinContains *uint64 `json:"minContains,omitempty"`
+	MaxContains *uint64 `json:"maxContains,omitempty"`
 }
 
 // ObjectValidation contains validation rules specific to object types
@@ -145,4 +424,11 @@ type ObjectValidation struct {
 	Required                     []string           `json:"required,omitempty"`
 	MinProperties                uint64             `json:"minProperties,omitempty"`
 	MaxProperties                *uint64            `json:"maxProperties,omitempty"`
+	PropertyNames                *Schema            `json:"propertyNames,omitempty"` // Schema every property name must satisfy
+	// DependentRequired and DependentSchemas come from the Draft 2019-09 keywords of the same name.
+	// Draft 7 has no such keywords, so they are emitted as the equivalent `dependencies` unless
+	// DependentKeywords is set (ConvertOptions.SchemaDraft is SchemaDraft202012).
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+	DependentSchemas  map[string]*Schema  `json:"dependentSchemas,omitempty"`
+	DependentKeywords bool                `json:"-"`
 }
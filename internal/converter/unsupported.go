@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// UnsupportedFeature is a spec feature the converter cannot represent and left out of the output
+type UnsupportedFeature struct {
+	Location string // e.g. "GET /pets parameter filter"
+	Feature  string
+}
+
+// String renders the feature as "location: feature"
+func (f UnsupportedFeature) String() string {
+	if f.Location == "" {
+		return f.Feature
+	}
+	return f.Location + ": " + f.Feature
+}
+
+// UnsupportedFeaturesError reports every unsupported feature found in a spec, for strict mode
+type UnsupportedFeaturesError struct {
+	Features []UnsupportedFeature
+}
+
+// Error lists all unsupported features, one per line
+func (e *UnsupportedFeaturesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "the spec uses %d unsupported feature(s); the generated server would be incomplete:", len(e.Features))
+	for _, feature := range e.Features {
+		b.WriteString("\n  - " + feature.String())
+	}
+	return b.String()
+}
+
+// unsupportedSchemaKeywords are JSON Schema keywords that kin-openapi keeps in Extensions and the
+// converter does not translate into input schemas
+var unsupportedSchemaKeywords = []string{
+	"$defs",
+	"$dynamicAnchor",
+	"$dynamicRef",
+	"contentEncoding",
+	"contentMediaType",
+	"contentSchema",
+	"patternProperties",
+	"prefixItems",
+	"unevaluatedItems",
+	"unevaluatedProperties",
+}
+
+// unsupportedf records a spec feature that is dropped from the output at the current location
+func (c *Converter) unsupportedf(format string, args ...interface{}) {
+	feature := UnsupportedFeature{Location: c.location, Feature: fmt.Sprintf(format, args...)}
+	for _, existing := range c.unsupported {
+		if existing == feature {
+			return
+		}
+	}
+	c.unsupported = append(c.unsupported, feature)
+}
+
+// at narrows the location reported by unsupportedf (e.g. "GET /pets" to "GET /pets parameter id")
+// and returns a function restoring the previous one
+func (c *Converter) at(detail string) func() {
+	previous := c.location
+	c.location = strings.TrimSpace(previous + " " + detail)
+	return func() { c.location = previous }
+}
+
+// checkUnsupportedKeywords records schema keywords that applySchema drops
+func (c *Converter) checkUnsupportedKeywords(schema *openapi3.Schema) {
+	for _, keyword := range unsupportedSchemaKeywords {
+		if _, ok := schema.Extensions[keyword]; ok {
+			c.unsupportedf("schema keyword %s", keyword)
+		}
+	}
+}
+
+// checkUnsupportedOperation records operation-level features that have no tool representation
+func (c *Converter) checkUnsupportedOperation(pathItem *openapi3.PathItem, operation *openapi3.Operation) {
+	if len(pathItem.Parameters) > 0 {
+		c.unsupportedf("path-level parameters")
+	}
+	if len(pathItem.Servers) > 0 {
+		c.unsupportedf("path-level servers")
+	}
+	if operation.Servers != nil && len(*operation.Servers) > 0 {
+		c.unsupportedf("operation-level servers")
+	}
+	if len(operation.Callbacks) > 0 {
+		names := make([]string, 0, len(operation.Callbacks))
+		for name := range operation.Callbacks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		c.unsupportedf("callbacks (%s)", strings.Join(names, ", "))
+	}
+}
+
+// checkUnsupportedParameterStyle records serialization styles the generated runtime cannot encode.
+// Only the defaults are supported: simple for path and header parameters, exploded form for query
+// and cookie parameters.
+func (c *Converter) checkUnsupportedParameterStyle(param *openapi3.Parameter) {
+	defaultStyle := openapi3.SerializationSimple
+	if param.In == openapi3.ParameterInQuery || param.In == openapi3.ParameterInCookie {
+		defaultStyle = openapi3.SerializationForm
+	}
+	if param.Style != "" && param.Style != defaultStyle {
+		c.unsupportedf("%s style", param.Style)
+	}
+	if param.Explode != nil && *param.Explode != (defaultStyle == openapi3.SerializationForm) {
+		c.unsupportedf("explode: %t", *param.Explode)
+	}
+}
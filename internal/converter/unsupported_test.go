@@ -0,0 +1,124 @@
+package converter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const unsupportedSpec = `openapi: 3.0.3
+info: {title: Unsupported, version: "1.0"}
+components:
+  securitySchemes:
+    queryKey: {type: apiKey, in: query, name: key}
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getPet
+      security: [{queryKey: []}]
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema: {type: object}
+        - name: where
+          in: query
+          content:
+            application/json:
+              schema: {type: object}
+      responses:
+        '200': {description: OK}
+    post:
+      operationId: updatePet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              patternProperties:
+                "^x-": {type: string}
+          text/plain: {}
+      callbacks:
+        onUpdate:
+          '{$request.body#/url}':
+            post:
+              responses:
+                '200': {description: OK}
+      responses:
+        '200': {description: OK}
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: tags, in: query, explode: false, schema: {type: array, items: {type: string}}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200': {description: OK}
+`
+
+func TestConvert_Unsupported(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(unsupportedSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var got []string
+	for _, feature := range config.Unsupported {
+		got = append(got, feature.String())
+	}
+	want := []string{
+		"GET /pets parameter tags: explode: false",
+		"GET /pets/{id} parameter filter: deepObject style",
+		"GET /pets/{id} parameter where: content instead of schema",
+		"GET /pets/{id}: path-level parameters",
+		"GET /pets/{id}: security scheme queryKey (apiKey in query)",
+		"POST /pets/{id} request body application/json: schema keyword patternProperties",
+		"POST /pets/{id}: callbacks (onUpdate)",
+		"POST /pets/{id}: path-level parameters",
+		"POST /pets/{id}: request body content type text/plain without a schema",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unsupported =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestConvert_NoUnsupported(t *testing.T) {
+	parser := NewParser(false)
+	if err := parser.Parse([]byte(deprecatedSpec)); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	config, err := NewConverter(parser).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(config.Unsupported) != 0 {
+		t.Errorf("expected no unsupported features, got %v", config.Unsupported)
+	}
+}
+
+func TestUnsupportedFeaturesError(t *testing.T) {
+	var err error = &UnsupportedFeaturesError{Features: []UnsupportedFeature{
+		{Location: "GET /pets", Feature: "callbacks (onEvent)"},
+		{Location: "POST /pets parameter q", Feature: "deepObject style"},
+	}}
+
+	var target *UnsupportedFeaturesError
+	if !errors.As(err, &target) || len(target.Features) != 2 {
+		t.Fatalf("errors.As failed for %v", err)
+	}
+	for _, want := range []string{
+		"2 unsupported feature(s)",
+		"\n  - GET /pets: callbacks (onEvent)",
+		"\n  - POST /pets parameter q: deepObject style",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err.Error(), want)
+		}
+	}
+}
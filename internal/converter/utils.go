@@ -3,6 +3,7 @@ package converter
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -41,31 +42,42 @@ func sortedContentTypes(content openapi3.Content) []string {
 	return types
 }
 
-// assignSuffixes adds a unique letter suffix (_A, _B, ..., _Z, _AA, _AB, ...) to each response template.
-func assignSuffixes(responses []ResponseTemplate) []ResponseTemplate {
-	for i := range responses {
-		responses[i].Suffix = toAlphaSuffix(i)
+// responseSuffix derives a response template's constant suffix from its status code and content
+// type (e.g. 200_application_json), so suffixes stay stable when other responses are added or removed.
+func responseSuffix(code, contentType string) string {
+	return identifierPart(code) + "_" + identifierPart(contentType)
+}
+
+// identifierPart lowercases s and replaces each run of characters that are not valid in a
+// Go identifier with a single underscore.
+func identifierPart(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
 	}
-	return responses
+	return strings.TrimSuffix(b.String(), "_")
 }
 
-// toAlphaSuffix converts an integer to a base-26 alphabetic suffix (A, B, ..., Z, AA, AB, ...).
-func toAlphaSuffix(n int) string {
-    var b strings.Builder
-    n++ // 1-based
-    for n > 0 {
-        n-- // 0-based for this digit
-        b.WriteByte(byte('A' + (n % 26)))
-        n /= 26
-    }
-    // The result is reversed, so reverse it
-    s := b.String()
-    // Reverse the string
-    runes := []rune(s)
-    for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
-        runes[i], runes[j] = runes[j], runes[i]
-    }
-    return string(runes)
+// dedupeSuffixes numbers suffixes that collide after sanitizing (_2, _3, ...) in template order.
+func dedupeSuffixes(responses []ResponseTemplate) []ResponseTemplate {
+	seen := make(map[string]int, len(responses))
+	for i := range responses {
+		suffix := responses[i].Suffix
+		seen[suffix]++
+		if n := seen[suffix]; n > 1 {
+			responses[i].Suffix = fmt.Sprintf("%s_%d", suffix, n)
+		}
+	}
+	return responses
 }
 
 
@@ -95,6 +107,51 @@ func formatForGoRawString(schema *openapi3.Schema, value interface{}) string {
     return str
 }
 
+// formatEnumValue renders an enum value for Markdown by its JSON type: strings in single quotes,
+// numbers, booleans and null bare, and arrays and objects as compact JSON
+func formatEnumValue(value interface{}) string {
+	str := fmt.Sprintf("%v", value)
+	if s, ok := value.(string); ok {
+		str = "'" + s + "'"
+	} else if bts, err := json.Marshal(normalizeEnumValue(value)); err == nil {
+		str = string(bts)
+	}
+	return strings.ReplaceAll(str, "`", "'")
+}
+
+// normalizeEnumValues returns enum values that encode to JSON. YAML decoders can produce maps with
+// non-string keys (e.g. `{1: one}`), which encoding/json rejects; their keys are converted to strings.
+func normalizeEnumValues(values []interface{}) []interface{} {
+	if values == nil {
+		return nil
+	}
+	normalized := make([]interface{}, len(values))
+	for i, value := range values {
+		normalized[i] = normalizeEnumValue(value)
+	}
+	return normalized
+}
+
+// normalizeEnumValue converts maps with non-string keys, recursively, into map[string]interface{}
+func normalizeEnumValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeEnumValue(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = normalizeEnumValue(item)
+		}
+		return m
+	case []interface{}:
+		return normalizeEnumValues(v)
+	}
+	return value
+}
 
 // getResponseDescription safely returns the response description.
 func getResponseDescription(responseRef *openapi3.ResponseRef) string {
@@ -124,3 +181,122 @@ func contains(slice []string, str string) bool {
 	}
 	return false
 }
+
+// enumHasNull reports whether an enum already lists null
+func enumHasNull(enum []interface{}) bool {
+	for _, value := range enum {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// collectExamples merges the singular OpenAPI 3.0 `example` with the OpenAPI 3.1 `examples` array.
+// The singular example comes first, followed by the array entries; duplicates are dropped.
+func collectExamples(schema *openapi3.Schema) []interface{} {
+	var candidates []interface{}
+	if schema.Example != nil {
+		candidates = append(candidates, schema.Example)
+	}
+	if raw, ok := schema.Extensions["examples"].([]interface{}); ok {
+		candidates = append(candidates, raw...)
+	}
+
+	var examples []interface{}
+	for _, candidate := range candidates {
+		duplicate := false
+		for _, existing := range examples {
+			if reflect.DeepEqual(existing, candidate) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			examples = append(examples, candidate)
+		}
+	}
+	return examples
+}
+
+// extensionUint64 reads a non-negative integer keyword captured in extensions, returning nil when
+// it is absent or not a whole non-negative number.
+func extensionUint64(extensions map[string]interface{}, key string) *uint64 {
+	var n float64
+	switch v := extensions[key].(type) {
+	case float64:
+		n = v
+	case int:
+		n = float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		n = f
+	default:
+		return nil
+	}
+	if n < 0 || n != float64(uint64(n)) {
+		return nil
+	}
+	u := uint64(n)
+	return &u
+}
+
+// extensionStringListMap reads a keyword mapping names to string lists (such as dependentRequired)
+// from extensions, skipping entries that are not lists of strings. It returns nil when absent.
+func extensionStringListMap(extensions map[string]interface{}, key string) map[string][]string {
+	raw, ok := extensions[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string][]string, len(raw))
+	for name, value := range raw {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		list := make([]string, 0, len(items))
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		result[name] = list
+	}
+	return result
+}
+
+// extensionSchema decodes a schema-valued keyword that kin-openapi does not model (OpenAPI 3.1
+// keywords such as contains or if) from extensions. It returns nil when the keyword is absent.
+// References inside the decoded schema are not resolved.
+func extensionSchema(extensions map[string]interface{}, key string) (*openapi3.Schema, error) {
+	raw, ok := extensions[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s schema: %w", key, err)
+	}
+	schema := &openapi3.Schema{}
+	if err := schema.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s schema: %w", key, err)
+	}
+	return schema, nil
+}
+
+// extensionInt reads a numeric vendor extension, returning 0 when it is absent or not a number.
+func extensionInt(extensions map[string]interface{}, key string) int {
+	switch v := extensions[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	}
+	return 0
+}
@@ -44,47 +44,39 @@ func TestSortedContentTypes(t *testing.T) {
 	}
 }
 
-func TestToAlphaSuffix(t *testing.T) {
+func TestResponseSuffix(t *testing.T) {
 	cases := []struct {
-		n    int
-		want string
+		code        string
+		contentType string
+		want        string
 	}{
-		{0, "A"},
-		{1, "B"},
-		{25, "Z"},
-		{26, "AA"},
-		{27, "AB"},
-		{51, "AZ"},
-		{52, "BA"},
-		{701, "ZZ"},
-		{702, "AAA"},
+		{"200", "application/json", "200_application_json"},
+		{"404", "application/problem+json", "404_application_problem_json"},
+		{"default", "text/plain; charset=utf-8", "default_text_plain_charset_utf_8"},
+		{"2XX", "application/vnd.api+json", "2xx_application_vnd_api_json"},
 	}
 
 	for _, c := range cases {
-		got := toAlphaSuffix(c.n)
-		if got != c.want {
-			t.Errorf("toAlphaSuffix(%d) = %q, want %q", c.n, got, c.want)
+		if got := responseSuffix(c.code, c.contentType); got != c.want {
+			t.Errorf("responseSuffix(%q, %q) = %q, want %q", c.code, c.contentType, got, c.want)
 		}
 	}
 }
 
-func TestAssignSuffixes(t *testing.T) {
-	responses := make([]ResponseTemplate, 5)
-	wantSuffixes := []string{"A", "B", "C", "D", "E"}
+func TestDedupeSuffixes(t *testing.T) {
+	responses := []ResponseTemplate{
+		{Suffix: "200_application_json"},
+		{Suffix: "200_text_plain"},
+		{Suffix: "200_application_json"},
+	}
+	want := []string{"200_application_json", "200_text_plain", "200_application_json_2"}
 
-	got := assignSuffixes(responses)
+	got := dedupeSuffixes(responses)
 	for i, resp := range got {
-		if resp.Suffix != wantSuffixes[i] {
-			t.Errorf("assignSuffixes: response %d has Suffix %q, want %q", i, resp.Suffix, wantSuffixes[i])
+		if resp.Suffix != want[i] {
+			t.Errorf("dedupeSuffixes: response %d has Suffix %q, want %q", i, resp.Suffix, want[i])
 		}
 	}
-
-	// Test with more than 26 to check double letters
-	responses = make([]ResponseTemplate, 28)
-	got = assignSuffixes(responses)
-	if got[26].Suffix != "AA" || got[27].Suffix != "AB" {
-		t.Errorf("assignSuffixes: got[26]=%q, got[27]=%q, want AA, AB", got[26].Suffix, got[27].Suffix)
-	}
 }
 
 func TestFormatForGoRawString(t *testing.T) {
@@ -278,3 +270,122 @@ func TestContains(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectExamples(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi3.Schema
+		want   []interface{}
+	}{
+		{"none", &openapi3.Schema{}, nil},
+		{"singular only", &openapi3.Schema{Example: 1.0}, []interface{}{1.0}},
+		{
+			"array only",
+			&openapi3.Schema{Extensions: map[string]interface{}{"examples": []interface{}{"a", "b"}}},
+			[]interface{}{"a", "b"},
+		},
+		{
+			"singular first and deduplicated",
+			&openapi3.Schema{
+				Example:    map[string]interface{}{"id": 1.0},
+				Extensions: map[string]interface{}{"examples": []interface{}{map[string]interface{}{"id": 1.0}, "x", "x"}},
+			},
+			[]interface{}{map[string]interface{}{"id": 1.0}, "x"},
+		},
+		{
+			"non-array examples ignored",
+			&openapi3.Schema{Extensions: map[string]interface{}{"examples": "oops"}},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectExamples(tt.schema)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectExamples() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtensionInt(t *testing.T) {
+	extensions := map[string]interface{}{
+		"x-float":  float64(1024),
+		"x-int":    7,
+		"x-string": "12",
+	}
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"x-float", 1024},
+		{"x-int", 7},
+		{"x-string", 0},
+		{"x-missing", 0},
+	}
+	for _, tt := range tests {
+		if got := extensionInt(extensions, tt.key); got != tt.want {
+			t.Errorf("extensionInt(%q) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestExtensionUint64(t *testing.T) {
+	extensions := map[string]interface{}{
+		"whole":    float64(3),
+		"zero":     float64(0),
+		"negative": float64(-1),
+		"fraction": 1.5,
+		"text":     "2",
+	}
+	if got := extensionUint64(extensions, "whole"); got == nil || *got != 3 {
+		t.Errorf("extensionUint64(whole) = %v, want 3", got)
+	}
+	if got := extensionUint64(extensions, "zero"); got == nil || *got != 0 {
+		t.Errorf("extensionUint64(zero) = %v, want 0", got)
+	}
+	for _, key := range []string{"negative", "fraction", "text", "missing"} {
+		if got := extensionUint64(extensions, key); got != nil {
+			t.Errorf("extensionUint64(%s) = %v, want nil", key, *got)
+		}
+	}
+}
+
+func TestExtensionSchema(t *testing.T) {
+	extensions := map[string]interface{}{
+		"contains": map[string]interface{}{"type": "integer", "minimum": float64(10)},
+	}
+	schema, err := extensionSchema(extensions, "contains")
+	if err != nil {
+		t.Fatalf("extensionSchema() error = %v", err)
+	}
+	if schema == nil || !schema.Type.Is("integer") || schema.Min == nil || *schema.Min != 10 {
+		t.Errorf("extensionSchema() = %+v, want integer with minimum 10", schema)
+	}
+
+	if schema, err := extensionSchema(extensions, "missing"); schema != nil || err != nil {
+		t.Errorf("extensionSchema(missing) = (%v, %v), want (nil, nil)", schema, err)
+	}
+
+	if _, err := extensionSchema(map[string]interface{}{"contains": "not a schema"}, "contains"); err == nil {
+		t.Error("expected error for a non-object schema, got nil")
+	}
+}
+
+func TestExtensionStringListMap(t *testing.T) {
+	extensions := map[string]interface{}{
+		"dependentRequired": map[string]interface{}{
+			"creditCard": []interface{}{"billingAddress", "cvv"},
+			"broken":     "not a list",
+		},
+	}
+	got := extensionStringListMap(extensions, "dependentRequired")
+	want := map[string][]string{"creditCard": {"billingAddress", "cvv"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extensionStringListMap() = %v, want %v", got, want)
+	}
+	if got := extensionStringListMap(extensions, "missing"); got != nil {
+		t.Errorf("extensionStringListMap(missing) = %v, want nil", got)
+	}
+}
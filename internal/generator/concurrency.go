@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"runtime"
+	"sync"
+)
+
+// concurrency returns the number of tool files generated in parallel
+func (g *Generator) concurrency() int {
+	if g.Concurrency > 0 {
+		return g.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// runConcurrently calls fn for every index in [0, n) using at most workers goroutines.
+// All calls run to completion; the error of the lowest failing index is returned so the
+// reported failure does not depend on scheduling.
+func runConcurrently(n, workers int, fn func(i int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	errs := make([]error, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestRunConcurrently_BoundsWorkers(t *testing.T) {
+	var running, peak int32
+	calls := make([]int32, 20)
+
+	err := runConcurrently(len(calls), 3, func(i int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&calls[i], 1)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runConcurrently() error = %v", err)
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent calls, saw %d", peak)
+	}
+	for i, n := range calls {
+		if n != 1 {
+			t.Errorf("index %d called %d times, want 1", i, n)
+		}
+	}
+}
+
+func TestRunConcurrently_ReturnsLowestIndexError(t *testing.T) {
+	err := runConcurrently(10, 4, func(i int) error {
+		if i == 7 || i == 3 {
+			return fmt.Errorf("failed %d", i)
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "failed 3" {
+		t.Errorf("runConcurrently() error = %v, want %q", err, "failed 3")
+	}
+}
+
+func TestRunConcurrently_NoItems(t *testing.T) {
+	if err := runConcurrently(0, 4, func(i int) error { return fmt.Errorf("unexpected call") }); err != nil {
+		t.Errorf("runConcurrently() error = %v, want nil", err)
+	}
+}
+
+func TestGenerateToolFiles_Concurrent(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{}
+	for i := 0; i < 50; i++ {
+		config.Tools = append(config.Tools, converter.Tool{
+			Name:           fmt.Sprintf("tool%02d", i),
+			RawInputSchema: `{"type":"object"}`,
+		})
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, Concurrency: 4}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	for _, tool := range config.Tools {
+		path := filepath.Join(tmpDir, "mcptools", capitalizeFirstLetter(tool.Name)+".go")
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be generated: %v", path, err)
+		}
+	}
+}
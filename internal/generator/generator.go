@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/lyeskara/testmcp/internal/converter"
@@ -10,23 +11,146 @@ import (
 type Generator struct {
 	specPath    string
 	PackageName string
-	outputDir   string
-	converter   converter.ConverterInterface
-	spec        *openapi3.T
+	// Manifest enables writing a TOOLS.md summary of the generated tools alongside the code
+	Manifest bool
+	// MaxResponseBytes truncates text returned by tool handlers to this many bytes; 0 disables it.
+	// Operations can override it with the x-mcp-max-response-bytes extension.
+	MaxResponseBytes int
+	// RetryCount enables retries with exponential backoff for idempotent outbound requests; 0 disables it
+	RetryCount int
+	// RetryBaseDelay is the wait before the first retry; it doubles for each following attempt
+	RetryBaseDelay time.Duration
+	// MaxTools fails generation when the server would expose more tools than this, instead of
+	// generating one too large for an MCP client to use; 0 disables the limit
+	MaxTools int
+	// Concurrency bounds how many tool files are generated in parallel; 0 uses GOMAXPROCS
+	Concurrency int
+	// OnlyOperation limits generation to one operation, selected by operationId or "METHOD /path".
+	// Only that tool file is written; register.go keeps tools whose files already exist.
+	OnlyOperation string
+	// ServiceInterface generates a Service interface with one method per tool and registers tools
+	// through an implementation of it, so handlers can be injected or mocked
+	ServiceInterface bool
+	// Tracing adds an OpenTelemetry tool handler middleware to server.go that records a span per
+	// tool call with the tracer the user assigns to the generated Tracer variable. The generated
+	// module then depends on go.opentelemetry.io/otel.
+	Tracing bool
+	// TestClient writes testclient.go with NewTestClient, which serves the generated server over
+	// mcp-go's in-process transport and returns a connected client for integration tests
+	TestClient bool
+	// Transport selects how the generated server is served: "stdio" (the default), "sse" or "http"
+	// (streamable HTTP). HTTP-based transports also get health and readiness endpoints at
+	// HealthPath and ReadyPath, which default to /healthz and /readyz.
+	Transport  string
+	HealthPath string
+	ReadyPath  string
+	// SchemaFormat selects how tool input schemas and response templates are written: inline
+	// Go constants (SchemaFormatInline, the default) or files embedded with go:embed (SchemaFormatJSON)
+	SchemaFormat string
+	// SchemaIndent is the indent unit of generated input schemas, made of spaces or tabs, or
+	// SchemaIndentCompact to write them without whitespace; empty keeps two spaces
+	SchemaIndent string
+	// MCPImportAlias imports mcp-go's mcp package under this name in the generated mcptools files,
+	// for projects where the mcp identifier is already taken; empty imports it unaliased
+	MCPImportAlias string
+	// BestEffortHTTPClient turns oapi-codegen failures in GenerateHTTPClient into a warning, so
+	// specs it cannot handle still get their tools and server
+	BestEffortHTTPClient bool
+	// PostProcess, when set, rewrites every generated file before it is written (see PostProcessor)
+	PostProcess PostProcessor
+	// GoGenerate writes generate.go with a go:generate directive rerunning mcpgen with the spec
+	// path, output directory and package name of this generator followed by GenerateFlags, e.g.
+	// "-includes=types,httpclient". An existing generate.go is never overwritten.
+	GoGenerate    bool
+	GenerateFlags []string
+	// ResponseTypes writes mcptools/responses.go with a type per JSON success response and a
+	// Parse<Tool>Response function decoding response bodies into them, for handlers that work with
+	// typed responses. Schemas are kept during conversion, so it needs ConvertOptions.ResponseSchemas.
+	ResponseTypes bool
+	// MockServer writes mockserver/main.go, a command serving each operation's example response
+	// (documented, or synthesized from the schema) so the tools can be exercised without the API
+	MockServer bool
+	// SortByTag orders the tools registered in server.go and register.go by their primary tag, then
+	// by name, so the tools of a tag are listed together; untagged tools come last. By default they
+	// are ordered by name.
+	SortByTag bool
+	// Parts limits generation to the listed parts of the output (PartServer, PartTools, ...), e.g. to
+	// iterate on the server wiring without rewriting every tool file: GenerateMCP and
+	// GenerateMCPFromConfig skip the other files and GenerateHTTPClient does nothing unless
+	// PartHTTPClient is listed. All parts are generated when it is empty.
+	Parts []string
+	// Prune deletes generated tool files whose operations are no longer in the spec.
+	// Files that do not match the generated tool file layout are never deleted.
+	Prune bool
+	// VerifyBuild runs go build on the output directory once GenerateMCP has written its files and
+	// fails with a *CompileError pointing at the offending files when the generated code does not
+	// compile. It is off by default because it is slow: it compiles the generated packages and their
+	// dependencies (mcp-go, the HTTP client, ...), which takes seconds even with a warm build cache.
+	// The output directory must be inside a Go module that requires those dependencies.
+	VerifyBuild bool
+	// Strict fails generation when the spec uses features the converter cannot represent
+	// (callbacks, content-style parameters, ...) instead of silently leaving them out
+	Strict     bool
+	outputDir  string
+	validation bool
+	options    converter.ConvertOptions
+	converter  converter.ConverterInterface
+	spec       *openapi3.T
+	// clientTypes is set once GenerateHTTPClient has written the spec's types to apiclient
+	clientTypes bool
+	// clientIncludes are the includes of the last GenerateHTTPClient call, rerun by Regenerate
+	clientIncludes []string
+	// sources are the local files the spec was read from: the spec, its overlay and the files its
+	// external $refs point to. Watch regenerates when any of them changes.
+	sources []string
+	// outputs records the files written since the last Regenerate, relative to outputDir
+	outputs *outputRecord
 }
 
+// NewGenerator parses the spec and prepares a generator for it. An empty packageName is derived
+// from the spec's info.title; an explicit one must be a valid Go identifier.
 func NewGenerator(specPath string, validation bool, packageName string, outputDir string) (*Generator, error) {
+	return NewGeneratorWithOptions(specPath, validation, packageName, outputDir, converter.ConvertOptions{})
+}
+
+// NewGeneratorWithOptions is NewGenerator with conversion options
+func NewGeneratorWithOptions(specPath string, validation bool, packageName string, outputDir string, options converter.ConvertOptions) (*Generator, error) {
+	if packageName != "" {
+		if err := validatePackageName(packageName); err != nil {
+			return nil, err
+		}
+	}
+
 	parser := converter.NewParser(validation)
+	parser.OverlayPath = options.OverlayPath
+	parser.AllowRemoteRefs = options.AllowRemoteRefs
+	parser.Format = options.SpecFormat
 	err := parser.ParseFile(specPath)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing OpenAPI specification: %w", err)
 	}
 
+	if options.NormalizeEnumNames {
+		converter.NormalizeEnumNames(parser.GetDocument())
+	}
+
+	if packageName == "" {
+		title := ""
+		if info := parser.GetDocument().Info; info != nil {
+			title = info.Title
+		}
+		packageName = packageNameFromTitle(title)
+	}
+
 	return &Generator{
 		specPath:    specPath,
-		converter:   converter.NewConverter(parser),
+		sources:     specSources(specPath, options, parser),
+		outputs:     &outputRecord{},
+		converter:   converter.NewConverterWithOptions(parser, options),
 		spec:        parser.GetDocument(),
 		outputDir:   outputDir,
+		validation:  validation,
+		options:     options,
 		PackageName: packageName,
 	}, nil
 }
@@ -4,10 +4,21 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
 )
 
+// generateClientCode runs oapi-codegen; a variable so tests can simulate codegen failures
+var generateClientCode = codegen.Generate
+
+// GenerateHTTPClient writes a typed API client and/or models for the spec to apiclient/HTTPClient.go.
+// With BestEffortHTTPClient, a codegen failure is reported as a warning and nil is returned.
+// It writes nothing when Parts leaves PartHTTPClient out.
 func (g *Generator) GenerateHTTPClient(includes []string) error {
+	if !g.generates(PartHTTPClient) {
+		return nil
+	}
+
 	// Determine what to generate
 	var generateTypes, generateClient bool
 	for _, inc := range includes {
@@ -22,6 +33,7 @@ func (g *Generator) GenerateHTTPClient(includes []string) error {
 	if !generateTypes && !generateClient {
 		return fmt.Errorf("no valid includes specified (must include 'types', 'httpclient', or both)")
 	}
+	g.clientIncludes = includes
 
 	if g.spec == nil {
 		return fmt.Errorf("code generation failed: OpenAPI spec is nil") 
@@ -35,17 +47,32 @@ func (g *Generator) GenerateHTTPClient(includes []string) error {
 		},
 	}
 	
-	code, err := codegen.Generate(g.spec, cfg)
+	code, err := runClientCodegen(g.spec, cfg)
 	if err != nil {
+		if g.BestEffortHTTPClient {
+			fmt.Printf("Warning: skipping HTTP client generation, the spec is not supported by oapi-codegen: %v\n", err)
+			return nil
+		}
 		return fmt.Errorf("code generation failed: %w", err)
 	}
 
 	// Write to file
-	if err := writeFileContent(g.outputDir + "/apiclient", "HTTPClient.go", func() ([]byte, error) {
+	if err := g.writeOutputFile("apiclient", "HTTPClient.go", false, func() ([]byte, error) {
 		return []byte(code), nil
 	}); err != nil {
 		return fmt.Errorf("failed to write generated code to file: %w", err)
 	}
+	g.clientTypes = generateTypes
 
 	return nil
 }
+
+// runClientCodegen calls generateClientCode, turning a panic inside oapi-codegen into an error
+func runClientCodegen(spec *openapi3.T, cfg codegen.Configuration) (code string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("oapi-codegen panicked: %v", r)
+		}
+	}()
+	return generateClientCode(spec, cfg)
+}
@@ -1,10 +1,14 @@
 package generator
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
 )
 
 // This assumes you have NewGenerator as in your previous code.
@@ -112,3 +116,49 @@ func TestGenerateHTTPClient_NilSpec(t *testing.T) {
 		t.Errorf("Expected error about nil spec, got: %v", err)
 	}
 }
+
+func TestGenerateHTTPClient_CodegenFailure(t *testing.T) {
+	original := generateClientCode
+	defer func() { generateClientCode = original }()
+
+	tests := []struct {
+		name     string
+		generate func(*openapi3.T, codegen.Configuration) (string, error)
+		wantErr  string
+	}{
+		{
+			name: "error",
+			generate: func(*openapi3.T, codegen.Configuration) (string, error) {
+				return "", errors.New("unsupported discriminator")
+			},
+			wantErr: "code generation failed: unsupported discriminator",
+		},
+		{
+			name: "panic",
+			generate: func(*openapi3.T, codegen.Configuration) (string, error) {
+				panic("nil schema")
+			},
+			wantErr: "code generation failed: oapi-codegen panicked: nil schema",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generateClientCode = tt.generate
+			outputDir := t.TempDir()
+
+			failFast := &Generator{spec: &openapi3.T{}, outputDir: outputDir}
+			err := failFast.GenerateHTTPClient([]string{"httpclient"})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+
+			bestEffort := &Generator{spec: &openapi3.T{}, outputDir: outputDir, BestEffortHTTPClient: true}
+			if err := bestEffort.GenerateHTTPClient([]string{"httpclient"}); err != nil {
+				t.Errorf("expected best-effort generation to continue, got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(outputDir, "apiclient", "HTTPClient.go")); !os.IsNotExist(err) {
+				t.Errorf("expected no client to be written, stat error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// maxToolsBreakdown caps how many path prefixes TooManyToolsError lists
+const maxToolsBreakdown = 10
+
+// TooManyToolsError is returned when the spec converts to more tools than Generator.MaxTools allows
+type TooManyToolsError struct {
+	Count int
+	Max   int
+	// Prefixes counts the matched operations by the first segment of their path, largest first,
+	// to show which parts of the spec to filter out
+	Prefixes []PathPrefixCount
+}
+
+// PathPrefixCount is the number of operations under one top-level path segment
+type PathPrefixCount struct {
+	Prefix string
+	Count  int
+}
+
+// Error reports the tool count and how to bring it under the limit
+func (e *TooManyToolsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "the spec matched %d operations, more than the %d-tool limit; "+
+		"an MCP client cannot use that many tools effectively", e.Count, e.Max)
+	if len(e.Prefixes) > 0 {
+		b.WriteString("\noperations by path prefix:")
+		for _, prefix := range e.Prefixes {
+			fmt.Fprintf(&b, "\n  %s: %d", prefix.Prefix, prefix.Count)
+		}
+	}
+	b.WriteString("\nnarrow the spec with an overlay that removes unneeded paths or tags (-overlay), " +
+		"exclude deprecated operations (-deprecated exclude), or raise the limit (-max-tools)")
+	return b.String()
+}
+
+// checkMaxTools fails with a TooManyToolsError when config has more tools than g.MaxTools; 0 disables the check
+func (g *Generator) checkMaxTools(config *converter.MCPConfig) error {
+	if g.MaxTools <= 0 || len(config.Tools) <= g.MaxTools {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, tool := range config.Tools {
+		counts[pathPrefix(tool.Path)]++
+	}
+	prefixes := make([]PathPrefixCount, 0, len(counts))
+	for prefix, count := range counts {
+		prefixes = append(prefixes, PathPrefixCount{Prefix: prefix, Count: count})
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if prefixes[i].Count != prefixes[j].Count {
+			return prefixes[i].Count > prefixes[j].Count
+		}
+		return prefixes[i].Prefix < prefixes[j].Prefix
+	})
+	if len(prefixes) > maxToolsBreakdown {
+		prefixes = prefixes[:maxToolsBreakdown]
+	}
+
+	return &TooManyToolsError{Count: len(config.Tools), Max: g.MaxTools, Prefixes: prefixes}
+}
+
+// pathPrefix returns the first segment of an operation path, e.g. "/pets" for "/pets/{id}"
+func pathPrefix(path string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return "/" + segment
+}
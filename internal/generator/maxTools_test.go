@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateMCP_MaxTools(t *testing.T) {
+	config := onlyOperationTestConfig()
+	config.Tools = append(config.Tools, converter.Tool{Name: "listOwners", Method: "GET", Path: "/owners", RawInputSchema: `{"type":"object"}`})
+
+	tmpDir := t.TempDir()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, MaxTools: 3}
+	err := g.GenerateMCP()
+
+	var tooMany *TooManyToolsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("GenerateMCP() error = %v, want a TooManyToolsError", err)
+	}
+	if tooMany.Count != 4 || tooMany.Max != 3 {
+		t.Errorf("Count, Max = %d, %d, want 4, 3", tooMany.Count, tooMany.Max)
+	}
+	want := []PathPrefixCount{{Prefix: "/pets", Count: 3}, {Prefix: "/owners", Count: 1}}
+	if len(tooMany.Prefixes) != len(want) || tooMany.Prefixes[0] != want[0] || tooMany.Prefixes[1] != want[1] {
+		t.Errorf("Prefixes = %v, want %v", tooMany.Prefixes, want)
+	}
+	for _, part := range []string{"matched 4 operations", "3-tool limit", "/pets: 3", "-overlay"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("error message missing %q:\n%s", part, err)
+		}
+	}
+	if _, statErr := os.Stat(filepath.Join(tmpDir, "mcptools")); !os.IsNotExist(statErr) {
+		t.Errorf("expected nothing to be generated, stat error = %v", statErr)
+	}
+
+	for _, max := range []int{0, 4} {
+		g := &Generator{PackageName: "mytools", outputDir: t.TempDir(), converter: &testConverter{config: config}, MaxTools: max}
+		if err := g.GenerateMCP(); err != nil {
+			t.Errorf("MaxTools = %d: GenerateMCP() error = %v", max, err)
+		}
+	}
+}
+
+func Test_pathPrefix(t *testing.T) {
+	tests := map[string]string{
+		"/pets/{id}": "/pets",
+		"/pets":      "/pets",
+		"pets/x":     "/pets",
+		"/":          "/",
+	}
+	for path, want := range tests {
+		if got := pathPrefix(path); got != want {
+			t.Errorf("pathPrefix(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
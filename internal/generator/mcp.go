@@ -14,32 +14,172 @@ var templatesFS embed.FS
 type ToolTemplateData struct {
 	ToolNameOriginal      string
 	ToolNameGo            string
+	ToolNameRegistered    string
 	ToolHandlerName       string
 	ToolDescription       string
+	Method                string
+	Path                  string
 	RawInputSchema        string
 	ResponseTemplate      []converter.ResponseTemplate
 	InputSchemaConst      string
 	ResponseTemplateConst string
+	MaxResponseBytes      int
+	HasProblemDetails     bool
+	ErrorFields           []StatusErrorFields
+	ResponseContentTypes  []string
+}
+
+// StatusErrorFields lists the documented error body fields for one status code (0 for default and ranges)
+type StatusErrorFields struct {
+	StatusCode int
+	Fields     []string
 }
 
 // GenerateMCP generates the MCP tool files while preserving existing handler implementations and imports
 func (g *Generator) GenerateMCP() error {
+	if err := g.validateSettings(); err != nil {
+		return err
+	}
+
+	config, err := g.BuildConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range config.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	return g.GenerateMCPFromConfig(config)
+}
+
+// BuildConfig converts the spec into the MCP configuration GenerateMCP generates from, with
+// ConvertOptions.ToolOverrides applied by the converter. Build it once and pass it to GenerateMCPFromConfig, or to the individual
+// Generate*File methods, to regenerate parts of the output without converting the spec again.
+func (g *Generator) BuildConfig() (*converter.MCPConfig, error) {
 	config, err := g.converter.Convert()
 	if err != nil {
-		return fmt.Errorf("failed at converting OpenAPI schema into MCP code %w", err)
+		return nil, fmt.Errorf("failed at converting OpenAPI schema into MCP code %w", err)
+	}
+
+	if g.Strict && len(config.Unsupported) > 0 {
+		return nil, &converter.UnsupportedFeaturesError{Features: config.Unsupported}
+	}
+	return config, nil
+}
+
+// GenerateMCPFromConfig writes the parts of the output selected by Parts from a configuration
+// returned by BuildConfig. Its warnings are left for the caller to report.
+func (g *Generator) GenerateMCPFromConfig(config *converter.MCPConfig) error {
+	if err := g.validateSettings(); err != nil {
+		return err
+	}
+
+	generated, registered := config, config
+	if g.OnlyOperation != "" {
+		var err error
+		generated, registered, err = g.restrictToOperation(config)
+		if err != nil {
+			return fmt.Errorf("failed to select operation: %w", err)
+		}
+	}
+
+	if err := g.checkMaxTools(registered); err != nil {
+		return err
+	}
+
+	if g.generates(PartServer) {
+		if err := g.GenerateServerFile(registered); err != nil {
+			return fmt.Errorf("failed to generate server file: %w", err)
+		}
 	}
 
-	if err := g.GenerateServerFile(config); err != nil {
-		return fmt.Errorf("failed to generate server file: %w", err)
+	if g.TestClient && g.generates(PartTestClient) {
+		if err := g.GenerateTestClientFile(); err != nil {
+			return fmt.Errorf("failed to generate test client file: %w", err)
+		}
 	}
 
-	if err := g.GenerateToolFiles(config); err != nil {
-		return fmt.Errorf("failed to generate tool files: %w", err)
+	if g.generates(PartRegister) {
+		if err := g.GenerateRegisterFile(registered); err != nil {
+			return fmt.Errorf("failed to generate register file: %w", err)
+		}
 	}
 
-	if err := g.GenerateHelpers(); err != nil {
-		return fmt.Errorf("failed to generate helpers: %w", err)
+	if g.generates(PartRuntime) {
+		if err := g.GenerateRuntimeFile(registered); err != nil {
+			return fmt.Errorf("failed to generate runtime file: %w", err)
+		}
+	}
+
+	if g.generates(PartTools) {
+		if err := g.GenerateToolFiles(generated); err != nil {
+			return fmt.Errorf("failed to generate tool files: %w", err)
+		}
+
+		if g.Prune {
+			deleted, err := g.pruneToolFiles(config)
+			for _, path := range deleted {
+				fmt.Printf("Deleted stale file %s\n", path)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to prune tool files: %w", err)
+			}
+		}
+	}
+
+	if g.generates(PartHelpers) {
+		if err := g.GenerateHelpers(); err != nil {
+			return fmt.Errorf("failed to generate helpers: %w", err)
+		}
+	}
+
+	if g.ResponseTypes && g.generates(PartResponseTypes) {
+		if err := g.GenerateResponseTypesFile(registered); err != nil {
+			return fmt.Errorf("failed to generate response types: %w", err)
+		}
+	}
+
+	if g.Manifest && g.generates(PartManifest) {
+		if err := g.GenerateManifest(registered); err != nil {
+			return fmt.Errorf("failed to generate manifest: %w", err)
+		}
+	}
+
+	if g.GoGenerate && g.generates(PartGoGenerate) {
+		if err := g.GenerateGoGenerateFile(); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", generateFileName, err)
+		}
+	}
+
+	if g.MockServer && g.generates(PartMockServer) {
+		if err := g.GenerateMockServerFile(registered); err != nil {
+			return fmt.Errorf("failed to generate mock server: %w", err)
+		}
+	}
+
+	if g.VerifyBuild {
+		if err := g.verifyBuild(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// validateSettings checks the exported settings that are not validated when they are set
+func (g *Generator) validateSettings() error {
+	if err := validateImportAlias(g.MCPImportAlias); err != nil {
+		return err
+	}
+	if err := validateSchemaFormat(g.SchemaFormat); err != nil {
+		return err
+	}
+	if err := validateSchemaIndent(g.SchemaIndent); err != nil {
+		return err
+	}
+	if err := g.validateRetries(); err != nil {
+		return err
+	}
+	return validateParts(g.Parts)
+}
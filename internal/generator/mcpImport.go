@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mcpImportPath is the mcp-go package the generated tools are built on
+const mcpImportPath = "github.com/mark3labs/mcp-go/mcp"
+
+// mcpImportData is embedded in template data so templates can import and qualify mcp-go's
+// mcp package under Generator.MCPImportAlias
+type mcpImportData struct {
+	// MCP qualifies identifiers from the package, e.g. "mcp" in mcp.Tool
+	MCP string
+	// MCPImport is the import spec, with the alias when one is set
+	MCPImport string
+}
+
+// mcpImport returns the qualifier and import spec for the mcp package
+func (g *Generator) mcpImport() mcpImportData {
+	if g.MCPImportAlias == "" {
+		return mcpImportData{MCP: "mcp", MCPImport: strconv.Quote(mcpImportPath)}
+	}
+	return mcpImportData{MCP: g.MCPImportAlias, MCPImport: g.MCPImportAlias + " " + strconv.Quote(mcpImportPath)}
+}
+
+// validateImportAlias reports whether alias can name the mcp import in the generated files
+func validateImportAlias(alias string) error {
+	if alias == "" {
+		return nil
+	}
+	if !token.IsIdentifier(alias) {
+		return fmt.Errorf("invalid mcp import alias %q: must be a valid Go identifier", alias)
+	}
+	if alias == "_" {
+		return fmt.Errorf("invalid mcp import alias %q: the blank identifier cannot qualify references", alias)
+	}
+	return nil
+}
+
+// importQualifier returns the name f refers to the package at path by, if f imports it
+func importQualifier(f *ast.File, path string) (string, bool) {
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || importPath != path {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name, true
+		}
+		// Only correct for packages whose name matches the last path element, as mcp-go's do
+		return path[strings.LastIndex(path, "/")+1:], true
+	}
+	return "", false
+}
+
+// fileMCPQualifier returns the name a Go source file refers to the mcp package by, defaulting to
+// "mcp" when the file does not import it or cannot be parsed
+func fileMCPQualifier(fileContent string) string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", fileContent, parser.ImportsOnly)
+	if err != nil {
+		return "mcp"
+	}
+	if name, ok := importQualifier(f, mcpImportPath); ok {
+		return name
+	}
+	return "mcp"
+}
+
+// mergeMCPImport replaces the mcp import among imports (as returned by extractImports) with spec,
+// adding it when missing, so a regenerated tool file imports mcp under the configured alias.
+// An empty list stays empty so callers fall back to their default imports.
+func mergeMCPImport(imports []string, spec string) []string {
+	if len(imports) == 0 {
+		return imports
+	}
+	merged := make([]string, 0, len(imports)+1)
+	for _, imp := range imports {
+		if strings.HasSuffix(imp, strconv.Quote(mcpImportPath)) {
+			continue
+		}
+		merged = append(merged, imp)
+	}
+	return append(merged, spec)
+}
+
+// addImport appends spec to imports (as returned by extractImports) unless it is already there
+func addImport(imports []string, spec string) []string {
+	if len(imports) == 0 || slices.Contains(imports, spec) {
+		return imports
+	}
+	return append(imports, spec)
+}
+
+// renameQualifier rewrites references like from.CallToolRequest in a function body to to.CallToolRequest,
+// leaving the rest of the body's text untouched. The body is returned unchanged if it cannot be parsed.
+func renameQualifier(body, from, to string) string {
+	if from == to {
+		return body
+	}
+	const prefix = "package p\nfunc _() "
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", prefix+body, parser.ParseComments)
+	if err != nil {
+		return body
+	}
+
+	var offsets []int
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == from {
+				offsets = append(offsets, fset.Position(ident.Pos()).Offset-len(prefix))
+			}
+		}
+		return true
+	})
+	sort.Ints(offsets)
+
+	var buf bytes.Buffer
+	last := 0
+	for _, offset := range offsets {
+		buf.WriteString(body[last:offset])
+		buf.WriteString(to)
+		last = offset + len(from)
+	}
+	buf.WriteString(body[last:])
+	return buf.String()
+}
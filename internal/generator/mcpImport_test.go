@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// unqualifiedMCP matches references to the mcp package under its default name
+var unqualifiedMCP = regexp.MustCompile(`[^.\w]mcp\.[A-Z]`)
+
+func TestGenerateMCP_MCPImportAlias(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &converter.MCPConfig{Tools: onlyOperationTestConfig().Tools[:1]}
+	config.Tools[0].MaxResponseBytes = 100
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, MCPImportAlias: "mcpgo", ServiceInterface: true}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	for _, name := range []string{"GetPet.go", "register.go", "runtime.go"} {
+		data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		content := string(data)
+		if !strings.Contains(content, `mcpgo "github.com/mark3labs/mcp-go/mcp"`) {
+			t.Errorf("%s does not import mcp under the alias\n%s", name, content)
+		}
+		if !strings.Contains(content, "mcpgo.CallToolResult") {
+			t.Errorf("%s does not qualify references with the alias\n%s", name, content)
+		}
+		if unqualifiedMCP.MatchString(content) {
+			t.Errorf("%s still refers to mcp unaliased\n%s", name, content)
+		}
+	}
+}
+
+func TestGenerateMCP_MCPImportAliasKeepsHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &converter.MCPConfig{Tools: onlyOperationTestConfig().Tools[:1]}
+	previous := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "mcptools", "GetPet.go")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read GetPet.go: %v", err)
+	}
+	custom, err := extractHandlerImplementation(string(data), "GetPetHandler")
+	if err != nil || custom == "" {
+		t.Fatalf("extractHandlerImplementation() = %q, %v", custom, err)
+	}
+	content := replaceHandlerImplementation(string(data), "GetPetHandler", "{\n\t// custom\n\treturn mcp.NewToolResultText(\"mcp.custom\"), nil\n}\n")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write GetPet.go: %v", err)
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, MCPImportAlias: "mcpgo"}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read GetPet.go: %v", err)
+	}
+	regenerated := string(data)
+	if !strings.Contains(regenerated, "// custom\n\treturn mcpgo.NewToolResultText(\"mcp.custom\"), nil") {
+		t.Errorf("custom handler not kept with the alias\n%s", regenerated)
+	}
+	if !strings.Contains(regenerated, `mcpgo "github.com/mark3labs/mcp-go/mcp"`) || strings.Contains(regenerated, "\t\"github.com/mark3labs/mcp-go/mcp\"") {
+		t.Errorf("expected only the aliased mcp import\n%s", regenerated)
+	}
+	if unqualifiedMCP.MatchString(regenerated) {
+		t.Errorf("GetPet.go still refers to mcp unaliased\n%s", regenerated)
+	}
+}
+
+func TestGenerateMCP_MCPImportAliasBuilds(t *testing.T) {
+	// The alias exists for packages that declare mcp themselves, so the generated files, including
+	// server.go and testclient.go next to the user's code, must build with mcp taken
+	moduleDir := t.TempDir()
+	goMod := "module example.com/aliased\n\ngo 1.23\n\nrequire github.com/mark3labs/mcp-go v0.44.0\n"
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(moduleDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalCwd)
+
+	g := &Generator{PackageName: "aliased", outputDir: ".", converter: &testConverter{config: onlyOperationTestConfig()}, MCPImportAlias: "mcpgo", TestClient: true}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, "user.go"), []byte("package aliased\n\nvar mcp = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOSUMDB=off")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "module lookup disabled") || strings.Contains(string(output), "cannot find module") {
+			t.Skipf("mcp-go is not in the module cache: %s", output)
+		}
+		t.Fatalf("generated code does not build with the mcp import aliased: %v\n%s", err, output)
+	}
+}
+
+func TestGenerateMCP_InvalidMCPImportAlias(t *testing.T) {
+	for _, alias := range []string{"_", "mcp-go", "func"} {
+		g := &Generator{PackageName: "mytools", outputDir: t.TempDir(), converter: &testConverter{config: onlyOperationTestConfig()}, MCPImportAlias: alias}
+		if err := g.GenerateMCP(); err == nil || !strings.Contains(err.Error(), "invalid mcp import alias") {
+			t.Errorf("alias %q: GenerateMCP() error = %v, want an invalid alias error", alias, err)
+		}
+	}
+}
+
+func Test_extractHandlerImplementation_AliasedImport(t *testing.T) {
+	src := "package mcptools\n\nimport mcpgo \"github.com/mark3labs/mcp-go/mcp\"\n\n" +
+		"func EchoHandler(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {\n\treturn nil, nil\n}\n"
+	body, err := extractHandlerImplementation(src, "EchoHandler")
+	if err != nil {
+		t.Fatalf("extractHandlerImplementation() error = %v", err)
+	}
+	if body != "{\n\treturn nil, nil\n}\n" {
+		t.Errorf("extractHandlerImplementation() = %q", body)
+	}
+}
+
+func Test_renameQualifier(t *testing.T) {
+	body := "{\n\t// mcp.Tool stays in comments\n\tvar r *mcp.CallToolResult\n\tx := mcp.NewToolResultText(\"mcp.Tool\")\n\treturn r, nil\n}\n"
+	want := "{\n\t// mcp.Tool stays in comments\n\tvar r *mcpgo.CallToolResult\n\tx := mcpgo.NewToolResultText(\"mcp.Tool\")\n\treturn r, nil\n}\n"
+	if got := renameQualifier(body, "mcp", "mcpgo"); got != want {
+		t.Errorf("renameQualifier() = %q, want %q", got, want)
+	}
+	if got := renameQualifier("{ broken", "mcp", "mcpgo"); got != "{ broken" {
+		t.Errorf("renameQualifier() = %q, want the unparsable body unchanged", got)
+	}
+}
+
+func Test_mergeMCPImport(t *testing.T) {
+	got := mergeMCPImport([]string{`"context"`, `"github.com/mark3labs/mcp-go/mcp"`, `"fmt"`}, `mcpgo "github.com/mark3labs/mcp-go/mcp"`)
+	want := []string{`"context"`, `"fmt"`, `mcpgo "github.com/mark3labs/mcp-go/mcp"`}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("mergeMCPImport() = %v, want %v", got, want)
+	}
+	if got := mergeMCPImport(nil, `"github.com/mark3labs/mcp-go/mcp"`); len(got) != 0 {
+		t.Errorf("mergeMCPImport(nil) = %v, want none", got)
+	}
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -63,6 +64,18 @@ func TestGenerateMCP(t *testing.T) {
 		t.Errorf("Expected helpers.go to be generated, but it does not exist")
 	}
 
+	// Check that register.go exists
+	registerGoPath := filepath.Join(tmpDir, "mcptools", "register.go")
+	if _, err := os.Stat(registerGoPath); err != nil {
+		t.Errorf("Expected register.go to be generated, but it does not exist")
+	}
+
+	// Check that runtime.go exists
+	runtimeGoPath := filepath.Join(tmpDir, "mcptools", "runtime.go")
+	if _, err := os.Stat(runtimeGoPath); err != nil {
+		t.Errorf("Expected runtime.go to be generated, but it does not exist")
+	}
+
 	// Check that the tool file exists
 	toolFilePath := filepath.Join(tmpDir, "mcptools", "Echo.go")
 	data, err := os.ReadFile(toolFilePath)
@@ -80,3 +93,73 @@ func TestGenerateMCP(t *testing.T) {
 		t.Errorf("Generated tool file missing package declaration")
 	}
 }
+
+func TestGenerateMCP_ToolOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// The converter leaves a ConvertOptions.ToolOverrides override in DisplayName and Description
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "echo",
+				DisplayName:    "repeat_message",
+				Description:    "Repeats the message back",
+				RawInputSchema: `{"type":"object"}`,
+				RequestTemplate: converter.RequestTemplate{
+					URL:    "/echo",
+					Method: "POST",
+				},
+			},
+		},
+	}
+
+	g := &Generator{
+		PackageName: "mytools",
+		outputDir:   tmpDir,
+		converter:   &testConverter{config: config},
+	}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	// The file and identifiers keep the operationId; only the registered name changes
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "Echo.go"))
+	if err != nil {
+		t.Fatalf("Expected Echo.go to be generated: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"func NewEchoMCPTool() mcp.Tool", `"repeat_message"`, `"Repeats the message back"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated tool file missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateMCP_Strict(t *testing.T) {
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "echo", RawInputSchema: `{"type":"object"}`}},
+		Unsupported: []converter.UnsupportedFeature{
+			{Location: "POST /echo", Feature: "callbacks (onEcho)"},
+			{Location: "POST /echo parameter q", Feature: "deepObject style"},
+		},
+	}
+
+	permissive := &Generator{PackageName: "mytools", outputDir: t.TempDir(), converter: &testConverter{config: config}}
+	if err := permissive.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed without strict mode: %v", err)
+	}
+
+	outputDir := t.TempDir()
+	strict := &Generator{PackageName: "mytools", outputDir: outputDir, converter: &testConverter{config: config}, Strict: true}
+	err := strict.GenerateMCP()
+	var unsupported *converter.UnsupportedFeaturesError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedFeaturesError, got %v", err)
+	}
+	if len(unsupported.Features) != 2 {
+		t.Errorf("expected every unsupported feature to be reported, got %v", unsupported.Features)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "mcptools")); !os.IsNotExist(err) {
+		t.Errorf("strict mode must not write any files when it fails")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -13,6 +15,19 @@ const (
 	goModFile      = "go.mod"
 )
 
+// moduleInfo is a cached findModulePath result. The go.mod modification time
+// is kept so an edited or removed go.mod invalidates the entry.
+type moduleInfo struct {
+	name    string
+	root    string
+	modTime time.Time
+}
+
+var (
+	moduleCacheMu sync.Mutex
+	moduleCache   = map[string]moduleInfo{}
+)
+
 // BuildImportPath finds the module root and builds the import path for mcptools
 func BuildImportPath(outputDir string) (string, error) {
 	// Get current working directory
@@ -22,7 +37,7 @@ func BuildImportPath(outputDir string) (string, error) {
 	}
 
 	// Find module info
-	moduleName, moduleRoot, err := findModulePath(cwd)
+	moduleName, moduleRoot, err := cachedFindModulePath(cwd)
 	if err != nil {
 		return "", fmt.Errorf("failed to find module: %w", err)
 	}
@@ -41,6 +56,43 @@ func BuildImportPath(outputDir string) (string, error) {
 	return importPath, nil
 }
 
+// cachedFindModulePath memoizes findModulePath keyed on the resolved start
+// directory, so a changed working directory simply misses the cache. Entries
+// are dropped when their go.mod is modified or removed; errors are not cached.
+func cachedFindModulePath(startDir string) (string, string, error) {
+	key := filepath.Clean(startDir)
+
+	moduleCacheMu.Lock()
+	info, ok := moduleCache[key]
+	moduleCacheMu.Unlock()
+
+	if ok {
+		stat, err := os.Stat(filepath.Join(info.root, goModFile))
+		if err == nil && stat.ModTime().Equal(info.modTime) {
+			return info.name, info.root, nil
+		}
+	}
+
+	name, root, err := findModulePath(key)
+	if err != nil {
+		moduleCacheMu.Lock()
+		delete(moduleCache, key)
+		moduleCacheMu.Unlock()
+		return "", "", err
+	}
+
+	stat, err := os.Stat(filepath.Join(root, goModFile))
+	if err != nil {
+		return "", "", fmt.Errorf("error checking for go.mod: %w", err)
+	}
+
+	moduleCacheMu.Lock()
+	moduleCache[key] = moduleInfo{name: name, root: root, modTime: stat.ModTime()}
+	moduleCacheMu.Unlock()
+
+	return name, root, nil
+}
+
 // findModulePath searches upward from startDir to find the go.mod file
 func findModulePath(startDir string) (string, string, error) {
 	currentDir := filepath.Clean(startDir)
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // Helper to create a temporary go.mod file
@@ -313,3 +314,56 @@ func TestBuildImportPath(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedFindModulePath(t *testing.T) {
+	root := t.TempDir()
+	goModPath := createTempGoMod(t, root, "module example.com/cached\n")
+	startDir := filepath.Join(root, "cmd", "tool")
+	if err := os.MkdirAll(startDir, 0755); err != nil {
+		t.Fatalf("Failed to create start dir: %v", err)
+	}
+
+	name, modRoot, err := cachedFindModulePath(startDir)
+	if err != nil {
+		t.Fatalf("cachedFindModulePath() error = %v", err)
+	}
+	if name != "example.com/cached" || modRoot != root {
+		t.Fatalf("cachedFindModulePath() = (%q, %q), want (%q, %q)", name, modRoot, "example.com/cached", root)
+	}
+
+	moduleCacheMu.Lock()
+	_, cached := moduleCache[filepath.Clean(startDir)]
+	moduleCacheMu.Unlock()
+	if !cached {
+		t.Fatal("expected result to be cached for start dir")
+	}
+
+	// Rewriting go.mod with a new modification time invalidates the entry.
+	createTempGoMod(t, root, "module example.com/renamed\n")
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(goModPath, later, later); err != nil {
+		t.Fatalf("Failed to update go.mod mtime: %v", err)
+	}
+
+	name, _, err = cachedFindModulePath(startDir)
+	if err != nil {
+		t.Fatalf("cachedFindModulePath() after edit error = %v", err)
+	}
+	if name != "example.com/renamed" {
+		t.Errorf("cachedFindModulePath() after edit = %q, want %q", name, "example.com/renamed")
+	}
+
+	// Removing go.mod drops the entry and surfaces the lookup error.
+	if err := os.Remove(goModPath); err != nil {
+		t.Fatalf("Failed to remove go.mod: %v", err)
+	}
+	if _, _, err := cachedFindModulePath(startDir); err == nil {
+		t.Error("expected error after go.mod removal, got nil")
+	}
+	moduleCacheMu.Lock()
+	_, cached = moduleCache[filepath.Clean(startDir)]
+	moduleCacheMu.Unlock()
+	if cached {
+		t.Error("expected cache entry to be dropped after lookup error")
+	}
+}
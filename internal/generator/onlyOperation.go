@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// restrictToOperation narrows a conversion to the operation selected by OnlyOperation.
+// It returns the tools to write files for (just the selected one) and the tools to register:
+// the selected tool plus every other tool whose file already exists in the output directory,
+// so existing registrations are merged rather than replaced.
+func (g *Generator) restrictToOperation(config *converter.MCPConfig) (generated, registered *converter.MCPConfig, err error) {
+	selected := -1
+	for i, tool := range config.Tools {
+		if matchesOperation(tool, g.OnlyOperation) {
+			selected = i
+			break
+		}
+	}
+	if selected == -1 {
+		return nil, nil, fmt.Errorf("no operation matches %q", g.OnlyOperation)
+	}
+
+	generated = &converter.MCPConfig{
+		Server:   config.Server,
+		Tools:    []converter.Tool{config.Tools[selected]},
+		Warnings: config.Warnings,
+	}
+
+	registered = &converter.MCPConfig{
+		Server:   config.Server,
+		Warnings: config.Warnings,
+	}
+	for i, tool := range config.Tools {
+		if i == selected || g.toolFileExists(tool) {
+			registered.Tools = append(registered.Tools, tool)
+		}
+	}
+
+	return generated, registered, nil
+}
+
+// matchesOperation reports whether selector names the tool's operation, either by
+// operationId or as "METHOD /path" (the method is matched case-insensitively)
+func matchesOperation(tool converter.Tool, selector string) bool {
+	if tool.Name == selector {
+		return true
+	}
+	fields := strings.Fields(selector)
+	return len(fields) == 2 && strings.EqualFold(fields[0], tool.Method) && fields[1] == tool.Path
+}
+
+// toolFileExists reports whether a tool file was already generated for the tool
+func (g *Generator) toolFileExists(tool converter.Tool) bool {
+	path := filepath.Join(g.outputDir, "mcptools", capitalizeFirstLetter(tool.Name)+".go")
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func onlyOperationTestConfig() *converter.MCPConfig {
+	return &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "getPet", Method: "GET", Path: "/pets/{id}", RawInputSchema: `{"type":"object"}`},
+			{Name: "listPets", Method: "GET", Path: "/pets", RawInputSchema: `{"type":"object"}`},
+			{Name: "deletePet", Method: "DELETE", Path: "/pets/{id}", RawInputSchema: `{"type":"object"}`},
+		},
+	}
+}
+
+func Test_matchesOperation(t *testing.T) {
+	tool := converter.Tool{Name: "getPet", Method: "GET", Path: "/pets/{id}"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"getPet", true},
+		{"GET /pets/{id}", true},
+		{"get /pets/{id}", true},
+		{"POST /pets/{id}", false},
+		{"GET /pets", false},
+		{"listPets", false},
+	}
+	for _, tt := range tests {
+		if got := matchesOperation(tool, tt.selector); got != tt.want {
+			t.Errorf("matchesOperation(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateMCP_OnlyOperationMergesRegistrations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A previous full run generated listPets only
+	previous := &Generator{
+		PackageName: "mytools",
+		outputDir:   tmpDir,
+		converter:   &testConverter{config: &converter.MCPConfig{Tools: onlyOperationTestConfig().Tools[1:2]}},
+	}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	g := &Generator{
+		PackageName:   "mytools",
+		outputDir:     tmpDir,
+		converter:     &testConverter{config: onlyOperationTestConfig()},
+		OnlyOperation: "GET /pets/{id}",
+	}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "mcptools", "GetPet.go")); err != nil {
+		t.Errorf("expected GetPet.go to be generated: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "mcptools", "DeletePet.go")); !os.IsNotExist(err) {
+		t.Errorf("expected DeletePet.go not to be generated, stat error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("Failed to read register.go: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"NewGetPetMCPTool()", "NewListPetsMCPTool()"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("register.go missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "NewDeletePetMCPTool()") {
+		t.Errorf("register.go should not register a tool without a generated file\n%s", content)
+	}
+}
+
+func TestGenerateMCP_OnlyOperationNoMatch(t *testing.T) {
+	g := &Generator{
+		PackageName:   "mytools",
+		outputDir:     t.TempDir(),
+		converter:     &testConverter{config: onlyOperationTestConfig()},
+		OnlyOperation: "createPet",
+	}
+	err := g.GenerateMCP()
+	if err == nil || !strings.Contains(err.Error(), `no operation matches "createPet"`) {
+		t.Errorf("expected a no-match error, got %v", err)
+	}
+}
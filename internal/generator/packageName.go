@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// defaultPackageName is used when neither a package name nor a usable spec title is available
+const defaultPackageName = "mcpgen"
+
+// packageNameFromTitle derives a lowercase Go package name from a spec title by dropping
+// everything but letters and digits, e.g. "Pet Store API (v2)" becomes "petstoreapiv2".
+func packageNameFromTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" {
+		return defaultPackageName
+	}
+	if unicode.IsDigit(rune(name[0])) || token.IsKeyword(name) {
+		name = "api" + name
+	}
+	return name
+}
+
+// validatePackageName reports whether name can be used as the generated package's name
+func validatePackageName(name string) error {
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("invalid package name %q: must be a valid Go identifier", name)
+	}
+	if name == "_" {
+		return fmt.Errorf("invalid package name %q: the blank identifier cannot name a package", name)
+	}
+	return nil
+}
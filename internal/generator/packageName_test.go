@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPackageNameFromTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Petstore", "petstore"},
+		{"Pet Store API", "petstoreapi"},
+		{"Pet-Store API (v2.1)!", "petstoreapiv21"},
+		{"  Todo_List  ", "todolist"},
+		{"3D Printing Service", "api3dprintingservice"},
+		{"Func", "apifunc"},
+		{"Café Menu", "cafmenu"},
+		{"", defaultPackageName},
+		{"???", defaultPackageName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := packageNameFromTitle(tt.title)
+			if got != tt.want {
+				t.Errorf("packageNameFromTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+			if err := validatePackageName(got); err != nil {
+				t.Errorf("derived package name %q is invalid: %v", got, err)
+			}
+		})
+	}
+}
+
+func TestValidatePackageName(t *testing.T) {
+	for _, name := range []string{"mytools", "my_tools", "v2api"} {
+		if err := validatePackageName(name); err != nil {
+			t.Errorf("validatePackageName(%q) error = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"my-tools", "2api", "my tools", "type", "_", ""} {
+		err := validatePackageName(name)
+		if err == nil || !strings.Contains(err.Error(), "invalid package name") {
+			t.Errorf("validatePackageName(%q) error = %v, want an invalid package name error", name, err)
+		}
+	}
+}
+
+func TestNewGenerator_PackageName(t *testing.T) {
+	specPath := createTempSpecFileWithContent(t, `openapi: 3.0.0
+info:
+  title: Pet Store API
+  version: 1.0.0
+paths: {}
+`)
+
+	g, err := NewGenerator(specPath, false, "", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if g.PackageName != "petstoreapi" {
+		t.Errorf("PackageName = %q, want %q", g.PackageName, "petstoreapi")
+	}
+
+	g, err = NewGenerator(specPath, false, "custom", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if g.PackageName != "custom" {
+		t.Errorf("PackageName = %q, want %q", g.PackageName, "custom")
+	}
+
+	if _, err := NewGenerator(specPath, false, "not-valid", t.TempDir()); err == nil || !strings.Contains(err.Error(), "invalid package name") {
+		t.Errorf("NewGenerator() error = %v, want an invalid package name error", err)
+	}
+}
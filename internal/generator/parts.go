@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Parts of the generated output that Generator.Parts can select
+const (
+	// PartServer is server.go, with NewMCPServer and the transport wiring
+	PartServer = "server"
+	// PartRegister is mcptools/register.go, which registers the tools
+	PartRegister = "register"
+	// PartRuntime is mcptools/runtime.go, with the configuration and request helpers
+	PartRuntime = "runtime"
+	// PartTools is the tool files, including pruning stale ones when Generator.Prune is set
+	PartTools = "tools"
+	// PartHelpers is the helpers package
+	PartHelpers = "helpers"
+	// PartTestClient is testclient.go, written when Generator.TestClient is set
+	PartTestClient = "testclient"
+	// PartManifest is TOOLS.md, written when Generator.Manifest is set
+	PartManifest = "manifest"
+	// PartGoGenerate is generate.go, written when Generator.GoGenerate is set
+	PartGoGenerate = "generate"
+	// PartResponseTypes is mcptools/responses.go, written when Generator.ResponseTypes is set
+	PartResponseTypes = "responses"
+	// PartMockServer is mockserver/main.go, written when Generator.MockServer is set
+	PartMockServer = "mock"
+	// PartHTTPClient is the HTTP client written by GenerateHTTPClient
+	PartHTTPClient = "client"
+)
+
+// allParts lists the parts in the order they are generated
+var allParts = []string{PartServer, PartTestClient, PartRegister, PartRuntime, PartTools, PartHelpers, PartResponseTypes, PartManifest, PartGoGenerate, PartMockServer, PartHTTPClient}
+
+// ParseParts parses a comma-separated list of parts, such as "server,register"; an empty list
+// selects every part
+func ParseParts(list string) ([]string, error) {
+	var parts []string
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return parts, validateParts(parts)
+}
+
+// validateParts reports whether every part is one of the known parts
+func validateParts(parts []string) error {
+	for _, part := range parts {
+		if !slices.Contains(allParts, part) {
+			return fmt.Errorf("unknown part %q: must be one of %s", part, strings.Join(allParts, ", "))
+		}
+	}
+	return nil
+}
+
+// generates reports whether Parts selects part; every part is generated when Parts is empty
+func (g *Generator) generates(part string) bool {
+	return len(g.Parts) == 0 || slices.Contains(g.Parts, part)
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateMCPFromConfig_Parts(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{
+		PackageName: "mytools",
+		outputDir:   tmpDir,
+		converter: &testConverter{config: &converter.MCPConfig{
+			Tools: []converter.Tool{{Name: "echo", RawInputSchema: `{"type":"object"}`}},
+		}},
+	}
+
+	config, err := g.BuildConfig()
+	if err != nil {
+		t.Fatalf("BuildConfig failed: %v", err)
+	}
+
+	g.Parts = []string{PartServer}
+	if err := g.GenerateMCPFromConfig(config); err != nil {
+		t.Fatalf("GenerateMCPFromConfig failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "server.go")); err != nil {
+		t.Errorf("expected server.go to be generated: %v", err)
+	}
+	for _, path := range []string{"mcptools", "helpers"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, path)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be generated with only the server part selected", path)
+		}
+	}
+
+	// The same configuration generates the remaining parts
+	g.Parts = []string{PartRegister, PartTools}
+	if err := g.GenerateMCPFromConfig(config); err != nil {
+		t.Fatalf("GenerateMCPFromConfig failed: %v", err)
+	}
+	for _, path := range []string{"mcptools/register.go", "mcptools/Echo.go"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, path)); err != nil {
+			t.Errorf("expected %s to be generated: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "mcptools", "runtime.go")); !os.IsNotExist(err) {
+		t.Errorf("expected runtime.go not to be generated")
+	}
+
+	g.Parts = []string{"handlers"}
+	if err := g.GenerateMCPFromConfig(config); err == nil {
+		t.Error("expected an error for an unknown part, got nil")
+	}
+}
+
+func TestGenerateHTTPClient_PartNotSelected(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir, Parts: []string{PartServer}}
+	if err := g.GenerateHTTPClient([]string{"types"}); err != nil {
+		t.Fatalf("GenerateHTTPClient failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "apiclient")); !os.IsNotExist(err) {
+		t.Errorf("expected no HTTP client without the client part")
+	}
+}
+
+func TestParseParts(t *testing.T) {
+	got, err := ParseParts(" server, tools ,")
+	if err != nil {
+		t.Fatalf("ParseParts failed: %v", err)
+	}
+	if want := []string{PartServer, PartTools}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseParts() = %v, want %v", got, want)
+	}
+	if got, err := ParseParts(""); err != nil || got != nil {
+		t.Errorf("ParseParts(\"\") = %v, %v, want nil, nil", got, err)
+	}
+	if _, err := ParseParts("server,handlers"); err == nil {
+		t.Error("expected an error for an unknown part, got nil")
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// OutputFile describes a generated file about to be written, for a PostProcessor
+type OutputFile struct {
+	// Path is relative to the output directory and slash-separated, e.g. "mcptools/register.go"
+	Path string
+	// Editable marks tool files: their handler bodies are edited by users and preserved across runs
+	Editable bool
+}
+
+// PostProcessor rewrites a generated file's content before it is compared with the file on disk
+// and written, e.g. to add license headers or run custom AST transforms. Tool files are processed
+// after existing handler implementations have been merged back in.
+type PostProcessor func(file OutputFile, content []byte) ([]byte, error)
+
+// generatedHeader marks a Go file as generated (https://go.dev/s/generatedcode)
+const generatedHeader = "// Code generated by mcpgen. DO NOT EDIT."
+
+// generatedMarker matches any tool's generated-code comment, such as the one oapi-codegen writes
+var generatedMarker = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// addGeneratedHeader prepends the standard "Code generated ... DO NOT EDIT." comment to generated
+// Go files, so editors, linters and reviewers treat them as generated. Editable tool files are
+// hand-maintained and non-Go files cannot carry the comment, so both are left unchanged, as are
+// files already marked as generated.
+func addGeneratedHeader(file OutputFile, content []byte) []byte {
+	if file.Editable || !strings.HasSuffix(file.Path, ".go") || generatedMarker.Match(content) {
+		return content
+	}
+	// The blank line keeps the header from becoming the package doc comment
+	return append([]byte(generatedHeader+"\n\n"), content...)
+}
+
+// writeOutputFile writes a generated file to dir (relative to the output directory) through
+// writeFileContent. Non-editable Go files get the generated-code header, Go files generated from
+// a spec file get its source stamp, then PostProcess runs on the content when one is set.
+func (g *Generator) writeOutputFile(dir, fileName string, editable bool, generateContent func() ([]byte, error)) error {
+	g.recordOutput(path.Join(dir, fileName))
+	return writeFileContent(filepath.Join(g.outputDir, dir), fileName, func() ([]byte, error) {
+		content, err := generateContent()
+		if err != nil {
+			return nil, err
+		}
+		file := OutputFile{Path: path.Join(dir, fileName), Editable: editable}
+		content = addGeneratedHeader(file, content)
+		content = g.addSourceStamp(file, content)
+		if g.PostProcess == nil {
+			return content, nil
+		}
+		processed, err := g.PostProcess(file, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to post-process %s: %w", file.Path, err)
+		}
+		return processed, nil
+	})
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"errors"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMCP_PostProcess(t *testing.T) {
+	tmpDir := t.TempDir()
+	var seen []OutputFile
+	g := &Generator{
+		PackageName: "mytools",
+		outputDir:   tmpDir,
+		converter:   &testConverter{config: onlyOperationTestConfig()},
+		Manifest:    true,
+		PostProcess: func(file OutputFile, content []byte) ([]byte, error) {
+			seen = append(seen, file)
+			return append([]byte("// Licensed under MIT\n\n"), content...), nil
+		},
+	}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	var paths []string
+	editable := make(map[string]bool)
+	for _, file := range seen {
+		paths = append(paths, file.Path)
+		editable[file.Path] = file.Editable
+	}
+	sort.Strings(paths)
+	for _, want := range []string{"TOOLS.md", "helpers/Paramhelpers.go", "mcptools/GetPet.go", "mcptools/register.go", "mcptools/runtime.go", "server.go"} {
+		if !containsString(paths, want) {
+			t.Errorf("PostProcess not called for %s, got %v", want, paths)
+		}
+	}
+	if !editable["mcptools/GetPet.go"] || editable["mcptools/register.go"] {
+		t.Errorf("only tool files should be editable, got %v", editable)
+	}
+
+	for _, path := range []string{"server.go", "mcptools/GetPet.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, path))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !strings.HasPrefix(string(content), "// Licensed under MIT\n") {
+			t.Errorf("%s was not post-processed:\n%s", path, content)
+		}
+	}
+}
+
+func TestGenerateMCP_PostProcessError(t *testing.T) {
+	g := &Generator{
+		PackageName: "mytools",
+		outputDir:   t.TempDir(),
+		converter:   &testConverter{config: onlyOperationTestConfig()},
+		PostProcess: func(file OutputFile, content []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	err := g.GenerateMCP()
+	if err == nil || !strings.Contains(err.Error(), "failed to post-process server.go: boom") {
+		t.Errorf("expected a post-process error, got %v", err)
+	}
+}
+
+func TestAddGeneratedHeader(t *testing.T) {
+	const source = "package mcptools\n"
+	const marked = "// Package apiclient provides primitives.\n//\n// Code generated by oapi-codegen version v2 DO NOT EDIT.\npackage apiclient\n"
+	tests := []struct {
+		name    string
+		file    OutputFile
+		content string
+		want    string
+	}{
+		{"generated go file", OutputFile{Path: "mcptools/register.go"}, source, generatedHeader + "\n\n" + source},
+		{"editable tool file", OutputFile{Path: "mcptools/GetPet.go", Editable: true}, source, source},
+		{"non-go file", OutputFile{Path: "TOOLS.md"}, source, source},
+		{"already marked", OutputFile{Path: "apiclient/HTTPClient.go"}, marked, marked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addGeneratedHeader(tt.file, []byte(tt.content))
+			if string(got) != tt.want {
+				t.Errorf("addGeneratedHeader() = %q, want %q", got, tt.want)
+			}
+			if again := addGeneratedHeader(tt.file, got); string(again) != tt.want {
+				t.Errorf("addGeneratedHeader is not idempotent: %q", again)
+			}
+		})
+	}
+}
+
+func TestGenerateMCP_GeneratedHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: onlyOperationTestConfig()}}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+	// Regenerating must keep a single header and the tool files' handlers
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	for path, wantHeader := range map[string]bool{
+		"server.go":               true,
+		"mcptools/register.go":    true,
+		"mcptools/runtime.go":     true,
+		"helpers/Paramhelpers.go": true,
+		"mcptools/GetPet.go":      false,
+	} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, path))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if hasHeader := strings.HasPrefix(string(content), generatedHeader+"\n\n"); hasHeader != wantHeader {
+			t.Errorf("%s: header present = %v, want %v", path, hasHeader, wantHeader)
+		}
+		if n := strings.Count(string(content), generatedHeader); n > 1 {
+			t.Errorf("%s has %d headers", path, n)
+		}
+		formatted, err := format.Source(content)
+		if err != nil || string(formatted) != string(content) {
+			t.Errorf("%s is not gofmt-clean (err = %v)", path, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// pruneToolFiles deletes tool files in mcptools/ that belong to operations no longer in config.
+// Only files proven to be generated tool files are removed (see isGeneratedToolFile), along with
+// their schema files; anything else in the directory is left alone. Returns the deleted paths relative to the output directory.
+func (g *Generator) pruneToolFiles(config *converter.MCPConfig) ([]string, error) {
+	toolsDir := filepath.Join(g.outputDir, "mcptools")
+	entries, err := os.ReadDir(toolsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", toolsDir, err)
+	}
+
+	current := make(map[string]bool, len(config.Tools))
+	for _, tool := range config.Tools {
+		current[capitalizeFirstLetter(tool.Name)+".go"] = true
+	}
+
+	var deleted []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || current[name] || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(toolsDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !isGeneratedToolFile(name, content) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return deleted, fmt.Errorf("failed to delete stale tool file %s: %w", path, err)
+		}
+		deleted = append(deleted, "mcptools/"+name)
+
+		schemaFiles, err := g.removeSchemaFiles(strings.TrimSuffix(name, ".go"))
+		deleted = append(deleted, schemaFiles...)
+		if err != nil {
+			return deleted, err
+		}
+	}
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+// isGeneratedToolFile reports whether fileName holds a tool file written by GenerateToolFiles:
+// Go source in package mcptools declaring the <Name>InputSchema constant (a variable when the schema
+// is embedded from a file) and the New<Name>MCPTool
+// and <Name>Handler functions, where <Name> is the file name without its extension
+func isGeneratedToolFile(fileName string, content []byte) bool {
+	name := strings.TrimSuffix(fileName, ".go")
+	if name == "" || name == fileName {
+		return false
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), fileName, content, parser.SkipObjectResolution)
+	if err != nil || file.Name.Name != "mcptools" {
+		return false
+	}
+
+	var hasSchema, hasTool, hasHandler bool
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil {
+				continue
+			}
+			switch decl.Name.Name {
+			case "New" + name + "MCPTool":
+				hasTool = true
+			case name + "Handler":
+				hasHandler = true
+			}
+		case *ast.GenDecl:
+			if decl.Tok != token.CONST && decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					// InputSchemaConst keeps the operationId's original case
+					if strings.EqualFold(ident.Name, name+"InputSchema") {
+						hasSchema = true
+					}
+				}
+			}
+		}
+	}
+	return hasSchema && hasTool && hasHandler
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateMCP_Prune(t *testing.T) {
+	tmpDir := t.TempDir()
+	toolsDir := filepath.Join(tmpDir, "mcptools")
+
+	previous := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: onlyOperationTestConfig()}}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	// Hand-written files must survive pruning, even ones that look like tools
+	handwritten := map[string]string{
+		"Helpers.go":      "package mcptools\n\nfunc Helper() {}\n",
+		"FakeTool.go":     "package mcptools\n\nfunc NewFakeToolMCPTool() {}\n",
+		"Broken.go":       "package mcptools\n\nfunc NewBrokenMCPTool( {\n",
+		"Other_test.go":   "package mcptools\n",
+		"ListPetsCopy.go": "package other\n",
+	}
+	for name, content := range handwritten {
+		if err := os.WriteFile(filepath.Join(toolsDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	// deletePet was removed from the spec
+	config := &converter.MCPConfig{Tools: onlyOperationTestConfig().Tools[:2]}
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, Prune: true}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(toolsDir, "DeletePet.go")); !os.IsNotExist(err) {
+		t.Errorf("expected DeletePet.go to be pruned, stat error = %v", err)
+	}
+	for _, name := range []string{"GetPet.go", "ListPets.go", "register.go", "runtime.go"} {
+		if _, err := os.Stat(filepath.Join(toolsDir, name)); err != nil {
+			t.Errorf("expected %s to be kept: %v", name, err)
+		}
+	}
+	for name := range handwritten {
+		if _, err := os.Stat(filepath.Join(toolsDir, name)); err != nil {
+			t.Errorf("expected hand-written %s to be kept: %v", name, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(toolsDir, "register.go"))
+	if err != nil {
+		t.Fatalf("Failed to read register.go: %v", err)
+	}
+	if strings.Contains(string(data), "DeletePet") {
+		t.Errorf("register.go still references the pruned tool\n%s", data)
+	}
+}
+
+func TestGenerateMCP_NoPruneKeepsStaleFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	previous := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: onlyOperationTestConfig()}}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	config := &converter.MCPConfig{Tools: onlyOperationTestConfig().Tools[:2]}
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "mcptools", "DeletePet.go")); err != nil {
+		t.Errorf("expected DeletePet.go to be kept without Prune: %v", err)
+	}
+}
+
+func TestRegenerate_SummaryReportsDeletions(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	outDir := filepath.Join(dir, "out")
+	writeWatchSpec(t, specPath, "listPets", "getPet")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", outDir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+	g.Prune = true
+	if _, err := g.Regenerate(specPath); err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+
+	writeWatchSpec(t, specPath, "listPets")
+	summary, err := g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if !containsString(summary.Deleted, "mcptools/GetPet.go") {
+		t.Errorf("expected GetPet.go to be deleted, got %v", summary.Deleted)
+	}
+	if len(summary.Deleted) != 1 {
+		t.Errorf("expected only GetPet.go to be deleted, got %v", summary.Deleted)
+	}
+}
+
+func Test_isGeneratedToolFile(t *testing.T) {
+	tool := "package mcptools\n\nconst getPetInputSchema = `{}`\n\nfunc NewGetPetMCPTool() mcp.Tool { return mcp.Tool{} }\n\nfunc GetPetHandler() {}\n"
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		want     bool
+	}{
+		{"generated", "GetPet.go", tool, true},
+		{"renamed", "Pet.go", tool, false},
+		{"other package", "GetPet.go", strings.Replace(tool, "package mcptools", "package tools", 1), false},
+		{"missing handler", "GetPet.go", strings.Replace(tool, "GetPetHandler", "Handle", 1), false},
+		{"missing schema", "GetPet.go", strings.Replace(tool, "getPetInputSchema", "schema", 1), false},
+		{"unparsable", "GetPet.go", tool + "func (", false},
+		{"not go", "GetPet.txt", tool, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGeneratedToolFile(tt.fileName, []byte(tt.content)); got != tt.want {
+				t.Errorf("isGeneratedToolFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
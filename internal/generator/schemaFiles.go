@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// Formats the input schemas and response templates of generated tools can be written in
+const (
+	// SchemaFormatInline embeds them in the tool files as Go string constants (the default)
+	SchemaFormatInline = "inline"
+	// SchemaFormatJSON writes them to files under mcptools/schemas that the tool files load with
+	// go:embed: <Tool>.input.json for the input schema and <Tool>.response_<suffix>.md for each
+	// response template
+	SchemaFormatJSON = "json"
+)
+
+// embedImport is the blank import go:embed needs in files that embed into string variables
+const embedImport = `_ "embed"`
+
+// schemaDir holds the SchemaFormatJSON files, relative to the mcptools package as go:embed requires
+const schemaDir = "schemas"
+
+// validateSchemaFormat reports whether format is a known SchemaFormat; empty means SchemaFormatInline
+func validateSchemaFormat(format string) error {
+	switch format {
+	case "", SchemaFormatInline, SchemaFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid schema format %q: must be %s or %s", format, SchemaFormatInline, SchemaFormatJSON)
+}
+
+// toolSchemaDir returns the directory tool files embed their schemas from, or "" when they are inline
+func (g *Generator) toolSchemaDir() string {
+	if g.SchemaFormat == SchemaFormatJSON {
+		return schemaDir
+	}
+	return ""
+}
+
+// writeSchemaFiles writes a tool's input schema and response templates to mcptools/schemas,
+// named as tool.templ embeds them
+func (g *Generator) writeSchemaFiles(toolName string, tool converter.Tool) error {
+	dir := path.Join("mcptools", schemaDir)
+	files := map[string]string{toolName + ".input.json": tool.RawInputSchema}
+	for _, response := range tool.Responses {
+		files[toolName+".response_"+response.Suffix+".md"] = response.PrependBody
+	}
+	for fileName, content := range files {
+		content := content
+		if err := g.writeOutputFile(dir, fileName, false, func() ([]byte, error) {
+			return []byte(content), nil
+		}); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path.Join(dir, fileName), err)
+		}
+	}
+	return nil
+}
+
+// removeSchemaFiles deletes the schema files written for a tool by writeSchemaFiles, if any,
+// returning their paths relative to the output directory
+func (g *Generator) removeSchemaFiles(toolName string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(g.outputDir, "mcptools", schemaDir, toolName+".*"))
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil {
+			return removed, fmt.Errorf("failed to delete %s: %w", match, err)
+		}
+		removed = append(removed, path.Join("mcptools", schemaDir, filepath.Base(match)))
+	}
+	return removed, nil
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func schemaFilesTestConfig() *converter.MCPConfig {
+	config := onlyOperationTestConfig()
+	config.Tools[0].Responses = []converter.ResponseTemplate{
+		{StatusCode: 200, ContentType: "application/json", Suffix: "200_application_json", PrependBody: "# Pet\n"},
+	}
+	return config
+}
+
+func TestGenerateMCP_SchemaFormatJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := schemaFilesTestConfig()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, SchemaFormat: SchemaFormatJSON}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	schemas := filepath.Join(tmpDir, "mcptools", "schemas")
+	files := map[string]string{
+		"GetPet.input.json":                       config.Tools[0].RawInputSchema,
+		"GetPet.response_200_application_json.md": "# Pet\n",
+		"ListPets.input.json":                     config.Tools[1].RawInputSchema,
+	}
+	for name, want := range files {
+		data, err := os.ReadFile(filepath.Join(schemas, name))
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "GetPet.go"))
+	if err != nil {
+		t.Fatalf("Failed to read GetPet.go: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		`_ "embed"`,
+		"//go:embed schemas/GetPet.input.json\nvar getPetInputSchema string",
+		"//go:embed schemas/GetPet.response_200_application_json.md\nvar GetPetResponseTemplate_200_application_json string",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("GetPet.go missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "const getPetInputSchema") {
+		t.Errorf("GetPet.go still inlines the schema\n%s", content)
+	}
+}
+
+func TestGenerateMCP_SchemaFormatJSONKeepsHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := schemaFilesTestConfig()
+	previous := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "mcptools", "GetPet.go")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read GetPet.go: %v", err)
+	}
+	content := replaceHandlerImplementation(string(data), "GetPetHandler", "{\n\t// custom\n\treturn nil, nil\n}\n")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write GetPet.go: %v", err)
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, SchemaFormat: SchemaFormatJSON}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read GetPet.go: %v", err)
+	}
+	if !strings.Contains(string(data), "// custom") || !strings.Contains(string(data), `_ "embed"`) {
+		t.Errorf("expected the custom handler and the embed import\n%s", data)
+	}
+}
+
+func TestGenerateMCP_PruneRemovesSchemaFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	previous := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: schemaFilesTestConfig()}, SchemaFormat: SchemaFormatJSON}
+	if err := previous.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	// getPet was removed from the spec
+	config := &converter.MCPConfig{Tools: schemaFilesTestConfig().Tools[1:]}
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: config}, SchemaFormat: SchemaFormatJSON, Prune: true}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	schemas := filepath.Join(tmpDir, "mcptools", "schemas")
+	for _, name := range []string{"GetPet.input.json", "GetPet.response_200_application_json.md"} {
+		if _, err := os.Stat(filepath.Join(schemas, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be pruned, stat error = %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(schemas, "ListPets.input.json")); err != nil {
+		t.Errorf("expected ListPets.input.json to be kept: %v", err)
+	}
+}
+
+func TestGenerateMCP_InvalidSchemaFormat(t *testing.T) {
+	g := &Generator{PackageName: "mytools", outputDir: t.TempDir(), converter: &testConverter{config: onlyOperationTestConfig()}, SchemaFormat: "yaml"}
+	if err := g.GenerateMCP(); err == nil || !strings.Contains(err.Error(), "invalid schema format") {
+		t.Errorf("GenerateMCP() error = %v, want an invalid schema format error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemaIndentCompact writes generated input schemas without any whitespace, which keeps the
+// embedded constants as small as possible
+const SchemaIndentCompact = "compact"
+
+// defaultSchemaIndent is the indent the converter marshals input schemas with
+const defaultSchemaIndent = "  "
+
+// ParseSchemaIndent converts a -schema-indent flag value into a Generator.SchemaIndent:
+// a number of spaces, "tab" or "compact". An empty value keeps the default two spaces.
+func ParseSchemaIndent(value string) (string, error) {
+	switch value {
+	case "":
+		return "", nil
+	case "tab":
+		return "\t", nil
+	case SchemaIndentCompact:
+		return SchemaIndentCompact, nil
+	}
+	spaces, err := strconv.Atoi(value)
+	if err != nil || spaces < 1 || spaces > 8 {
+		return "", fmt.Errorf("invalid schema indent %q: must be a number of spaces from 1 to 8, tab or compact", value)
+	}
+	return strings.Repeat(" ", spaces), nil
+}
+
+// validateSchemaIndent reports whether indent is usable as a SchemaIndent: only spaces and tabs,
+// or SchemaIndentCompact; empty means the default two spaces
+func validateSchemaIndent(indent string) error {
+	if indent == SchemaIndentCompact || strings.Trim(indent, " \t") == "" {
+		return nil
+	}
+	return fmt.Errorf("invalid schema indent %q: must be spaces, tabs or %s", indent, SchemaIndentCompact)
+}
+
+// formatSchema re-indents a generated JSON schema with g.SchemaIndent. Object keys keep the order
+// the converter wrote them in, so the output is deterministic for every setting.
+func (g *Generator) formatSchema(schema string) (string, error) {
+	if g.SchemaIndent == "" || g.SchemaIndent == defaultSchemaIndent || schema == "" {
+		return schema, nil
+	}
+	var buf bytes.Buffer
+	var err error
+	if g.SchemaIndent == SchemaIndentCompact {
+		err = json.Compact(&buf, []byte(schema))
+	} else {
+		err = json.Indent(&buf, []byte(schema), "", g.SchemaIndent)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to format input schema: %w", err)
+	}
+	return buf.String(), nil
+}
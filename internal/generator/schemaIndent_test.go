@@ -0,0 +1,51 @@
+package generator
+
+import "testing"
+
+func TestFormatSchema(t *testing.T) {
+	schema := "{\n  \"type\": \"object\",\n  \"properties\": {\n    \"b\": {\n      \"type\": \"string\"\n    },\n    \"a\": {\n      \"type\": \"integer\"\n    }\n  }\n}"
+	tests := []struct {
+		indent string
+		want   string
+	}{
+		{"", schema},
+		{"  ", schema},
+		{SchemaIndentCompact, `{"type":"object","properties":{"b":{"type":"string"},"a":{"type":"integer"}}}`},
+		{"\t", "{\n\t\"type\": \"object\",\n\t\"properties\": {\n\t\t\"b\": {\n\t\t\t\"type\": \"string\"\n\t\t},\n\t\t\"a\": {\n\t\t\t\"type\": \"integer\"\n\t\t}\n\t}\n}"},
+	}
+	for _, tt := range tests {
+		g := &Generator{SchemaIndent: tt.indent}
+		got, err := g.formatSchema(schema)
+		if err != nil {
+			t.Fatalf("formatSchema(%q) failed: %v", tt.indent, err)
+		}
+		if got != tt.want {
+			t.Errorf("formatSchema(%q) = %q, want %q", tt.indent, got, tt.want)
+		}
+	}
+}
+
+func TestParseSchemaIndent(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"2", "  ", false},
+		{"4", "    ", false},
+		{"tab", "\t", false},
+		{"compact", SchemaIndentCompact, false},
+		{"0", "", true},
+		{"wide", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSchemaIndent(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSchemaIndent(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if err := validateSchemaIndent("--"); err == nil {
+		t.Error("expected an error for an indent that is not whitespace")
+	}
+}
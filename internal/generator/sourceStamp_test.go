@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSourceStamp(t *testing.T) {
+	dir := t.TempDir()
+	g := &Generator{
+		specPath:  filepath.Join(dir, "specs", "petstore.yaml"),
+		outputDir: filepath.Join(dir, "out"),
+		spec:      &openapi3.T{Info: &openapi3.Info{Version: "1.2.0"}},
+	}
+	want := "// Source: ../specs/petstore.yaml (version 1.2.0), generated by mcpgen " + mcpgenVersion()
+	if got := g.sourceStamp(); got != want {
+		t.Errorf("sourceStamp() = %q, want %q", got, want)
+	}
+
+	generated := g.addSourceStamp(OutputFile{Path: "server.go"}, []byte(generatedHeader+"\n\npackage out\n"))
+	if want := generatedHeader + "\n" + g.sourceStamp() + "\n\npackage out\n"; string(generated) != want {
+		t.Errorf("stamped server.go = %q, want %q", generated, want)
+	}
+	tool := g.addSourceStamp(OutputFile{Path: "mcptools/GetPet.go", Editable: true}, []byte("package mcptools\n"))
+	if want := g.sourceStamp() + "\n\npackage mcptools\n"; string(tool) != want {
+		t.Errorf("stamped tool file = %q, want %q", tool, want)
+	}
+	if manifest := g.addSourceStamp(OutputFile{Path: "TOOLS.md"}, []byte("# Tools\n")); string(manifest) != "# Tools\n" {
+		t.Errorf("expected non-Go files to be left alone, got %q", manifest)
+	}
+
+	// Generators that were not created from a spec file stamp nothing
+	g = &Generator{outputDir: dir}
+	if content := g.addSourceStamp(OutputFile{Path: "server.go"}, []byte("package out\n")); strings.Contains(string(content), "Source:") {
+		t.Errorf("expected no stamp without a spec path, got %q", content)
+	}
+}
+
+func TestSourceStamp_StableAcrossLocalBuilds(t *testing.T) {
+	defer func(original func() (*debug.BuildInfo, bool)) { readBuildInfo = original }(readBuildInfo)
+	dir := t.TempDir()
+	g := &Generator{specPath: filepath.Join(dir, "petstore.yaml"), outputDir: dir}
+	stampWith := func(version string) string {
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: version}}, true
+		}
+		return g.sourceStamp()
+	}
+
+	// Each commit of a VCS checkout builds with its own pseudo-version
+	first := stampWith("v0.0.0-20261017220702-419070f4e52e")
+	for _, version := range []string{"v0.4.2-0.20261018090000-0123456789ab", "v0.4.1+dirty", "(devel)"} {
+		if got := stampWith(version); got != first {
+			t.Errorf("stamp for %s = %q, want the same as for other local builds, %q", version, got, first)
+		}
+	}
+	if want := "// Source: petstore.yaml, generated by mcpgen (devel)"; first != want {
+		t.Errorf("sourceStamp() = %q, want %q", first, want)
+	}
+	if got, want := stampWith("v0.4.1"), "// Source: petstore.yaml, generated by mcpgen v0.4.1"; got != want {
+		t.Errorf("sourceStamp() = %q, want %q", got, want)
+	}
+}
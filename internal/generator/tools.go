@@ -10,6 +10,9 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -28,105 +31,203 @@ func (g *Generator) GenerateToolFiles(config *converter.MCPConfig) error {
 		return fmt.Errorf("failed to parse tool template: %w", err)
 	}
 
-	for _, tool := range config.Tools {
-		capitalizedName := capitalizeFirstLetter(tool.Name)
-		data := struct {
-			ToolTemplateData
-			URL     string
-			Method  string
-			Headers []converter.Header
-		}{
-			ToolTemplateData: ToolTemplateData{
-				ToolNameOriginal:      capitalizedName,
-				ToolNameGo:            capitalizedName,
-				ToolHandlerName:       capitalizedName + "Handler",
-				ToolDescription:       tool.Description,
-				RawInputSchema:        tool.RawInputSchema,
-				ResponseTemplate:      tool.Responses,
-				InputSchemaConst:      fmt.Sprintf("%sInputSchema", tool.Name),
-				ResponseTemplateConst: fmt.Sprintf("%sResponseTemplate", tool.Name),
-			},
-			URL:     tool.RequestTemplate.URL,
-			Method:  tool.RequestTemplate.Method,
-			Headers: tool.RequestTemplate.Headers,
-		}
-
-		outputFileName := capitalizedName + ".go"
-		outputFilePath := filepath.Join(g.outputDir+"/mcptools", outputFileName)
-
-		// Check if file already exists and extract handler implementation if it does
-		existingImplementation := ""
-		existingImports := []string{}
-
-		if _, err := os.Stat(outputFilePath); err == nil {
-			existingContent, err := os.ReadFile(outputFilePath)
-			if err == nil {
-				existingImplementation, err = extractHandlerImplementation(string(existingContent), data.ToolHandlerName)
-				if err != nil {
-					return err
-				}
-				// Extract existing imports
-				existingImports = extractImports(string(existingContent))
-			}
-		}
-
-		// Generate code for this tool
-		var toolBuf bytes.Buffer
+	return runConcurrently(len(config.Tools), g.concurrency(), func(i int) error {
+		return g.generateToolFile(tmpl, config.Tools[i], config.Server.URL)
+	})
+}
 
-		// Write package declaration
-		fmt.Fprintf(&toolBuf, "package mcptools\n\n")
+// generateToolFile renders a single tool file, preserving an existing handler implementation.
+// serverURL is the URL runtime.go declares as ServerURL.
+func (g *Generator) generateToolFile(tmpl *template.Template, tool converter.Tool, serverURL string) error {
+	capitalizedName := capitalizeFirstLetter(tool.Name)
+	rawInputSchema, err := g.formatSchema(tool.RawInputSchema)
+	if err != nil {
+		return fmt.Errorf("failed to generate tool %s: %w", tool.Name, err)
+	}
+	tool.RawInputSchema = rawInputSchema
+	data := struct {
+		ToolTemplateData
+		URL      string
+		RouteURL string // Go expression for URL, built on the ServerURL constant when it can be
+		Method   string
+		Headers  []converter.Header
+		Args     []converter.Arg
+		// SchemaDir is set when the schemas are embedded from files instead of inlined
+		SchemaDir string
+		mcpImportData
+	}{
+		ToolTemplateData: ToolTemplateData{
+			ToolNameOriginal:      capitalizedName,
+			ToolNameGo:            capitalizedName,
+			ToolNameRegistered:    tool.RegisteredName(),
+			ToolHandlerName:       capitalizedName + "Handler",
+			ToolDescription:       tool.Description,
+			RawInputSchema:        tool.RawInputSchema,
+			ResponseTemplate:      tool.Responses,
+			InputSchemaConst:      fmt.Sprintf("%sInputSchema", tool.Name),
+			ResponseTemplateConst: fmt.Sprintf("%sResponseTemplate", tool.Name),
+			HasProblemDetails:     hasProblemDetails(tool.Responses),
+			ErrorFields:           collectErrorFields(tool.Responses),
+			ResponseContentTypes:  collectResponseContentTypes(tool.Responses),
+		},
+		URL:           tool.RequestTemplate.URL,
+		RouteURL:      routeURLExpr(tool.RequestTemplate.URL, serverURL),
+		Method:        tool.RequestTemplate.Method,
+		Headers:       tool.RequestTemplate.Headers,
+		Args:          tool.Args,
+		SchemaDir:     g.toolSchemaDir(),
+		mcpImportData: g.mcpImport(),
+	}
 
-		// Merge imports
-		requiredImports := []string{
-			"context",
-			"fmt",
-			"github.com/mark3labs/mcp-go/mcp",
+	if data.SchemaDir != "" {
+		if err := g.writeSchemaFiles(capitalizedName, tool); err != nil {
+			return err
 		}
+	}
+
+	outputFileName := capitalizedName + ".go"
+	outputFilePath := filepath.Join(g.outputDir+"/mcptools", outputFileName)
+
+	// Check if file already exists and extract handler implementation if it does
+	existingImplementation := ""
+	existingImports := []string{}
 
-		if len(existingImports) > 0 {
-			fmt.Fprintf(&toolBuf, "import (\n")
-			for _, imp := range existingImports {
-				fmt.Fprintf(&toolBuf, "\t%s\n", imp)
+	if _, err := os.Stat(outputFilePath); err == nil {
+		existingContent, err := os.ReadFile(outputFilePath)
+		if err == nil {
+			existingImplementation, err = extractHandlerImplementation(string(existingContent), data.ToolHandlerName)
+			if err != nil {
+				return err
 			}
-			fmt.Fprintf(&toolBuf, ")\n\n")
-		} else {
-			fmt.Fprintf(&toolBuf, "import (\n")
-			for _, imp := range requiredImports {
-				fmt.Fprintf(&toolBuf, "\t\"%s\"\n", imp)
+			// A kept implementation refers to mcp by the name the file imported it under
+			existingImplementation = renameQualifier(existingImplementation, fileMCPQualifier(string(existingContent)), data.MCP)
+			// Extract existing imports
+			existingImports = mergeMCPImport(extractImports(string(existingContent)), data.MCPImport)
+			if data.SchemaDir != "" {
+				existingImports = addImport(existingImports, embedImport)
 			}
-			fmt.Fprintf(&toolBuf, ")\n\n")
 		}
+	}
 
-		// Execute template to get the boilerplate
-		if err := tmpl.Execute(&toolBuf, data); err != nil {
-			return fmt.Errorf("failed to render template for tool %s: %w", tool.Name, err)
-		}
+	// Generate code for this tool
+	var toolBuf bytes.Buffer
 
-		// If we have an existing implementation, replace the default one
-		if existingImplementation != "" {
-			toolContent := toolBuf.String()
-			toolContent = replaceHandlerImplementation(toolContent, data.ToolHandlerName, existingImplementation)
-			toolBuf.Reset()
-			toolBuf.WriteString(toolContent)
-		}
+	// Write package declaration
+	fmt.Fprintf(&toolBuf, "package mcptools\n\n")
 
-		// Format the generated code
-		formattedCode, err := format.Source(toolBuf.Bytes())
-		if err != nil {
-			return fmt.Errorf("failed to format generated code for %s: %w", outputFileName, err)
+	// Merge imports
+	requiredImports := []string{
+		strconv.Quote("context"),
+		strconv.Quote("fmt"),
+		data.MCPImport,
+	}
+	if data.SchemaDir != "" {
+		requiredImports = append(requiredImports, embedImport)
+	}
+
+	if len(existingImports) > 0 {
+		fmt.Fprintf(&toolBuf, "import (\n")
+		for _, imp := range existingImports {
+			fmt.Fprintf(&toolBuf, "\t%s\n", imp)
+		}
+		fmt.Fprintf(&toolBuf, ")\n\n")
+	} else {
+		fmt.Fprintf(&toolBuf, "import (\n")
+		for _, imp := range requiredImports {
+			fmt.Fprintf(&toolBuf, "\t%s\n", imp)
 		}
+		fmt.Fprintf(&toolBuf, ")\n\n")
+	}
 
-		err = writeFileContent(g.outputDir+"/mcptools", outputFileName, func() ([]byte, error) {
-			return formattedCode, nil
-		})
+	// Execute template to get the boilerplate
+	if err := tmpl.Execute(&toolBuf, data); err != nil {
+		return fmt.Errorf("failed to render template for tool %s: %w", tool.Name, err)
+	}
 
-		if err != nil {
-			return fmt.Errorf("failed to write %s: %w", outputFileName, err)
-		}
+	// If we have an existing implementation, replace the default one
+	if existingImplementation != "" {
+		toolContent := toolBuf.String()
+		toolContent = replaceHandlerImplementation(toolContent, data.ToolHandlerName, existingImplementation)
+		toolBuf.Reset()
+		toolBuf.WriteString(toolContent)
+	}
+
+	// Format the generated code
+	formattedCode, err := format.Source(toolBuf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code for %s: %w", outputFileName, err)
+	}
+
+	err = g.writeOutputFile("mcptools", outputFileName, true, func() ([]byte, error) {
+		return formattedCode, nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFileName, err)
 	}
 
 	return nil
 }
+
+// collectResponseContentTypes lists the distinct content types documented for a tool's
+// successful (2xx/3xx) responses, in declaration order
+func collectResponseContentTypes(responses []converter.ResponseTemplate) []string {
+	var contentTypes []string
+	for _, response := range responses {
+		success := response.StatusCode >= 200 && response.StatusCode < 400
+		// Status ranges are recorded with code 0, so fall back to the suffix for 2XX/3XX
+		if response.StatusCode == 0 {
+			success = strings.HasPrefix(response.Suffix, "2xx_") || strings.HasPrefix(response.Suffix, "3xx_")
+		}
+		if !success {
+			continue
+		}
+		for _, contentType := range append([]string{response.ContentType}, response.AlternateContentTypes...) {
+			if contentType != "" && !slices.Contains(contentTypes, contentType) {
+				contentTypes = append(contentTypes, contentType)
+			}
+		}
+	}
+	return contentTypes
+}
+
+// collectErrorFields merges the documented error fields of a tool's responses by status code
+func collectErrorFields(responses []converter.ResponseTemplate) []StatusErrorFields {
+	var result []StatusErrorFields
+	index := make(map[int]int)
+	for _, response := range responses {
+		if len(response.ErrorFields) == 0 {
+			continue
+		}
+		i, ok := index[response.StatusCode]
+		if !ok {
+			i = len(result)
+			index[response.StatusCode] = i
+			result = append(result, StatusErrorFields{StatusCode: response.StatusCode})
+		}
+		for _, field := range response.ErrorFields {
+			if !slices.Contains(result[i].Fields, field) {
+				result[i].Fields = append(result[i].Fields, field)
+			}
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].StatusCode < result[b].StatusCode })
+	for i := range result {
+		sort.Strings(result[i].Fields)
+	}
+	return result
+}
+
+// hasProblemDetails reports whether any response of a tool is an RFC 7807 problem details error
+func hasProblemDetails(responses []converter.ResponseTemplate) bool {
+	for _, response := range responses {
+		if response.ProblemDetails {
+			return true
+		}
+	}
+	return false
+}
+
 func capitalizeFirstLetter(s string) string {
 	if len(s) == 0 {
 		return s
@@ -161,6 +262,11 @@ func extractHandlerImplementation(fileContent, handlerName string) (string, erro
 		return "", nil
 	}
 
+	qualifier, ok := importQualifier(f, mcpImportPath)
+	if !ok {
+		qualifier = "mcp"
+	}
+
 	var foundBodies []string
 
 	for _, decl := range f.Decls {
@@ -168,17 +274,18 @@ func extractHandlerImplementation(fileContent, handlerName string) (string, erro
 		if !ok || fn.Name.Name != handlerName {
 			continue
 		}
-		// Check signature: (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		// Check signature: (ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error),
+		// with mcp being whatever name the file imports the package by
 		if len(fn.Type.Params.List) != 2 || len(fn.Type.Results.List) != 2 {
 			continue
 		}
 		param2 := fn.Type.Params.List[1]
 		result1 := fn.Type.Results.List[0]
 
-		if exprToString(param2.Type) != "mcp.CallToolRequest" {
+		if exprToString(param2.Type) != qualifier+".CallToolRequest" {
 			continue
 		}
-		if exprToString(result1.Type) != "*mcp.CallToolResult" {
+		if exprToString(result1.Type) != "*"+qualifier+".CallToolResult" {
 			continue
 		}
 
@@ -241,3 +348,18 @@ func exprToString(expr ast.Expr) string {
 	printer.Fprint(&buf, token.NewFileSet(), expr)
 	return buf.String()
 }
+
+// routeURLExpr returns the Go expression of a route URL for tool files: ServerURL followed by the
+// operation path when the URL starts with the server URL, so the base URL is declared once in
+// runtime.go, and the quoted URL otherwise (e.g. for operations with their own servers)
+func routeURLExpr(routeURL, serverURL string) string {
+	serverURL = strings.TrimRight(serverURL, "/")
+	rest, ok := strings.CutPrefix(routeURL, serverURL)
+	if serverURL == "" || !ok || rest != "" && !strings.HasPrefix(rest, "/") {
+		return strconv.Quote(routeURL)
+	}
+	if rest == "" {
+		return "ServerURL"
+	}
+	return "ServerURL + " + strconv.Quote(rest)
+}
@@ -517,3 +517,377 @@ func EchoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 		t.Errorf("Custom handler implementation was not preserved in Echo.go")
 	}
 }
+
+func TestGenerateToolFiles_Headers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "listItems",
+				RawInputSchema: `{"type":"object"}`,
+				RequestTemplate: converter.RequestTemplate{
+					URL:    "https://api.example.com/items",
+					Method: "GET",
+					Headers: []converter.Header{
+						{Key: "Content-Type", Value: "application/json"},
+						{Key: "X-API-Key", Value: "${API_KEY}"},
+					},
+				},
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "ListItems.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+
+	expected := []string{
+		"var ListItemsHeaders = map[string]string{",
+		`"Content-Type": "application/json",`,
+		`"X-API-Key":    "${API_KEY}",`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated tool file missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateToolFiles_ProblemDetailsHint(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "getItem",
+				RawInputSchema: `{"type":"object"}`,
+				Responses: []converter.ResponseTemplate{
+					{PrependBody: "ok", StatusCode: 200, ContentType: "application/json", Suffix: "200_application_json"},
+					{PrependBody: "problem", StatusCode: 404, ContentType: "application/problem+json", Suffix: "404_application_problem_json", ProblemDetails: true},
+				},
+			},
+			{
+				Name:           "ping",
+				RawInputSchema: `{"type":"object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	withProblems, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "GetItem.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if !strings.Contains(string(withProblems), "ProblemResult") {
+		t.Errorf("expected a ProblemResult hint in GetItem.go")
+	}
+
+	withoutProblems, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "Ping.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if strings.Contains(string(withoutProblems), "ProblemResult") {
+		t.Errorf("did not expect a ProblemResult hint in Ping.go")
+	}
+}
+
+func TestGenerateToolFiles_ErrorFields(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "getItem",
+				RawInputSchema: `{"type":"object"}`,
+				Responses: []converter.ResponseTemplate{
+					{PrependBody: "ok", StatusCode: 200, ContentType: "application/json", Suffix: "200_application_json"},
+					{PrependBody: "missing", StatusCode: 404, ContentType: "application/json", Suffix: "404_application_json", ErrorFields: []string{"message"}},
+					{PrependBody: "missing", StatusCode: 404, ContentType: "application/xml", Suffix: "404_application_xml", ErrorFields: []string{"code", "message"}},
+					{PrependBody: "error", StatusCode: 0, ContentType: "application/json", Suffix: "default_application_json", ErrorFields: []string{"error"}},
+				},
+			},
+			{
+				Name:           "ping",
+				RawInputSchema: `{"type":"object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	withErrors, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "GetItem.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, want := range []string{
+		"var GetItemErrorFields = map[int][]string{",
+		`0:   {"error"},`,
+		`404: {"code", "message"},`,
+		"ErrorResult(resp.StatusCode, resp.Header.Get(\"Content-Type\"), body, GetItemErrorFields)",
+	} {
+		if !strings.Contains(string(withErrors), want) {
+			t.Errorf("GetItem.go missing %q\n%s", want, withErrors)
+		}
+	}
+
+	withoutErrors, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "Ping.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if strings.Contains(string(withoutErrors), "PingErrorFields") {
+		t.Errorf("did not expect an error fields map in Ping.go")
+	}
+	if !strings.Contains(string(withoutErrors), "body, nil), nil") {
+		t.Errorf("expected an ErrorResult hint with nil fields in Ping.go")
+	}
+}
+
+func TestGenerateToolFiles_Route(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "updatePet",
+				RawInputSchema: `{"type":"object"}`,
+				Args: []converter.Arg{
+					{Name: "X-Request-Id", Source: "header"},
+					{Name: "body", Source: "body"},
+					{Name: "dryRun", Source: "query"},
+					{Name: "filter", Source: "query", AllowReserved: true, AllowEmptyValue: true},
+					{Name: "petId", Source: "path"},
+					{Name: "session", Source: "cookie"},
+				},
+				RequestTemplate: converter.RequestTemplate{
+					URL:     "https://api.example.com/pets/{petId}",
+					Method:  "PUT",
+					Headers: []converter.Header{{Key: "Content-Type", Value: "application/json"}},
+				},
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "UpdatePet.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, want := range []string{
+		"var UpdatePetRoute = Route{",
+		`Method:  "PUT",`,
+		`URL:     "https://api.example.com/pets/{petId}",`,
+		"Headers: UpdatePetHeaders,",
+		`{Name: "X-Request-Id", In: "header"},`,
+		`{Name: "body", In: "body"},`,
+		`{Name: "dryRun", In: "query"},`,
+		`{Name: "filter", In: "query", AllowReserved: true, AllowEmptyValue: true},`,
+		`{Name: "petId", In: "path"},`,
+		`{Name: "session", In: "cookie"},`,
+		"Send(ctx, UpdatePetRoute, request.GetArguments())",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("UpdatePet.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateToolFiles_RouteOnServerURL(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Server: converter.ServerConfig{URL: "https://api.example.com/v1/"},
+		Tools: []converter.Tool{
+			{
+				Name:           "getPet",
+				RawInputSchema: `{"type":"object"}`,
+				RequestTemplate: converter.RequestTemplate{
+					URL:    "https://api.example.com/v1/pets/{petId}",
+					Method: "GET",
+				},
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "GetPet.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, want := range []string{
+		`URL:     ServerURL + "/pets/{petId}",`,
+		// Comments keep the full URL for documentation
+		"(GET https://api.example.com/v1/pets/{petId})",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("GetPet.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func Test_routeURLExpr(t *testing.T) {
+	tests := []struct {
+		routeURL  string
+		serverURL string
+		want      string
+	}{
+		{"https://api.example.com/v1/pets", "https://api.example.com/v1", `ServerURL + "/pets"`},
+		{"https://api.example.com/v1", "https://api.example.com/v1/", "ServerURL"},
+		{"/v1/pets", "/v1", `ServerURL + "/pets"`},
+		// Not on a path boundary, another server, or no server at all
+		{"https://api.example.com/v10/pets", "https://api.example.com/v1", `"https://api.example.com/v10/pets"`},
+		{"https://uploads.example.com/files", "https://api.example.com", `"https://uploads.example.com/files"`},
+		{"/pets", "", `"/pets"`},
+	}
+	for _, tt := range tests {
+		if got := routeURLExpr(tt.routeURL, tt.serverURL); got != tt.want {
+			t.Errorf("routeURLExpr(%q, %q) = %s, want %s", tt.routeURL, tt.serverURL, got, tt.want)
+		}
+	}
+}
+
+func Test_collectResponseContentTypes(t *testing.T) {
+	responses := []converter.ResponseTemplate{
+		{StatusCode: 200, ContentType: "application/json", Suffix: "200_application_json"},
+		{StatusCode: 200, ContentType: "text/plain", Suffix: "200_text_plain"},
+		{StatusCode: 0, ContentType: "application/octet-stream", Suffix: "2xx_application_octet_stream"},
+		{StatusCode: 201, ContentType: "application/hal+json", AlternateContentTypes: []string{"application/json", "application/vnd.api+json"}, Suffix: "201_application_hal_json"},
+		{StatusCode: 201, ContentType: "application/json", Suffix: "201_application_json"},
+		{StatusCode: 404, ContentType: "application/xml", Suffix: "404_application_xml"},
+		{StatusCode: 0, ContentType: "text/html", Suffix: "default_text_html"},
+	}
+
+	got := collectResponseContentTypes(responses)
+	want := []string{"application/json", "text/plain", "application/octet-stream", "application/hal+json", "application/vnd.api+json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("collectResponseContentTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateToolFiles_ResponseContentTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "download",
+				RawInputSchema: `{"type":"object"}`,
+				Responses: []converter.ResponseTemplate{
+					{PrependBody: "ok", StatusCode: 200, ContentType: "application/json", Suffix: "200_application_json"},
+					{PrependBody: "ok", StatusCode: 200, ContentType: "text/csv", Suffix: "200_text_csv"},
+				},
+			},
+			{
+				Name:           "ping",
+				RawInputSchema: `{"type":"object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	download, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "Download.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, want := range []string{
+		`var DownloadResponseContentTypes = []string{"application/json", "text/csv"}`,
+		"FormatResponse(resp.Header.Get(\"Content-Type\"), body, DownloadResponseContentTypes)",
+	} {
+		if !strings.Contains(string(download), want) {
+			t.Errorf("Download.go missing %q\n%s", want, download)
+		}
+	}
+
+	ping, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "Ping.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if strings.Contains(string(ping), "PingResponseContentTypes") {
+		t.Errorf("did not expect a response content types var in Ping.go")
+	}
+}
+
+func TestGenerateToolFiles_NoResponseTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "deleteItem",
+				RawInputSchema: `{"type":"object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "DeleteItem.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	content := string(data)
+
+	if strings.Contains(content, "DeleteItemResponseTemplate") {
+		t.Errorf("expected no response template const when no response schema is documented\n%s", content)
+	}
+	if !strings.Contains(content, "// No response schema documented for the DeleteItem tool") {
+		t.Errorf("expected a note about the missing response schema\n%s", content)
+	}
+}
+
+func TestGenerateToolFiles_MultilineDescription(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:           "createItem",
+				Description:    "Create an item\n\n## Example Request\n\n```\n{\"name\": \"widget\"}\n```",
+				RawInputSchema: `{"type":"object"}`,
+			},
+		},
+	}
+
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateToolFiles(config); err != nil {
+		t.Fatalf("GenerateToolFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "CreateItem.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	want := `"Create an item\n\n## Example Request\n\n` + "```" + `\n{\"name\": \"widget\"}\n` + "```" + `"`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected the description as an escaped string literal %s\n%s", want, data)
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CompileError reports that the generated code does not build, with the compiler's diagnostics
+type CompileError struct {
+	Dir         string
+	Diagnostics []CompileDiagnostic
+	// Output is the raw go build output, kept for failures that are not tied to a file
+	Output string
+}
+
+// CompileDiagnostic is one compiler error in a generated file
+type CompileDiagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error lists the offending files and their diagnostics, one per line
+func (e *CompileError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "generated code in %s does not compile:", e.Dir)
+	if len(e.Diagnostics) == 0 {
+		b.WriteString("\n" + strings.TrimSpace(e.Output))
+		return b.String()
+	}
+	for _, d := range e.Diagnostics {
+		fmt.Fprintf(&b, "\n  %s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	}
+	return b.String()
+}
+
+// compileDiagnosticPattern matches go build diagnostics such as "tools/get.go:12:3: undefined: x"
+var compileDiagnosticPattern = regexp.MustCompile(`^(\S+\.go):(\d+)(?::(\d+))?: (.+)$`)
+
+// verifyBuild runs go build on the output directory and returns a *CompileError when the
+// generated code does not compile. The output directory must be inside a Go module that
+// provides the generated code's dependencies.
+func (g *Generator) verifyBuild() error {
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = g.outputDir
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to run go build: %w", err)
+	}
+	return newCompileError(g.outputDir, string(output))
+}
+
+// newCompileError parses go build output run in dir, resolving file paths against dir
+func newCompileError(dir, output string) *CompileError {
+	compileErr := &CompileError{Dir: dir, Output: output}
+	for _, line := range strings.Split(output, "\n") {
+		match := compileDiagnosticPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		file := match[1]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		lineNumber, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		compileErr.Diagnostics = append(compileErr.Diagnostics, CompileDiagnostic{
+			File:    file,
+			Line:    lineNumber,
+			Column:  column,
+			Message: match[4],
+		})
+	}
+	return compileErr
+}
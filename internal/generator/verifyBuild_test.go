@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyBuild(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	write("go.mod", "module example.com/generated\n\ngo 1.21\n")
+	write("server.go", "package generated\n\nfunc Name() string { return \"ok\" }\n")
+
+	g := &Generator{outputDir: dir}
+	if err := g.verifyBuild(); err != nil {
+		t.Fatalf("verifyBuild failed on valid code: %v", err)
+	}
+
+	write("tools.go", "package generated\n\nfunc Broken() int {\n\treturn undefinedName\n}\n")
+	err := g.verifyBuild()
+	var compileErr *CompileError
+	if !errors.As(err, &compileErr) {
+		t.Fatalf("expected a *CompileError, got %v", err)
+	}
+	if len(compileErr.Diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic, got %+v\n%s", compileErr.Diagnostics, compileErr.Output)
+	}
+	d := compileErr.Diagnostics[0]
+	if d.File != filepath.Join(dir, "tools.go") || d.Line != 4 || !strings.Contains(d.Message, "undefinedName") {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+	if !strings.Contains(err.Error(), filepath.Join(dir, "tools.go")+":4:") {
+		t.Errorf("error does not point at the offending file: %v", err)
+	}
+}
+
+func TestCompileError_WithoutDiagnostics(t *testing.T) {
+	err := newCompileError("out", "go: cannot find main module\n")
+	if len(err.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", err.Diagnostics)
+	}
+	if want := "generated code in out does not compile:\ngo: cannot find main module"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
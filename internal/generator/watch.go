@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// watchDebounce is how long Watch waits for changes to settle before regenerating; a variable so
+// tests can shorten it
+var watchDebounce = 500 * time.Millisecond
+
+// GenerationSummary lists the output files a generation run created, changed or deleted
+type GenerationSummary struct {
+	Created []string
+	Updated []string
+	Deleted []string
+}
+
+// Watch regenerates the MCP code whenever the spec at specPath, its overlay or a local file one of
+// its $refs points to changes, until ctx is cancelled. Changes are reported by fsnotify on the
+// files' directories, so editors that save by replacing the file are followed, and rapid edits are
+// coalesced: regeneration runs once no change has been seen for the debounce period. Parse and
+// generation errors are reported and the loop keeps watching. Returns nil when ctx is cancelled.
+func (g *Generator) Watch(ctx context.Context, specPath string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", specPath, err)
+	}
+	defer watcher.Close()
+
+	if len(g.sources) == 0 {
+		g.sources = []string{specPath}
+	}
+	watched, err := watchSources(watcher, g.sources)
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", specPath, err)
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if watched[filepath.Clean(event.Name)] && !event.Has(fsnotify.Chmod) {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("Error watching %s: %v\n", specPath, err)
+		case <-debounce.C:
+			summary, err := g.Regenerate(specPath)
+			if err != nil {
+				fmt.Printf("Error regenerating from %s: %v\n", specPath, err)
+				continue
+			}
+			printSummary(summary)
+			// The overlay or the $refs may have changed with the spec
+			if watched, err = watchSources(watcher, g.sources); err != nil {
+				fmt.Printf("Error watching %s: %v\n", specPath, err)
+			}
+		}
+	}
+}
+
+// watchSources points watcher at the directories holding files, dropping directories it no longer
+// needs, and returns the set of watched files by absolute path. Directories are watched rather
+// than the files themselves so that a file replaced on save keeps being followed.
+func watchSources(watcher *fsnotify.Watcher, files []string) (map[string]bool, error) {
+	watched := make(map[string]bool, len(files))
+	dirs := make(map[string]bool)
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		watched[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	for _, dir := range watcher.WatchList() {
+		if !dirs[dir] {
+			_ = watcher.Remove(dir)
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return nil, err
+		}
+	}
+	return watched, nil
+}
+
+// specSources lists the local files a parsed spec was read from
+func specSources(specPath string, options converter.ConvertOptions, parser *converter.Parser) []string {
+	sources := []string{specPath}
+	if options.OverlayPath != "" {
+		sources = append(sources, options.OverlayPath)
+	}
+	return append(sources, parser.ReferencedFiles()...)
+}
+
+// Regenerate re-parses the spec at specPath and reruns GenerateHTTPClient, when it has been run, and
+// GenerateMCP, reporting which output files changed. Only generated files are compared: those the
+// previous run wrote and those this one writes. The generator keeps its previous spec if parsing fails.
+func (g *Generator) Regenerate(specPath string) (GenerationSummary, error) {
+	parser := converter.NewParser(g.validation)
+	parser.OverlayPath = g.options.OverlayPath
+	parser.AllowRemoteRefs = g.options.AllowRemoteRefs
+	parser.Format = g.options.SpecFormat
+	if err := parser.ParseFile(specPath); err != nil {
+		return GenerationSummary{}, fmt.Errorf("error parsing OpenAPI specification: %w", err)
+	}
+	if g.options.NormalizeEnumNames {
+		converter.NormalizeEnumNames(parser.GetDocument())
+	}
+	g.specPath = specPath
+	g.sources = specSources(specPath, g.options, parser)
+	g.converter = converter.NewConverterWithOptions(parser, g.options)
+	g.spec = parser.GetDocument()
+
+	previous := g.takeOutputs()
+	before, err := snapshotFiles(g.outputDir, previous)
+	if err != nil {
+		return GenerationSummary{}, err
+	}
+	if len(g.clientIncludes) > 0 {
+		if err := g.GenerateHTTPClient(g.clientIncludes); err != nil {
+			return GenerationSummary{}, fmt.Errorf("error generating HTTP client: %w", err)
+		}
+	}
+	if err := g.GenerateMCP(); err != nil {
+		return GenerationSummary{}, err
+	}
+	after, err := snapshotFiles(g.outputDir, append(previous, g.writtenOutputs()...))
+	if err != nil {
+		return GenerationSummary{}, err
+	}
+
+	var summary GenerationSummary
+	for path, sum := range after {
+		prev, ok := before[path]
+		switch {
+		case !ok:
+			summary.Created = append(summary.Created, path)
+		case prev != sum:
+			summary.Updated = append(summary.Updated, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			summary.Deleted = append(summary.Deleted, path)
+		}
+	}
+	sort.Strings(summary.Created)
+	sort.Strings(summary.Updated)
+	sort.Strings(summary.Deleted)
+	return summary, nil
+}
+
+// outputRecord collects the paths of written output files. Tool files are written concurrently,
+// hence the lock.
+type outputRecord struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+// recordOutput notes that a run wrote the file at path, relative to the output directory. Nothing
+// is recorded for generators built without NewGenerator until their first Regenerate.
+func (g *Generator) recordOutput(path string) {
+	if g.outputs == nil {
+		return
+	}
+	g.outputs.mu.Lock()
+	defer g.outputs.mu.Unlock()
+	if g.outputs.paths == nil {
+		g.outputs.paths = make(map[string]bool)
+	}
+	g.outputs.paths[path] = true
+}
+
+// writtenOutputs returns the files written since the last takeOutputs
+func (g *Generator) writtenOutputs() []string {
+	if g.outputs == nil {
+		return nil
+	}
+	g.outputs.mu.Lock()
+	defer g.outputs.mu.Unlock()
+	paths := make([]string, 0, len(g.outputs.paths))
+	for path := range g.outputs.paths {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// takeOutputs returns the files written since the last takeOutputs and starts a new record
+func (g *Generator) takeOutputs() []string {
+	paths := g.writtenOutputs()
+	g.outputs = &outputRecord{}
+	return paths
+}
+
+// snapshotFiles hashes the files at paths, relative to dir, skipping those that do not exist
+func snapshotFiles(dir string, paths []string) (map[string][sha256.Size]byte, error) {
+	sums := make(map[string][sha256.Size]byte, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+		sums[path] = sha256.Sum256(data)
+	}
+	return sums, nil
+}
+
+func printSummary(summary GenerationSummary) {
+	if len(summary.Created) == 0 && len(summary.Updated) == 0 && len(summary.Deleted) == 0 {
+		fmt.Println("Regenerated: no changes")
+		return
+	}
+	fmt.Printf("Regenerated: %d created, %d updated, %d deleted\n", len(summary.Created), len(summary.Updated), len(summary.Deleted))
+	for _, path := range summary.Created {
+		fmt.Printf("  created %s\n", path)
+	}
+	for _, path := range summary.Updated {
+		fmt.Printf("  updated %s\n", path)
+	}
+	for _, path := range summary.Deleted {
+		fmt.Printf("  deleted %s\n", path)
+	}
+}
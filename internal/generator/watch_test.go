@@ -0,0 +1,287 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func writeWatchSpec(t *testing.T, path string, operationIDs ...string) {
+	t.Helper()
+	spec := "openapi: 3.0.0\ninfo:\n  title: Watch\n  version: 1.0.0\npaths:\n"
+	for _, id := range operationIDs {
+		spec += fmt.Sprintf("  /%s:\n    get:\n      operationId: %s\n      responses:\n        '200':\n          description: OK\n", id, id)
+	}
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+}
+
+func TestRegenerate_Summary(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	outDir := filepath.Join(dir, "out")
+	writeWatchSpec(t, specPath, "listPets")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", outDir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+
+	summary, err := g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if !containsString(summary.Created, "mcptools/ListPets.go") {
+		t.Errorf("expected ListPets.go to be created, got %v", summary.Created)
+	}
+	if len(summary.Updated) != 0 {
+		t.Errorf("expected no updates on first run, got %v", summary.Updated)
+	}
+
+	writeWatchSpec(t, specPath, "listPets", "getPet")
+	summary, err = g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if !containsString(summary.Created, "mcptools/GetPet.go") {
+		t.Errorf("expected GetPet.go to be created, got %v", summary.Created)
+	}
+	if !containsString(summary.Updated, "mcptools/register.go") {
+		t.Errorf("expected register.go to be updated, got %v", summary.Updated)
+	}
+	if containsString(summary.Updated, "mcptools/ListPets.go") {
+		t.Errorf("expected ListPets.go to be unchanged, got %v", summary.Updated)
+	}
+}
+
+func TestRegenerate_ParseErrorKeepsPreviousSpec(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	writeWatchSpec(t, specPath, "listPets")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+	previous := g.spec
+
+	if err := os.WriteFile(specPath, []byte("openapi: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	if _, err := g.Regenerate(specPath); err == nil {
+		t.Fatal("expected parse error, got nil")
+	}
+	if g.spec != previous {
+		t.Error("expected generator to keep its previous spec after a parse error")
+	}
+}
+
+func TestWatch_RegeneratesOnChange(t *testing.T) {
+	origDebounce := watchDebounce
+	watchDebounce = 30 * time.Millisecond
+	defer func() { watchDebounce = origDebounce }()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	outDir := filepath.Join(dir, "out")
+	writeWatchSpec(t, specPath, "listPets")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", outDir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- g.Watch(ctx, specPath) }()
+
+	// A broken intermediate save must not stop the loop
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(specPath, []byte("openapi: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	writeWatchSpec(t, specPath, "listPets", "getPet")
+
+	target := filepath.Join(outDir, "mcptools", "GetPet.go")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(target); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			cancel()
+			t.Fatalf("timed out waiting for %s", target)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Watch returned %v after cancellation, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not stop after context cancellation")
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRegenerate_AppliesOverlay(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	outDir := filepath.Join(dir, "out")
+	writeWatchSpec(t, specPath, "listPets", "getPet")
+	overlay := "overlay: 1.0.0\ninfo:\n  title: Hide getPet\n  version: 1.0.0\nactions:\n  - target: $.paths['/getPet']\n    remove: true\n"
+	if err := os.WriteFile(overlayPath, []byte(overlay), 0644); err != nil {
+		t.Fatalf("Failed to write overlay: %v", err)
+	}
+
+	g, err := NewGeneratorWithOptions(specPath, false, "watchpkg", outDir, converter.ConvertOptions{OverlayPath: overlayPath})
+	if err != nil {
+		t.Fatalf("NewGeneratorWithOptions failed: %v", err)
+	}
+	if _, ok := g.spec.Paths.Map()["/getPet"]; ok {
+		t.Errorf("expected the overlay to remove /getPet")
+	}
+
+	writeWatchSpec(t, specPath, "listPets", "getPet", "deletePet")
+	summary, err := g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if containsString(summary.Created, "mcptools/GetPet.go") {
+		t.Errorf("expected the overlay to be reapplied on regeneration, got %v", summary.Created)
+	}
+	if !containsString(summary.Created, "mcptools/DeletePet.go") {
+		t.Errorf("expected DeletePet.go to be created, got %v", summary.Created)
+	}
+}
+
+func TestWatch_RegeneratesOnRefChange(t *testing.T) {
+	origDebounce := watchDebounce
+	watchDebounce = 30 * time.Millisecond
+	defer func() { watchDebounce = origDebounce }()
+
+	dir := t.TempDir()
+	refDir := filepath.Join(dir, "schemas")
+	if err := os.Mkdir(refDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(dir, "spec.yaml")
+	refPath := filepath.Join(refDir, "paths.yaml")
+	outDir := filepath.Join(dir, "out")
+	spec := "openapi: 3.0.0\ninfo:\n  title: Watch\n  version: 1.0.0\npaths:\n  /pets:\n    $ref: 'schemas/paths.yaml#/pets'\n"
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeRef := func(operationID string) {
+		t.Helper()
+		ref := fmt.Sprintf("pets:\n  get:\n    operationId: %s\n    responses:\n      '200':\n        description: OK\n", operationID)
+		if err := os.WriteFile(refPath, []byte(ref), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeRef("listPets")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", outDir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Watch(ctx, specPath)
+
+	time.Sleep(50 * time.Millisecond)
+	writeRef("findPets")
+
+	target := filepath.Join(outDir, "mcptools", "FindPets.go")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(target); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", target)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRegenerate_OnlyComparesGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	writeWatchSpec(t, specPath, "listPets")
+	userFile := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(userFile, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGenerator(specPath, false, "watchpkg", dir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	// Deleting a file the generator did not write is not reported
+	if err := os.Remove(userFile); err != nil {
+		t.Fatal(err)
+	}
+	writeWatchSpec(t, specPath)
+	summary, err := g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if containsString(summary.Deleted, "notes.txt") || containsString(summary.Created, "spec.yaml") {
+		t.Errorf("expected only generated files in the summary, got %+v", summary)
+	}
+	if !containsString(summary.Updated, "mcptools/register.go") {
+		t.Errorf("expected register.go to be updated, got %v", summary.Updated)
+	}
+}
+
+func TestRegenerate_RerunsHTTPClient(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	outDir := filepath.Join(dir, "out")
+	writeWatchSpec(t, specPath, "listPets")
+
+	g, err := NewGenerator(specPath, false, "watchpkg", outDir)
+	if err != nil {
+		t.Fatalf("NewGenerator failed: %v", err)
+	}
+	if err := g.GenerateHTTPClient([]string{"httpclient"}); err != nil {
+		t.Fatalf("GenerateHTTPClient failed: %v", err)
+	}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	writeWatchSpec(t, specPath, "listPets", "getPet")
+	summary, err := g.Regenerate(specPath)
+	if err != nil {
+		t.Fatalf("Regenerate failed: %v", err)
+	}
+	if !containsString(summary.Updated, "apiclient/HTTPClient.go") {
+		t.Errorf("expected the HTTP client to be regenerated, got %+v", summary)
+	}
+}
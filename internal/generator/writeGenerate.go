@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// generateFileName is the file holding the go:generate directive, next to server.go
+const generateFileName = "generate.go"
+
+// GenerateGoGenerateFile writes generate.go next to server.go with a go:generate directive that
+// reruns mcpgen with the spec path, output directory and package name of this generator followed
+// by GenerateFlags, so go generate ./... reproduces the output. The spec path and the paths of the
+// -overlay, -overrides and -glossary flags are made relative to the output directory, where go
+// generate runs the command. An existing generate.go is left
+// untouched so customized directives survive regeneration.
+func (g *Generator) GenerateGoGenerateFile() error {
+	if _, err := os.Stat(filepath.Join(g.outputDir, generateFileName)); err == nil {
+		fmt.Printf("Keeping existing %s; delete it to record the current command\n", generateFileName)
+		return nil
+	}
+
+	generateTemplateContent, err := templatesFS.ReadFile("templates/generate.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read generate template file: %w", err)
+	}
+
+	tmpl, err := template.New("generate.templ").Parse(string(generateTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse generate template: %w", err)
+	}
+
+	data := struct {
+		PackageName string
+		Command     string
+	}{
+		PackageName: g.PackageName,
+		Command:     g.generateCommand(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render generate template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %w", generateFileName, err)
+	}
+
+	if err := g.writeOutputFile("", generateFileName, true, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write %s file: %w", generateFileName, err)
+	}
+
+	return nil
+}
+
+// generateCommand returns the mcpgen command recorded in generate.go, run with go run at the
+// running mcpgen version when it is a released one. Other builds are not pinned: "(devel)" and
+// modified (+dirty) builds have no version, and the pseudo-version Go stamps into builds from a
+// VCS checkout names a commit the module proxy may not serve. The directive then needs mcpgen in
+// the module's requirements.
+func (g *Generator) generateCommand() string {
+	command := modulePath + "/cmd/mcpgen"
+	if version := mcpgenVersion(); isReleasedVersion(version) {
+		command += "@" + version
+	}
+
+	args := []string{"go", "run", command, "-input", g.relativeToOutput(g.specPath), "-output", ".", "-package", g.PackageName}
+	for _, arg := range g.GenerateFlags {
+		name, value, ok := strings.Cut(strings.TrimPrefix(arg, "-"), "=")
+		if ok && value != "" && slices.Contains(generatePathFlags, name) {
+			arg = "-" + name + "=" + g.relativeToOutput(value)
+		}
+		args = append(args, arg)
+	}
+	for i, arg := range args {
+		args[i] = generateArg(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// isReleasedVersion reports whether version is a tagged release, as opposed to "(devel)", a
+// pseudo-version or a build with +metadata
+func isReleasedVersion(version string) bool {
+	return semver.IsValid(version) && semver.Build(version) == "" && !module.IsPseudoVersion(version)
+}
+
+// generatePathFlags are the mcpgen flags taking a file path, which generateCommand rebases like
+// the spec path
+var generatePathFlags = []string{"overlay", "overrides", "glossary"}
+
+// relativeToOutput returns path relative to the output directory, where go generate runs the
+// command, or path unchanged when it cannot be made relative
+func (g *Generator) relativeToOutput(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	absOutput, err := filepath.Abs(g.outputDir)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(absOutput, absPath)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// generateArg quotes an argument of a go:generate directive when go generate would otherwise split
+// or misread it: directives are split on spaces and arguments may be Go double-quoted strings
+func generateArg(arg string) string {
+	if arg == "" || strings.ContainsAny(arg, " \t\"\\") {
+		return strconv.Quote(arg)
+	}
+	return arg
+}
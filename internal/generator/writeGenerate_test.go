@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoGenerateFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{
+		specPath:      filepath.Join(tmpDir, "openapi.yaml"),
+		outputDir:     filepath.Join(tmpDir, "server"),
+		PackageName:   "petstore",
+		GenerateFlags: []string{"-includes=types", "-glossary=" + filepath.Join(tmpDir, "team glossary.yaml"), "-overrides=" + filepath.Join(tmpDir, "server", "ov.yaml"), "-overlay="},
+	}
+	if err := g.GenerateGoGenerateFile(); err != nil {
+		t.Fatalf("GenerateGoGenerateFile failed: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "server", "generate.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read generate.go: %v", err)
+	}
+	for _, want := range []string{
+		"package petstore",
+		"//go:generate go run github.com/lyeskara/testmcp/cmd/mcpgen",
+		` -input ../openapi.yaml -output . -package petstore -includes=types "-glossary=../team glossary.yaml" -overrides=ov.yaml -overlay=` + "\n",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("generate.go missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "DO NOT EDIT") {
+		t.Errorf("generate.go is meant to be edited, got the generated-code header\n%s", content)
+	}
+
+	// A customized directive survives regeneration
+	customized := []byte("package petstore\n\n//go:generate go run ./tools/regen\n")
+	if err := os.WriteFile(path, customized, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.GenerateGoGenerateFile(); err != nil {
+		t.Fatalf("GenerateGoGenerateFile failed: %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != string(customized) {
+		t.Errorf("expected the existing generate.go to be kept, got:\n%s", content)
+	}
+}
+
+func Test_generateArg(t *testing.T) {
+	tests := map[string]string{
+		"-retries=2":      "-retries=2",
+		"a b":             `"a b"`,
+		`say "hi"`:        `"say \"hi\""`,
+		"":                `""`,
+		`C:\specs\a.yaml`: `"C:\\specs\\a.yaml"`,
+	}
+	for arg, want := range tests {
+		if got := generateArg(arg); got != want {
+			t.Errorf("generateArg(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
+
+func Test_isReleasedVersion(t *testing.T) {
+	tests := map[string]bool{
+		"v0.4.1":                               true,
+		"v1.2.0-rc.1":                          true,
+		"(devel)":                              false,
+		"":                                     false,
+		"v0.0.0-20261017220702-419070f4e52e":   false,
+		"v0.4.2-0.20261017220702-419070f4e52e": false,
+		"v0.4.1+dirty":                         false,
+	}
+	for version, want := range tests {
+		if got := isReleasedVersion(version); got != want {
+			t.Errorf("isReleasedVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
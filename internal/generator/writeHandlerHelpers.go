@@ -35,7 +35,7 @@ func (g *Generator) GenerateHelpers() error {
 		return fmt.Errorf("failed to format generated helpers code: %w", err)
 	}
 
-	err = writeFileContent(g.outputDir + "/helpers", "Paramhelpers.go", func() ([]byte, error) {
+	err = g.writeOutputFile("helpers", "Paramhelpers.go", false, func() ([]byte, error) {
 		return formattedCode, nil
 	})
 	if err != nil {
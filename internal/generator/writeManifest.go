@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// GenerateManifest writes a TOOLS.md file describing every tool exposed by the generated server
+func (g *Generator) GenerateManifest(config *converter.MCPConfig) error {
+	if err := g.writeOutputFile("", "TOOLS.md", false, func() ([]byte, error) {
+		return []byte(renderManifest(config)), nil
+	}); err != nil {
+		return fmt.Errorf("failed to write TOOLS.md file: %w", err)
+	}
+	return nil
+}
+
+// renderManifest builds the Markdown manifest for the tools in config
+func renderManifest(config *converter.MCPConfig) string {
+	var b strings.Builder
+	b.WriteString("# Tools\n\n")
+	b.WriteString("This file is generated from the OpenAPI specification. Do not edit it by hand.\n\n")
+	b.WriteString(fmt.Sprintf("The server exposes %d tool(s).\n", len(config.Tools)))
+
+	for _, tool := range config.Tools {
+		b.WriteString(fmt.Sprintf("\n## %s\n\n", tool.RegisteredName()))
+		if tool.Description != "" {
+			b.WriteString(fmt.Sprintf("%s\n\n", tool.Description))
+		}
+		b.WriteString(fmt.Sprintf("**Endpoint:** `%s %s`\n\n", tool.RequestTemplate.Method, tool.RequestTemplate.URL))
+
+		if len(tool.Args) == 0 {
+			b.WriteString("_No input parameters._\n")
+			continue
+		}
+		b.WriteString("| Name | In | Type | Required | Description |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, arg := range tool.Args {
+			required := "no"
+			if arg.Required {
+				required = "yes"
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				arg.Name, arg.Source, manifestArgType(arg), required, manifestCell(arg.Description)))
+		}
+	}
+
+	return b.String()
+}
+
+// manifestArgType describes an argument's type, listing content types for request bodies
+func manifestArgType(arg converter.Arg) string {
+	if arg.Source == "body" && len(arg.ContentTypes) > 0 {
+		contentTypes := make([]string, 0, len(arg.ContentTypes))
+		for contentType := range arg.ContentTypes {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+		return strings.Join(contentTypes, ", ")
+	}
+	if arg.Schema == nil || len(arg.Schema.Types) == 0 {
+		return "any"
+	}
+	typeDesc := strings.Join(arg.Schema.Types, " \\| ")
+	if arg.Schema.Format != "" {
+		typeDesc += fmt.Sprintf(" (%s)", arg.Schema.Format)
+	}
+	return typeDesc
+}
+
+// manifestCell keeps free text on a single Markdown table row
+func manifestCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{
+				Name:        "getPet",
+				Description: "Fetch a pet | by id",
+				Args: []converter.Arg{
+					{Name: "petId", Source: "path", Required: true, Description: "The pet id", Schema: &converter.Schema{Types: []string{"integer"}, Format: "int64"}},
+					{Name: "body", Source: "body", ContentTypes: map[string]*converter.Schema{"application/xml": {}, "application/json": {}}},
+				},
+				RequestTemplate: converter.RequestTemplate{URL: "https://api.example.com/pets/{petId}", Method: "GET"},
+			},
+			{
+				Name:            "ping",
+				RequestTemplate: converter.RequestTemplate{URL: "https://api.example.com/ping", Method: "GET"},
+			},
+		},
+	}
+
+	if err := g.GenerateManifest(config); err != nil {
+		t.Fatalf("GenerateManifest failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "TOOLS.md"))
+	if err != nil {
+		t.Fatalf("failed to read TOOLS.md: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"The server exposes 2 tool(s).",
+		"## GetPet",
+		"Fetch a pet | by id",
+		"**Endpoint:** `GET https://api.example.com/pets/{petId}`",
+		"| petId | path | integer (int64) | yes | The pet id |",
+		"| body | body | application/json, application/xml | no |  |",
+		"## Ping",
+		"_No input parameters._",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("TOOLS.md missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateMCP_ManifestOptIn(t *testing.T) {
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "echo", RawInputSchema: `{"type":"object"}`}},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		g := &Generator{
+			PackageName: "mytools",
+			Manifest:    enabled,
+			outputDir:   tmpDir,
+			converter:   &testConverter{config: config},
+		}
+		if err := g.GenerateMCP(); err != nil {
+			t.Fatalf("GenerateMCP failed: %v", err)
+		}
+		_, err := os.Stat(filepath.Join(tmpDir, "TOOLS.md"))
+		if exists := err == nil; exists != enabled {
+			t.Errorf("Manifest=%v: TOOLS.md exists = %v", enabled, exists)
+		}
+	}
+}
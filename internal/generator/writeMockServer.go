@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// mockServerDir is the directory of the mock server command, relative to the output directory
+const mockServerDir = "mockserver"
+
+// pathTemplateParam matches the parameters of an OpenAPI path template, e.g. {id}
+var pathTemplateParam = regexp.MustCompile(`\{[^{}]*\}`)
+
+// mockRouteData is an operation served by the mock server
+type mockRouteData struct {
+	Method string
+	Path   string
+	// Pattern is a regular expression matching the request paths of Path
+	Pattern     string
+	Status      int
+	ContentType string
+	Body        string
+	params      int
+}
+
+// GenerateMockServerFile writes mockserver/main.go, a command serving the example of each tool's
+// first success response at its method and path, so the generated tools can be run against it
+// instead of the real API
+func (g *Generator) GenerateMockServerFile(config *converter.MCPConfig) error {
+	mockServerTemplateContent, err := templatesFS.ReadFile("templates/mockserver.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read mock server template file: %w", err)
+	}
+
+	tmpl, err := template.New("mockserver.templ").Parse(string(mockServerTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse mock server template: %w", err)
+	}
+
+	routes := make([]mockRouteData, 0, len(config.Tools))
+	for _, tool := range config.Tools {
+		if tool.Path == "" {
+			continue
+		}
+		route, err := buildMockRoute(tool)
+		if err != nil {
+			return fmt.Errorf("failed to build mock route for %s: %w", tool.Name, err)
+		}
+		routes = append(routes, route)
+	}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].params < routes[j].params })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Routes []mockRouteData }{routes}); err != nil {
+		return fmt.Errorf("failed to render mock server template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated mock server: %w", err)
+	}
+
+	if err := g.writeOutputFile(mockServerDir, "main.go", false, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write mock server file: %w", err)
+	}
+
+	return nil
+}
+
+// buildMockRoute picks the response the mock server sends for a tool: its first 2xx response
+// template, or the default response. Operations without one get 204 No Content.
+func buildMockRoute(tool converter.Tool) (mockRouteData, error) {
+	route := mockRouteData{
+		Method:  strings.ToUpper(tool.Method),
+		Path:    tool.Path,
+		Pattern: mockPathPattern(tool.Path),
+		Status:  http.StatusNoContent,
+		params:  len(pathTemplateParam.FindAllString(tool.Path, -1)),
+	}
+
+	var response *converter.ResponseTemplate
+	for i := range tool.Responses {
+		candidate := &tool.Responses[i]
+		if candidate.StatusCode >= 200 && candidate.StatusCode < 300 {
+			response = candidate
+			break
+		}
+		if candidate.StatusCode == 0 && response == nil {
+			response = candidate
+		}
+	}
+	if response == nil {
+		return route, nil
+	}
+
+	route.Status = response.StatusCode
+	if route.Status == 0 {
+		route.Status = http.StatusOK
+	}
+	if response.Example == nil {
+		return route, nil
+	}
+	route.ContentType = response.ContentType
+	if body, ok := response.Example.(string); ok {
+		route.Body = body
+		return route, nil
+	}
+	body, err := json.MarshalIndent(response.Example, "", "  ")
+	if err != nil {
+		return route, fmt.Errorf("failed to encode the example response: %w", err)
+	}
+	route.Body = string(body)
+	return route, nil
+}
+
+// mockPathPattern turns an OpenAPI path template into an anchored regular expression in which
+// each parameter matches one path segment, e.g. /pets/{id} into ^/pets/[^/]+$
+func mockPathPattern(path string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, loc := range pathTemplateParam.FindAllStringIndex(path, -1) {
+		b.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		b.WriteString("[^/]+")
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(path[last:]))
+	b.WriteString("$")
+	return b.String()
+}
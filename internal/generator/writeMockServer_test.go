@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateMockServerFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "getPet", Method: "get", Path: "/pets/{id}", Responses: []converter.ResponseTemplate{
+				{StatusCode: 404, ContentType: "application/json", Example: map[string]interface{}{"code": 404}},
+				{StatusCode: 200, ContentType: "application/json", Example: map[string]interface{}{"name": "Rex"}},
+			}},
+			{Name: "getMine", Method: "GET", Path: "/pets/mine", Responses: []converter.ResponseTemplate{
+				{StatusCode: 0, ContentType: "text/plain", Example: "mine"},
+			}},
+			{Name: "deletePet", Method: "DELETE", Path: "/pets/{id}"},
+		},
+	}
+	if err := g.GenerateMockServerFile(config); err != nil {
+		t.Fatalf("GenerateMockServerFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mockserver", "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read mockserver/main.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"// Code generated by mcpgen. DO NOT EDIT.",
+		"package main",
+		`{method: "GET", path: regexp.MustCompile("^/pets/mine$"), status: 200, contentType: "text/plain", body: "mine"},`,
+		`{method: "GET", path: regexp.MustCompile("^/pets/[^/]+$"), status: 200, contentType: "application/json", body: "{\n  \"name\": \"Rex\"\n}"},`,
+		`{method: "DELETE", path: regexp.MustCompile("^/pets/[^/]+$"), status: 204},`,
+		"func serveMock(w http.ResponseWriter, r *http.Request) {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("mockserver/main.go missing %q\n%s", want, strContent)
+		}
+	}
+	if strings.Index(strContent, "^/pets/mine$") > strings.Index(strContent, "^/pets/[^/]+$") {
+		t.Errorf("expected literal paths before templated ones\n%s", strContent)
+	}
+}
+
+func Test_mockPathPattern(t *testing.T) {
+	tests := map[string]string{
+		"/pets":              "^/pets$",
+		"/pets/{id}":         "^/pets/[^/]+$",
+		"/files/{name}.json": `^/files/[^/]+\.json$`,
+		"/a/{x}/b/{y}":       "^/a/[^/]+/b/[^/]+$",
+		"/search+{term}(v1)": `^/search\+[^/]+\(v1\)$`,
+	}
+	for path, want := range tests {
+		if got := mockPathPattern(path); got != want {
+			t.Errorf("mockPathPattern(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
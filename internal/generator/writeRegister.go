@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// GenerateRegisterFile creates a register.go file in the mcptools package exposing RegisterTools
+func (g *Generator) GenerateRegisterFile(config *converter.MCPConfig) error {
+	registerTemplateContent, err := templatesFS.ReadFile("templates/register.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read register template file: %w", err)
+	}
+
+	tmpl, err := template.New("register.templ").Parse(string(registerTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse register template: %w", err)
+	}
+
+	tools := g.buildServerToolData(config)
+	limitResponses := false
+	for _, tool := range tools {
+		if tool.MaxResponseBytes > 0 {
+			limitResponses = true
+			break
+		}
+	}
+
+	data := struct {
+		Tools            []ToolTemplateData
+		LimitResponses   bool
+		ServiceInterface bool
+		mcpImportData
+	}{
+		Tools:            tools,
+		LimitResponses:   limitResponses,
+		ServiceInterface: g.ServiceInterface,
+		mcpImportData:    g.mcpImport(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render register template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated register.go: %w", err)
+	}
+
+	if err := g.writeOutputFile("mcptools", "register.go", false, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write register.go file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateRegisterFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "echo", Description: "Echoes input"},
+			{Name: "reverse", Description: "Reverses input"},
+		},
+	}
+
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"package mcptools",
+		"func RegisterTools(s *server.MCPServer)",
+		"s.AddTool(entry.Tool, entry.Handler)",
+		"Tool:        NewEchoMCPTool(),\n\t\t\tInputSchema: echoInputSchema,\n\t\t\tHandler:     EchoHandler,",
+		"Tool:        NewReverseMCPTool(),\n\t\t\tInputSchema: reverseInputSchema,\n\t\t\tHandler:     ReverseHandler,",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("register.go missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateRegisterFile_NoTools(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	if err := g.GenerateRegisterFile(&converter.MCPConfig{}); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	if !strings.Contains(string(content), "return []ToolEntry{}") {
+		t.Errorf("expected an empty tool table, got:\n%s", content)
+	}
+}
+
+func TestGenerateRegisterFile_ResponseLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir, MaxResponseBytes: 4096}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "echo"},
+			{Name: "search", MaxResponseBytes: 512},
+			{Name: "raw", MaxResponseBytes: -1},
+		},
+	}
+
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"Handler:     limitResponse(EchoHandler, 4096),",
+		"Handler:     limitResponse(SearchHandler, 512),",
+		"Handler:     RawHandler,",
+		"func limitResponse(handler server.ToolHandlerFunc, maxBytes int) server.ToolHandlerFunc",
+		"func truncateUTF8(s string, maxBytes int) string",
+		`"unicode/utf8"`,
+		`"\n[truncated]"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("register.go missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateRegisterFile_NoResponseLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{Tools: []converter.Tool{{Name: "echo"}}}
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	if strings.Contains(string(content), "limitResponse") || strings.Contains(string(content), "unicode/utf8") {
+		t.Errorf("expected no truncation helpers without a limit, got:\n%s", content)
+	}
+}
+
+func TestGenerateRegisterFile_ServiceInterface(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir, ServiceInterface: true, MaxResponseBytes: 64}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "echo", Description: "Echoes input"},
+			{Name: "reverse", Description: "Reverses input"},
+		},
+	}
+
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"type Service interface {",
+		"Echo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)",
+		"Reverse(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)",
+		"type Handlers struct{}",
+		"func (Handlers) Echo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {\n\treturn EchoHandler(ctx, request)",
+		"func RegisterTools(s *server.MCPServer) {\n\tRegisterService(s, Handlers{})",
+		"func RegisterService(s *server.MCPServer, svc Service) {",
+		"func ToolTable() []ToolEntry {\n\treturn ServiceToolTable(Handlers{})",
+		"func ServiceToolTable(svc Service) []ToolEntry {",
+		"Handler:     limitResponse(svc.Echo, 64),",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("register.go missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateRegisterFile_IncludePredicate(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "echo"}, {Name: "reverse", DisplayName: "reverse_text"}},
+	}
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"func RegisterTools(s *server.MCPServer) {\n\tRegisterToolsFunc(s, nil)",
+		"func RegisterToolsFunc(s *server.MCPServer, include func(name string) bool) {",
+		"for _, entry := range ToolTable() {\n\t\tif include == nil || include(entry.Name) {\n\t\t\ts.AddTool(entry.Tool, entry.Handler)",
+		"Name:        \"Echo\",\n\t\t\tTool:        NewEchoMCPTool(),",
+		"Name:        \"reverse_text\",\n\t\t\tTool:        NewReverseMCPTool(),",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("register.go missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateRegisterFile_ToolTable(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "getPet", Method: "GET", Path: "/pets/{id}"}},
+	}
+	if err := g.GenerateRegisterFile(config); err != nil {
+		t.Fatalf("GenerateRegisterFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "register.go"))
+	if err != nil {
+		t.Fatalf("failed to read register.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"type ToolEntry struct {",
+		"Tool        mcp.Tool",
+		"Handler     server.ToolHandlerFunc",
+		"\t\t{\n\t\t\tName:        \"GetPet\",\n\t\t\tTool:        NewGetPetMCPTool(),\n\t\t\tInputSchema: getPetInputSchema,\n" +
+			"\t\t\tHandler:     GetPetHandler,\n\t\t\tMethod:      \"GET\",\n\t\t\tPath:        \"/pets/{id}\",\n\t\t},",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("register.go missing %q\n%s", want, strContent)
+		}
+	}
+}
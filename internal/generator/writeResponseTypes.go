@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// clientPackage is the package GenerateHTTPClient writes to, next to mcptools
+const clientPackage = "apiclient"
+
+// ResponseTypeData is a tool's typed success responses
+type ResponseTypeData struct {
+	ToolNameOriginal string
+	Responses        []TypedResponse
+}
+
+// TypedResponse is a success status code of a tool and the type its body decodes into
+type TypedResponse struct {
+	StatusCode int
+	TypeName   string
+}
+
+// GenerateResponseTypesFile writes mcptools/responses.go with a type per JSON success response of
+// each tool, built from ResponseTemplate.Schema (see ConvertOptions.ResponseSchemas), and a
+// Parse<Tool>Response function decoding a body returned by Send into them. Response schemas
+// referencing a component schema reuse the apiclient type when GenerateHTTPClient wrote types.
+// The file is regenerated as a whole; handlers opt into it, so their edits are unaffected.
+func (g *Generator) GenerateResponseTypesFile(config *converter.MCPConfig) error {
+	responseTypesTemplateContent, err := templatesFS.ReadFile("templates/responses.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read response types template file: %w", err)
+	}
+
+	tmpl, err := template.New("responses.templ").Parse(string(responseTypesTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse response types template: %w", err)
+	}
+
+	builder := newResponseTypeBuilder(g.clientTypes)
+	var tools []ResponseTypeData
+	for _, tool := range config.Tools {
+		if data := builder.addTool(tool); len(data.Responses) > 0 {
+			tools = append(tools, data)
+		}
+	}
+
+	clientImportPath := ""
+	if builder.usesClient {
+		importPath, err := BuildImportPath(g.outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to build import path: %w", err)
+		}
+		clientImportPath = strings.TrimSuffix(importPath, "mcptools") + clientPackage
+	}
+
+	data := struct {
+		Tools            []ResponseTypeData
+		Declarations     []string
+		ClientImportPath string
+		mcpImportData
+	}{
+		Tools:            tools,
+		Declarations:     slices.DeleteFunc(builder.declarations, func(d string) bool { return d == "" }),
+		ClientImportPath: clientImportPath,
+		mcpImportData:    g.mcpImport(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render response types template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated responses.go: %w", err)
+	}
+
+	if err := g.writeOutputFile("mcptools", "responses.go", false, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write responses.go file: %w", err)
+	}
+
+	return nil
+}
+
+// responseTypeBuilder turns converted response schemas into Go type declarations
+type responseTypeBuilder struct {
+	declarations []string
+	names        map[string]bool
+	structs      map[string]bool
+	visiting     map[*converter.Schema]bool
+	// clientTypes reports whether apiclient holds oapi-codegen types; usesClient whether one is used
+	clientTypes bool
+	usesClient  bool
+}
+
+func newResponseTypeBuilder(clientTypes bool) *responseTypeBuilder {
+	return &responseTypeBuilder{
+		names:       make(map[string]bool),
+		structs:     make(map[string]bool),
+		visiting:    make(map[*converter.Schema]bool),
+		clientTypes: clientTypes,
+	}
+}
+
+// addTool declares the types of a tool's success responses, one per status code: when a status
+// code has several JSON content types, the first one's schema is used
+func (b *responseTypeBuilder) addTool(tool converter.Tool) ResponseTypeData {
+	name := capitalizeFirstLetter(tool.Name)
+	data := ResponseTypeData{ToolNameOriginal: name}
+	seen := make(map[int]bool)
+	for _, response := range tool.Responses {
+		if response.Schema == nil || seen[response.StatusCode] {
+			continue
+		}
+		seen[response.StatusCode] = true
+
+		typeName := fmt.Sprintf("%sResponse%d", name, response.StatusCode)
+		doc := fmt.Sprintf("is the body of a %d %s response to the %s tool's request", response.StatusCode, response.ContentType, name)
+		if b.clientTypes && response.SchemaName != "" {
+			b.usesClient = true
+			typeName = b.reserve(typeName)
+			b.declare(fmt.Sprintf("// %s %s (%s)\ntype %s = %s.%s", typeName, doc, response.SchemaName, typeName, clientPackage, codegen.SchemaNameToTypeName(response.SchemaName)))
+		} else {
+			slot := b.placeholder()
+			goType := b.goType(response.Schema, typeName, doc)
+			if b.structs[goType] {
+				typeName = goType
+			} else {
+				typeName = b.reserve(typeName)
+				b.declarations[slot] = fmt.Sprintf("// %s %s\ntype %s %s", typeName, doc, typeName, goType)
+			}
+		}
+		data.Responses = append(data.Responses, TypedResponse{StatusCode: response.StatusCode, TypeName: typeName})
+	}
+	return data
+}
+
+// goType returns the Go type of values of a schema. Objects with properties are declared as
+// structs named name and documented with doc, and their nested objects after their path (e.g.
+// GetPetResponse200Owner). Combinators, mixed types and recursive references decode into any.
+func (b *responseTypeBuilder) goType(s *converter.Schema, name, doc string) string {
+	if s == nil || b.visiting[s] || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0 {
+		return "any"
+	}
+	b.visiting[s] = true
+	defer delete(b.visiting, s)
+
+	var types []string
+	for _, t := range s.Types {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	kind := ""
+	switch {
+	case len(types) == 1:
+		kind = types[0]
+	case len(types) == 0 && s.Object != nil:
+		kind = "object"
+	case len(types) == 0 && s.Array != nil:
+		kind = "array"
+	}
+
+	switch kind {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Array == nil || s.Array.Items == nil {
+			return "[]any"
+		}
+		return "[]" + b.goType(s.Array.Items, name+"Item", "is an item of "+name)
+	case "object":
+		if s.Object == nil || len(s.Object.Properties) == 0 {
+			if s.Object != nil && s.Object.AdditionalProperties != nil {
+				return "map[string]" + b.goType(s.Object.AdditionalProperties, name+"Value", "is a value of "+name)
+			}
+			return "map[string]any"
+		}
+		return b.declareStruct(s, name, doc)
+	}
+	return "any"
+}
+
+// declareStruct declares a struct with a field per property that is not writeOnly, sorted by name.
+// Optional and nullable scalars and structs are pointers so absent values stay absent when the
+// response is encoded again.
+func (b *responseTypeBuilder) declareStruct(s *converter.Schema, name, doc string) string {
+	name = b.reserve(name)
+	b.structs[name] = true
+	slot := b.placeholder()
+	properties := make([]string, 0, len(s.Object.Properties))
+	for property := range s.Object.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	var fields strings.Builder
+	fieldNames := make(map[string]bool)
+	for _, property := range properties {
+		prop := s.Object.Properties[property]
+		if prop != nil && prop.WriteOnly {
+			continue
+		}
+		fieldName := goFieldName(property)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", goFieldName(property), i)
+		}
+		fieldNames[fieldName] = true
+
+		fieldType := b.goType(prop, name+fieldName, fmt.Sprintf("is the %s property of %s", property, name))
+		required := slices.Contains(s.Object.Required, property)
+		nullable := prop != nil && slices.Contains(prop.Types, "null")
+		if (!required || nullable) && !strings.HasPrefix(fieldType, "[]") && !strings.HasPrefix(fieldType, "map[") && fieldType != "any" {
+			fieldType = "*" + fieldType
+		}
+		tag := property
+		if !required {
+			tag += ",omitempty"
+		}
+
+		if prop != nil && prop.Description != "" {
+			fields.WriteString("\t// " + strings.Join(strings.Fields(prop.Description), " ") + "\n")
+		}
+		fields.WriteString(fmt.Sprintf("\t%s %s `json:%q`\n", fieldName, fieldType, tag))
+	}
+
+	comment := "// " + name + " " + doc
+	if description := strings.Join(strings.Fields(s.Description), " "); description != "" {
+		comment += "\n//\n// " + description
+	}
+	b.declarations[slot] = fmt.Sprintf("%s\ntype %s struct {\n%s}", comment, name, fields.String())
+	return name
+}
+
+// reserve returns name, or name with a number appended when it is already declared
+func (b *responseTypeBuilder) reserve(name string) string {
+	unique := name
+	for i := 2; b.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	b.names[unique] = true
+	return unique
+}
+
+func (b *responseTypeBuilder) declare(declaration string) {
+	b.declarations = append(b.declarations, declaration)
+}
+
+// placeholder reserves the position of a declaration written once its nested types are declared,
+// so types come before the types of their fields
+func (b *responseTypeBuilder) placeholder() int {
+	b.declare("")
+	return len(b.declarations) - 1
+}
+
+// goFieldName turns a JSON property name into an exported Go identifier, e.g. created_at into CreatedAt
+func goFieldName(property string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range property {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Field" + name
+	}
+	return name
+}
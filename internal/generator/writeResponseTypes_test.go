@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func petResponseSchema() *converter.Schema {
+	str := &converter.Schema{Types: []string{"string"}}
+	return &converter.Schema{
+		Types:       []string{"object"},
+		Description: "A pet",
+		Object: &converter.ObjectValidation{
+			Required: []string{"id", "owner"},
+			Properties: map[string]*converter.Schema{
+				"id":         {Types: []string{"integer"}, Description: "Unique\nidentifier"},
+				"nick-name":  {Types: []string{"string", "null"}},
+				"password":   {Types: []string{"string"}, WriteOnly: true},
+				"tags":       {Types: []string{"array"}, Array: &converter.ArrayValidation{Items: str}},
+				"attributes": {Types: []string{"object"}, Object: &converter.ObjectValidation{AdditionalProperties: str}},
+				"owner": {Types: []string{"object"}, Object: &converter.ObjectValidation{
+					Properties: map[string]*converter.Schema{"name": str},
+				}},
+				"variant": {OneOf: []*converter.Schema{str, {Types: []string{"integer"}}}},
+			},
+		},
+	}
+}
+
+func TestGenerateResponseTypesFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "getPet", Responses: []converter.ResponseTemplate{
+				{StatusCode: 200, ContentType: "application/json", Schema: petResponseSchema(), SchemaName: "Pet"},
+				{StatusCode: 200, ContentType: "application/hal+json", Schema: &converter.Schema{Types: []string{"string"}}},
+				{StatusCode: 202, ContentType: "application/json", Schema: &converter.Schema{
+					Types: []string{"array"}, Array: &converter.ArrayValidation{Items: &converter.Schema{Types: []string{"number"}}},
+				}},
+				{StatusCode: 404, ContentType: "application/json"},
+			}},
+			{Name: "deletePet", Responses: []converter.ResponseTemplate{{StatusCode: 204}}},
+		},
+	}
+	if err := g.GenerateResponseTypesFile(config); err != nil {
+		t.Fatalf("GenerateResponseTypesFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "responses.go"))
+	if err != nil {
+		t.Fatalf("failed to read responses.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"// Code generated by mcpgen. DO NOT EDIT.",
+		"func JSONResult(value any) (*mcp.CallToolResult, error) {",
+		"// GetPetResponse200 is the body of a 200 application/json response to the GetPet tool's request\n//\n// A pet\ntype GetPetResponse200 struct {",
+		"\tAttributes map[string]string `json:\"attributes,omitempty\"`",
+		"\t// Unique identifier\n\tId       int64                  `json:\"id\"`",
+		"\tNickName *string                `json:\"nick-name,omitempty\"`",
+		"\tOwner    GetPetResponse200Owner `json:\"owner\"`",
+		"\tTags     []string               `json:\"tags,omitempty\"`",
+		"\tVariant  any                    `json:\"variant,omitempty\"`",
+		"// GetPetResponse200Owner is the owner property of GetPetResponse200\ntype GetPetResponse200Owner struct {\n\tName *string `json:\"name,omitempty\"`",
+		"type GetPetResponse202 []float64",
+		"type GetPetResponse struct {\n\tStatusCode int\n\tJSON200    *GetPetResponse200\n\tJSON202    *GetPetResponse202\n}",
+		"func ParseGetPetResponse(resp *http.Response, body []byte) (*GetPetResponse, error) {",
+		"\tcase 202:\n\t\tparsed.JSON202 = new(GetPetResponse202)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("responses.go missing %q\n%s", want, strContent)
+		}
+	}
+	for _, unwanted := range []string{"Password", "GetPetResponse404", "DeletePet", "apiclient"} {
+		if strings.Contains(strContent, unwanted) {
+			t.Errorf("responses.go should not contain %q\n%s", unwanted, strContent)
+		}
+	}
+	if strings.Index(strContent, "type GetPetResponse200 struct") > strings.Index(strContent, "type GetPetResponse200Owner struct") {
+		t.Errorf("expected types to come before the types of their fields\n%s", strContent)
+	}
+}
+
+func TestGenerateResponseTypesFile_ClientTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir, clientTypes: true}
+
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{{Name: "getPet", Responses: []converter.ResponseTemplate{
+			{StatusCode: 200, ContentType: "application/json", Schema: petResponseSchema(), SchemaName: "pet_record"},
+		}}},
+	}
+	if err := g.GenerateResponseTypesFile(config); err != nil {
+		t.Fatalf("GenerateResponseTypesFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "responses.go"))
+	if err != nil {
+		t.Fatalf("failed to read responses.go: %v", err)
+	}
+	strContent := string(content)
+	for _, want := range []string{"/apiclient\"", "type GetPetResponse200 = apiclient.PetRecord"} {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("responses.go missing %q\n%s", want, strContent)
+		}
+	}
+	if strings.Contains(strContent, "GetPetResponse200Owner") {
+		t.Errorf("expected the apiclient type to be reused instead of generating one\n%s", strContent)
+	}
+}
+
+func Test_goFieldName(t *testing.T) {
+	tests := map[string]string{
+		"id":         "Id",
+		"created_at": "CreatedAt",
+		"nick-name":  "NickName",
+		"@type":      "Type",
+		"2fa":        "Field2fa",
+		"":           "Field",
+	}
+	for property, want := range tests {
+		if got := goFieldName(property); got != want {
+			t.Errorf("goFieldName(%q) = %q, want %q", property, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+// defaultRetryBaseDelay is the wait before the first retry when RetryCount is set without a delay
+const defaultRetryBaseDelay = 200 * time.Millisecond
+
+// validateRetries rejects negative retry settings and defaults the base delay when retries are
+// enabled, since a zero delay would retry a failing server in a tight loop
+func (g *Generator) validateRetries() error {
+	if g.RetryCount < 0 {
+		return fmt.Errorf("invalid retry count %d: must not be negative", g.RetryCount)
+	}
+	if g.RetryBaseDelay < 0 {
+		return fmt.Errorf("invalid retry base delay %s: must not be negative", g.RetryBaseDelay)
+	}
+	if g.RetryCount > 0 && g.RetryBaseDelay == 0 {
+		g.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	return nil
+}
+
+// GenerateRuntimeFile creates a runtime.go file in the mcptools package with helpers shared by all tool handlers
+func (g *Generator) GenerateRuntimeFile(config *converter.MCPConfig) error {
+	runtimeTemplateContent, err := templatesFS.ReadFile("templates/runtime.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read runtime template file: %w", err)
+	}
+
+	tmpl, err := template.New("runtime.templ").Parse(string(runtimeTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse runtime template: %w", err)
+	}
+
+	data := struct {
+		RetryCount        int
+		RetryBaseDelay    time.Duration
+		ServerURL         string
+		ServerURLTemplate string
+		ServerVariables   []string
+		APIVersion        string
+		mcpImportData
+	}{
+		RetryCount:     g.RetryCount,
+		RetryBaseDelay: g.RetryBaseDelay,
+		// Route URLs join the server URL without its trailing slash
+		ServerURL:         strings.TrimRight(config.Server.URL, "/"),
+		ServerURLTemplate: commentLine(config.Server.URLTemplate),
+		ServerVariables:   serverVariableDocs(config.Server.Variables),
+		APIVersion:        config.Server.Version,
+		mcpImportData:     g.mcpImport(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render runtime template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated runtime.go: %w", err)
+	}
+
+	if err := g.writeOutputFile("mcptools", "runtime.go", false, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write runtime.go file: %w", err)
+	}
+
+	return nil
+}
+
+// serverVariableDocs describes each server variable on one comment line: the default substituted
+// in ServerURL, the alternatives its enum allows and its description,
+// e.g. "region: us (default), eu or ap. Where the data is stored"
+func serverVariableDocs(variables []converter.ServerVariable) []string {
+	docs := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		doc := variable.Name + ": " + variable.Default + " (default)"
+		var alternatives []string
+		for _, value := range variable.Enum {
+			if value != variable.Default {
+				alternatives = append(alternatives, value)
+			}
+		}
+		switch len(alternatives) {
+		case 0:
+		case 1:
+			doc += " or " + alternatives[0]
+		default:
+			doc += ", " + strings.Join(alternatives[:len(alternatives)-1], ", ") + " or " + alternatives[len(alternatives)-1]
+		}
+		if variable.Description != "" {
+			doc += ". " + variable.Description
+		}
+		docs = append(docs, commentLine(doc))
+	}
+	return docs
+}
+
+// commentLine collapses whitespace, including newlines, so text fits on a single comment line
+func commentLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
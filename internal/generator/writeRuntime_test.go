@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lyeskara/testmcp/internal/converter"
+)
+
+func TestGenerateRuntimeFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	if err := g.GenerateRuntimeFile(&converter.MCPConfig{}); err != nil {
+		t.Fatalf("GenerateRuntimeFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "runtime.go"))
+	if err != nil {
+		t.Fatalf("failed to read runtime.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"package mcptools",
+		"func ResolveHeaders(headers map[string]string) map[string]string",
+		"os.Getenv",
+		"var HTTPClient = http.DefaultClient",
+		"func ProblemResult(contentType string, body []byte) (*mcp.CallToolResult, bool)",
+		"func ErrorResult(statusCode int, contentType string, body []byte, errorFields map[int][]string) *mcp.CallToolResult",
+		"func FormatResponse(contentType string, body []byte, documented []string) *mcp.CallToolResult",
+		"Logger *slog.Logger",
+		`const LogLevelEnv = "LOG_LEVEL"`,
+		"Logger: NewLogger(os.Getenv(LogLevelEnv))",
+		"func NewLogger(level string) *slog.Logger {",
+		"func Logger() *slog.Logger {",
+		"func BuildRequest(ctx context.Context, route Route, args map[string]interface{}) (*http.Request, error)",
+		"func encodeQuery(query url.Values, reserved map[string]bool) string",
+		"func Send(ctx context.Context, route Route, args map[string]interface{}) (*http.Response, []byte, error)",
+		"type Config struct {",
+		"func DefaultConfig() Config {",
+		"func Configure(config Config) {",
+		"const ServerURL = \"\"",
+		"json.Indent(&indented, body, \"\", \"  \")",
+		"strings.HasPrefix(mediaType, \"text/\")",
+		"base64.StdEncoding.EncodeToString(body)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("runtime.go missing %q\n%s", want, strContent)
+		}
+	}
+}
+
+func TestGenerateRuntimeFile_ServerURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{Server: converter.ServerConfig{URL: "https://api.example.com/v1/", Version: "1.2.0"}}
+	if err := g.GenerateRuntimeFile(config); err != nil {
+		t.Fatalf("GenerateRuntimeFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "runtime.go"))
+	if err != nil {
+		t.Fatalf("failed to read runtime.go: %v", err)
+	}
+	for _, want := range []string{
+		`const ServerURL = "https://api.example.com/v1"`,
+		`const APIVersion = "1.2.0"`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("runtime.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateRuntimeFile_ServerVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	config := &converter.MCPConfig{Server: converter.ServerConfig{
+		URL:         "https://us.api.example.com:443",
+		URLTemplate: "https://{region}.api.example.com:{port}",
+		Variables: []converter.ServerVariable{
+			{Name: "port", Default: "443"},
+			{Name: "region", Default: "us", Enum: []string{"eu", "us", "ap"}, Description: "Where the\ndata is stored"},
+		},
+	}}
+	if err := g.GenerateRuntimeFile(config); err != nil {
+		t.Fatalf("GenerateRuntimeFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "runtime.go"))
+	if err != nil {
+		t.Fatalf("failed to read runtime.go: %v", err)
+	}
+	want := "// The spec declares it as https://{region}.api.example.com:{port}, with these variables:\n" +
+		"//   - port: 443 (default)\n" +
+		"//   - region: us (default), eu or ap. Where the data is stored\n" +
+		"//\n" +
+		"// To use other values, set Config.BaseURL to the URL with them substituted.\n" +
+		"const ServerURL = \"https://us.api.example.com:443\""
+	if !strings.Contains(string(content), want) {
+		t.Errorf("runtime.go missing %q\n%s", want, content)
+	}
+}
+
+func TestGenerateRuntimeFile_Retries(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir, RetryCount: 3, RetryBaseDelay: 1500 * time.Microsecond}
+
+	if err := g.GenerateRuntimeFile(&converter.MCPConfig{}); err != nil {
+		t.Fatalf("GenerateRuntimeFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "runtime.go"))
+	if err != nil {
+		t.Fatalf("failed to read runtime.go: %v", err)
+	}
+	strContent := string(content)
+
+	expected := []string{
+		"Transport: &RetryTransport{",
+		"MaxRetries: 3,",
+		"BaseDelay:  1500000, // 1.5ms",
+		"http.MethodPut:     true,",
+		"func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("runtime.go missing %q\n%s", want, strContent)
+		}
+	}
+	if strings.Contains(strContent, "http.MethodPost") {
+		t.Errorf("POST must not be retried by default")
+	}
+}
+
+func TestGenerateRuntimeFile_NoRetries(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{outputDir: tmpDir}
+
+	if err := g.GenerateRuntimeFile(&converter.MCPConfig{}); err != nil {
+		t.Fatalf("GenerateRuntimeFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "mcptools", "runtime.go"))
+	if err != nil {
+		t.Fatalf("failed to read runtime.go: %v", err)
+	}
+	if strings.Contains(string(content), "RetryTransport") {
+		t.Errorf("expected no retry scaffolding when RetryCount is 0")
+	}
+}
+
+func TestValidateRetries(t *testing.T) {
+	for _, g := range []*Generator{{RetryCount: -1}, {RetryCount: 1, RetryBaseDelay: -time.Second}} {
+		if err := g.validateRetries(); err == nil {
+			t.Errorf("expected an error for RetryCount %d, RetryBaseDelay %s", g.RetryCount, g.RetryBaseDelay)
+		}
+	}
+
+	g := &Generator{RetryCount: 2}
+	if err := g.validateRetries(); err != nil {
+		t.Fatalf("validateRetries() error = %v", err)
+	}
+	if g.RetryBaseDelay != defaultRetryBaseDelay {
+		t.Errorf("RetryBaseDelay = %s, want %s", g.RetryBaseDelay, defaultRetryBaseDelay)
+	}
+}
@@ -4,13 +4,34 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"slices"
+	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/lyeskara/testmcp/internal/converter"
 )
 
+// Transports the generated server can be served over
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
+// Default probe paths mounted next to the MCP endpoint by HTTP-based transports
+const (
+	defaultHealthPath = "/healthz"
+	defaultReadyPath  = "/readyz"
+)
+
 // GenerateServerFile creates a server.go file in the same package as the tools
 func (g *Generator) GenerateServerFile(config *converter.MCPConfig) error {
+	transport, healthPath, readyPath, err := g.serverTransport()
+	if err != nil {
+		return err
+	}
+
 	serverTemplateContent, err := templatesFS.ReadFile("templates/server.templ")
 	if err != nil {
 		return fmt.Errorf("failed to read server template file: %w", err)
@@ -30,21 +51,22 @@ func (g *Generator) GenerateServerFile(config *converter.MCPConfig) error {
 		PackageName        string
 		MCPToolsImportPath string
 		Tools              []ToolTemplateData
+		ServiceInterface   bool
+		Tracing            bool
+		Transport          string
+		HealthPath         string
+		ReadyPath          string
+		mcpImportData
 	}{
 		PackageName:        g.PackageName,
-		Tools:              make([]ToolTemplateData, 0, len(config.Tools)),
+		Tools:              g.buildServerToolData(config),
 		MCPToolsImportPath: importPath,
-	}
-
-	for _, tool := range config.Tools {
-		capitalizedName := capitalizeFirstLetter(tool.Name)
-
-		data.Tools = append(data.Tools, ToolTemplateData{
-			ToolNameOriginal: capitalizedName,
-			ToolNameGo:       capitalizedName,
-			ToolHandlerName:  capitalizedName + "Handler",
-			ToolDescription:  tool.Description,
-		})
+		ServiceInterface:   g.ServiceInterface,
+		Tracing:            g.Tracing,
+		Transport:          transport,
+		HealthPath:         healthPath,
+		ReadyPath:          readyPath,
+		mcpImportData:      g.mcpImport(),
 	}
 
 	var buf bytes.Buffer
@@ -57,7 +79,7 @@ func (g *Generator) GenerateServerFile(config *converter.MCPConfig) error {
 		return fmt.Errorf("failed to format generated server.go: %w", err)
 	}
 
-	if err := writeFileContent(g.outputDir, "server.go", func() ([]byte, error) {
+	if err := g.writeOutputFile("", "server.go", false, func() ([]byte, error) {
 		return formattedCode, nil
 	}); err != nil {
 		return fmt.Errorf("failed to write server.go file: %w", err)
@@ -65,3 +87,92 @@ func (g *Generator) GenerateServerFile(config *converter.MCPConfig) error {
 
 	return nil
 }
+
+// serverTransport validates the transport settings and fills in their defaults
+func (g *Generator) serverTransport() (transport, healthPath, readyPath string, err error) {
+	transport = g.Transport
+	if transport == "" {
+		transport = TransportStdio
+	}
+	if transport != TransportStdio && transport != TransportSSE && transport != TransportHTTP {
+		return "", "", "", fmt.Errorf("unknown transport %q: use %s, %s or %s", transport, TransportStdio, TransportSSE, TransportHTTP)
+	}
+
+	healthPath, readyPath = g.HealthPath, g.ReadyPath
+	if healthPath == "" {
+		healthPath = defaultHealthPath
+	}
+	if readyPath == "" {
+		readyPath = defaultReadyPath
+	}
+	for _, path := range []string{healthPath, readyPath} {
+		if !strings.HasPrefix(path, "/") {
+			return "", "", "", fmt.Errorf("invalid probe path %q: it must start with /", path)
+		}
+	}
+	if healthPath == readyPath {
+		return "", "", "", fmt.Errorf("health and readiness paths must differ, both are %q", healthPath)
+	}
+	return transport, healthPath, readyPath, nil
+}
+
+// buildServerToolData collects the per-tool data needed to register tools on a server, in
+// registration order (see sortTools)
+func (g *Generator) buildServerToolData(config *converter.MCPConfig) []ToolTemplateData {
+	tools := make([]ToolTemplateData, 0, len(config.Tools))
+	for _, tool := range g.sortTools(config.Tools) {
+		capitalizedName := capitalizeFirstLetter(tool.Name)
+
+		tools = append(tools, ToolTemplateData{
+			ToolNameOriginal:   capitalizedName,
+			ToolNameGo:         capitalizedName,
+			ToolNameRegistered: tool.RegisteredName(),
+			ToolHandlerName:    capitalizedName + "Handler",
+			ToolDescription:    tool.Description,
+			InputSchemaConst:   fmt.Sprintf("%sInputSchema", tool.Name),
+			Method:             tool.Method,
+			Path:               tool.Path,
+			MaxResponseBytes:   g.responseLimit(tool),
+		})
+	}
+	return tools
+}
+
+// sortTools returns a copy of tools ordered by name, or by primary tag and then name when
+// SortByTag is set. Untagged tools sort after tagged ones so the order never depends on the
+// order of operations in the spec.
+func (g *Generator) sortTools(tools []converter.Tool) []converter.Tool {
+	sorted := slices.Clone(tools)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if g.SortByTag {
+			ti, tj := primaryTag(sorted[i]), primaryTag(sorted[j])
+			if (ti == "") != (tj == "") {
+				return tj == ""
+			}
+			if ti != tj {
+				return ti < tj
+			}
+		}
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].RegisteredName() < sorted[j].RegisteredName()
+	})
+	return sorted
+}
+
+// primaryTag returns the first tag of a tool, or "" when it has none
+func primaryTag(tool converter.Tool) string {
+	if len(tool.Tags) == 0 {
+		return ""
+	}
+	return tool.Tags[0]
+}
+
+// responseLimit returns the effective response size limit for a tool
+func (g *Generator) responseLimit(tool converter.Tool) int {
+	if tool.MaxResponseBytes != 0 {
+		return tool.MaxResponseBytes
+	}
+	return g.MaxResponseBytes
+}
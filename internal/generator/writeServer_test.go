@@ -4,9 +4,12 @@ import (
 	"go/format"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"text/template"
+
+	"github.com/lyeskara/testmcp/internal/converter"
 )
 
 func Test_RenderAndWriteServerTemplate(t *testing.T) {
@@ -38,10 +41,18 @@ func Test_RenderAndWriteServerTemplate(t *testing.T) {
 		PackageName        string
 		MCPToolsImportPath string
 		Tools              []ToolTemplateData
+		ServiceInterface   bool
+		Tracing            bool
+		Transport          string
+		HealthPath         string
+		ReadyPath          string
+		mcpImportData
 	}{
 		PackageName:        "mytools",
 		MCPToolsImportPath: "github.com/example/project/mcptools",
 		Tools:              tools,
+		Transport:          TransportStdio,
+		mcpImportData:      (&Generator{}).mcpImport(),
 	}
 
 	// Parse and render the template
@@ -75,11 +86,244 @@ func Test_RenderAndWriteServerTemplate(t *testing.T) {
 	}
 	strContent := string(content)
 
-	// Check for package declaration and handler names
+	// Check for package declaration and delegated tool registration
 	if !strings.Contains(strContent, "package mytools") {
 		t.Errorf("Generated file missing package declaration")
 	}
-	if !strings.Contains(strContent, "EchoHandler") || !strings.Contains(strContent, "ReverseHandler") {
-		t.Errorf("Generated file missing expected handler names")
+	if !strings.Contains(strContent, "mcptools.RegisterToolsFunc(s, IncludeTool)") {
+		t.Errorf("Generated file missing RegisterToolsFunc call")
+	}
+
+	// Check for the hook and middleware extension points
+	for _, want := range []string{
+		"var Hooks = newLoggingHooks()",
+		`mcptools.Logger().InfoContext(ctx, "tool call", "tool", request.Params.Name, "id", id)`,
+		`mcptools.Logger().ErrorContext(ctx, "request failed", "method", string(method), "id", id, "error", err)`,
+		"var ToolMiddlewares []server.ToolHandlerMiddleware",
+		"var IncludeTool func(name string) bool",
+		"server.WithHooks(Hooks)",
+		"server.WithToolHandlerMiddleware(middleware)",
+		"func NewMCPServer(config mcptools.Config) *server.MCPServer",
+		"mcptools.Configure(config)",
+	} {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("Generated file missing %q", want)
+		}
+	}
+}
+
+func TestGenerateServerFile_ServiceInterface(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, ServiceInterface: true}
+
+	config := &converter.MCPConfig{Tools: []converter.Tool{{Name: "echo"}}}
+	if err := g.GenerateServerFile(config); err != nil {
+		t.Fatalf("GenerateServerFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "server.go"))
+	if err != nil {
+		t.Fatalf("failed to read server.go: %v", err)
+	}
+	strContent := string(content)
+
+	for _, want := range []string{
+		"var Service mcptools.Service = mcptools.Handlers{}",
+		"mcptools.RegisterServiceFunc(s, Service, IncludeTool)",
+	} {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("server.go missing %q\n%s", want, strContent)
+		}
+	}
+	if strings.Contains(strContent, "mcptools.RegisterToolsFunc(") {
+		t.Errorf("expected registration through Service\n%s", strContent)
+	}
+}
+
+func TestGenerateServerFile_Capabilities(t *testing.T) {
+	tests := []struct {
+		name  string
+		tools []converter.Tool
+		want  bool
+	}{
+		{"with tools", []converter.Tool{{Name: "echo"}}, true},
+		{"without tools", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			g := &Generator{PackageName: "mytools", outputDir: tmpDir}
+			if err := g.GenerateServerFile(&converter.MCPConfig{Tools: tt.tools}); err != nil {
+				t.Fatalf("GenerateServerFile failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "server.go"))
+			if err != nil {
+				t.Fatalf("failed to read server.go: %v", err)
+			}
+			strContent := string(content)
+
+			if got := strings.Contains(strContent, "server.WithToolCapabilities(true),"); got != tt.want {
+				t.Errorf("tool capabilities declared = %v, want %v\n%s", got, tt.want, strContent)
+			}
+			for _, unwanted := range []string{"WithResourceCapabilities", "WithPromptCapabilities", "WithLogging"} {
+				if strings.Contains(strContent, unwanted) {
+					t.Errorf("server.go should not declare %s\n%s", unwanted, strContent)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateServerFile_Tracing(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, Tracing: true}
+
+	config := &converter.MCPConfig{Tools: []converter.Tool{{Name: "getPet", Method: "GET", Path: "/pets/{id}"}}}
+	if err := g.GenerateServerFile(config); err != nil {
+		t.Fatalf("GenerateServerFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "server.go"))
+	if err != nil {
+		t.Fatalf("failed to read server.go: %v", err)
+	}
+	strContent := string(content)
+
+	for _, want := range []string{
+		"var Tracer trace.Tracer",
+		`"GetPet": {"GET", "/pets/{id}"},`,
+		`Tracer.Start(ctx, "tools/call "+name, trace.WithAttributes(attributes...))`,
+		"opts = append(opts, server.WithToolHandlerMiddleware(traceToolCall))",
+	} {
+		if !strings.Contains(strContent, want) {
+			t.Errorf("server.go missing %q\n%s", want, strContent)
+		}
+	}
+	if strings.Index(strContent, "WithToolHandlerMiddleware(traceToolCall)") > strings.Index(strContent, "range ToolMiddlewares") {
+		t.Errorf("expected the tracing middleware before the user's middlewares\n%s", strContent)
+	}
+
+	// Without the option nothing depends on OpenTelemetry
+	g = &Generator{PackageName: "mytools", outputDir: tmpDir}
+	if err := g.GenerateServerFile(config); err != nil {
+		t.Fatalf("GenerateServerFile failed: %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(tmpDir, "server.go"))
+	if err != nil {
+		t.Fatalf("failed to read server.go: %v", err)
+	}
+	if strings.Contains(string(content), "opentelemetry") {
+		t.Errorf("expected no OpenTelemetry import without Tracing\n%s", content)
+	}
+}
+
+func TestGenerateServerFile_Transports(t *testing.T) {
+	tests := []struct {
+		name    string
+		g       Generator
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "stdio has no probes",
+			g:       Generator{},
+			notWant: []string{"net/http", "HealthPath", "NewHTTPHandler"},
+		},
+		{
+			name: "sse with default paths",
+			g:    Generator{Transport: TransportSSE},
+			want: []string{
+				`HealthPath = "/healthz"`,
+				`ReadyPath  = "/readyz"`,
+				"func NewHTTPHandler(s *server.MCPServer) http.Handler",
+				`mux.Handle("/", server.NewSSEServer(s))`,
+				"http.StatusServiceUnavailable",
+				"func ListenAndServe(addr string, config mcptools.Config) error",
+			},
+		},
+		{
+			name: "streamable http with custom paths",
+			g:    Generator{Transport: TransportHTTP, HealthPath: "/live", ReadyPath: "/ready"},
+			want: []string{
+				`HealthPath = "/live"`,
+				`ReadyPath  = "/ready"`,
+				`mux.Handle("/mcp", server.NewStreamableHTTPServer(s))`,
+			},
+			notWant: []string{"NewSSEServer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			g := tt.g
+			g.PackageName, g.outputDir = "mytools", tmpDir
+
+			if err := g.GenerateServerFile(&converter.MCPConfig{Tools: []converter.Tool{{Name: "echo"}}}); err != nil {
+				t.Fatalf("GenerateServerFile failed: %v", err)
+			}
+			content, err := os.ReadFile(filepath.Join(tmpDir, "server.go"))
+			if err != nil {
+				t.Fatalf("failed to read server.go: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("server.go missing %q\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("server.go unexpectedly contains %q\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateServerFile_InvalidTransport(t *testing.T) {
+	for _, g := range []Generator{
+		{Transport: "websocket"},
+		{Transport: TransportHTTP, HealthPath: "healthz"},
+		{Transport: TransportHTTP, HealthPath: "/probe", ReadyPath: "/probe"},
+	} {
+		g.PackageName, g.outputDir = "mytools", t.TempDir()
+		if err := g.GenerateServerFile(&converter.MCPConfig{}); err == nil {
+			t.Errorf("expected an error for transport %q with paths %q/%q", g.Transport, g.HealthPath, g.ReadyPath)
+		}
+	}
+}
+
+func TestBuildServerToolData_Order(t *testing.T) {
+	config := &converter.MCPConfig{
+		Tools: []converter.Tool{
+			{Name: "listUsers", Tags: []string{"users"}},
+			{Name: "ping"},
+			{Name: "getPet", Tags: []string{"pets", "users"}},
+			{Name: "addPet", Tags: []string{"pets"}},
+			{Name: "health"},
+		},
+	}
+	registered := func(tools []ToolTemplateData) []string {
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.ToolNameRegistered)
+		}
+		return names
+	}
+
+	g := &Generator{}
+	want := []string{"AddPet", "GetPet", "Health", "ListUsers", "Ping"}
+	if got := registered(g.buildServerToolData(config)); !slices.Equal(got, want) {
+		t.Errorf("default order = %v, want %v", got, want)
+	}
+
+	g.SortByTag = true
+	want = []string{"AddPet", "GetPet", "ListUsers", "Health", "Ping"}
+	if got := registered(g.buildServerToolData(config)); !slices.Equal(got, want) {
+		t.Errorf("order by tag = %v, want %v", got, want)
+	}
+	if config.Tools[0].Name != "listUsers" {
+		t.Errorf("expected the config's tools to be left in place, got %s first", config.Tools[0].Name)
 	}
 }
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+// GenerateTestClientFile creates a testclient.go file next to server.go with NewTestClient, which
+// connects a client to the generated server in memory for integration tests
+func (g *Generator) GenerateTestClientFile() error {
+	testClientTemplateContent, err := templatesFS.ReadFile("templates/testclient.templ")
+	if err != nil {
+		return fmt.Errorf("failed to read test client template file: %w", err)
+	}
+
+	tmpl, err := template.New("testclient.templ").Parse(string(testClientTemplateContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse test client template: %w", err)
+	}
+
+	importPath, err := BuildImportPath(g.outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to build import path: %w", err)
+	}
+
+	data := struct {
+		PackageName        string
+		MCPToolsImportPath string
+		mcpImportData
+	}{
+		PackageName:        g.PackageName,
+		MCPToolsImportPath: importPath,
+		mcpImportData:      g.mcpImport(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render test client template: %w", err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated testclient.go: %w", err)
+	}
+
+	if err := g.writeOutputFile("", "testclient.go", false, func() ([]byte, error) {
+		return formattedCode, nil
+	}); err != nil {
+		return fmt.Errorf("failed to write testclient.go file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMCP_TestClient(t *testing.T) {
+	tmpDir := t.TempDir()
+	g := &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: onlyOperationTestConfig()}, TestClient: true}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "testclient.go"))
+	if err != nil {
+		t.Fatalf("failed to read testclient.go: %v", err)
+	}
+	for _, want := range []string{
+		"package mytools",
+		`"github.com/mark3labs/mcp-go/client"`,
+		"func NewTestClient(ctx context.Context, config mcptools.Config) (*client.Client, error) {",
+		"client.NewInProcessClient(NewMCPServer(config))",
+		"c.Initialize(ctx, request)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("testclient.go missing %q\n%s", want, content)
+		}
+	}
+
+	// Opt-in only
+	tmpDir = t.TempDir()
+	g = &Generator{PackageName: "mytools", outputDir: tmpDir, converter: &testConverter{config: onlyOperationTestConfig()}}
+	if err := g.GenerateMCP(); err != nil {
+		t.Fatalf("GenerateMCP failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "testclient.go")); !os.IsNotExist(err) {
+		t.Errorf("expected no testclient.go by default, stat error = %v", err)
+	}
+}